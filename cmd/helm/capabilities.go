@@ -0,0 +1,86 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/spf13/pflag"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// capabilityFlags overrides the cluster capabilities a chart is rendered against.
+//
+// The file is read first; the individual flags take precedence over it.
+type capabilityFlags struct {
+	file          string
+	kubeVersion   string
+	apiVersions   []string
+	tillerVersion string
+}
+
+func (c *capabilityFlags) addFlags(f *pflag.FlagSet) {
+	f.StringVar(&c.file, "capabilities", "", "render against the capabilities (kubeVersion, apiVersions, tillerVersion) described in a YAML file")
+	f.StringVar(&c.kubeVersion, "kube-version", "", "render against this Kubernetes version, e.g. 1.8")
+	f.StringArrayVar(&c.apiVersions, "api-versions", []string{}, "render against this set of API versions instead of the discovered ones (can specify multiple)")
+	f.StringVar(&c.tillerVersion, "tiller-version", "", "render against this Tiller version")
+}
+
+// override returns the capabilities set through the flags, or nil if none were set.
+func (c *capabilityFlags) override() (*chartutil.Capabilities, error) {
+	if c.file == "" && c.kubeVersion == "" && len(c.apiVersions) == 0 && c.tillerVersion == "" {
+		return nil, nil
+	}
+
+	caps := &chartutil.Capabilities{}
+	if c.file != "" {
+		var err error
+		if caps, err = chartutil.ReadCapabilitiesFile(c.file); err != nil {
+			return nil, err
+		}
+	}
+	if len(c.apiVersions) > 0 {
+		caps.APIVersions = chartutil.NewVersionSet(c.apiVersions...)
+	}
+	if c.kubeVersion != "" {
+		kv, err := chartutil.ParseKubeVersion(c.kubeVersion)
+		if err != nil {
+			return nil, err
+		}
+		caps.KubeVersion = kv
+	}
+	if c.tillerVersion != "" {
+		tv, err := chartutil.ParseTillerVersion(c.tillerVersion)
+		if err != nil {
+			return nil, err
+		}
+		caps.TillerVersion = tv
+	}
+	return caps, nil
+}
+
+// dryRunOverride is like override, but refuses to override the capabilities
+// of anything other than a dry run.
+func (c *capabilityFlags) dryRunOverride(dryRun bool) (*chartutil.Capabilities, error) {
+	caps, err := c.override()
+	if err != nil {
+		return nil, err
+	}
+	if caps != nil && !dryRun {
+		return nil, chartutil.ErrCapabilitiesNotDryRun
+	}
+	return caps, nil
+}
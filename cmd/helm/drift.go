@@ -0,0 +1,111 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var driftHelp = `
+This command compares the resources of the deployed revision of a release
+with their live state in the cluster, and reports the resources that are
+missing and the fields whose values differ.
+
+Only the fields that the chart sets are compared, so fields filled in by
+Kubernetes, such as the status of a resource, are not reported. The data of
+Secrets, and the values that the chart marks as sensitive, are masked.
+
+The command exits with status 0 when the cluster matches the release, 3 when
+it does not, and 1 on errors, so that it can be used to monitor releases.
+`
+
+// driftExitCode is the exit status of 'helm drift' when a release drifted.
+const driftExitCode = 3
+
+type driftCmd struct {
+	release string
+	out     io.Writer
+	client  helm.Interface
+}
+
+func newDriftCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	drift := &driftCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "drift [flags] RELEASE_NAME",
+		Short:   "compare the resources of a release with the cluster",
+		Long:    driftHelp,
+		PreRunE: setupConnection,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errReleaseRequired
+			}
+			drift.release = args[0]
+			drift.client = ensureHelmClient(drift.client)
+			return drift.run()
+		},
+	}
+	return cmd
+}
+
+func (d *driftCmd) run() error {
+	res, err := d.client.ReleaseDrift(d.release)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	printDrift(d.out, res)
+	if len(res.Resources) > 0 {
+		return exitError{
+			error: fmt.Errorf("release %s has drifted from revision %d", res.Name, res.Version),
+			code:  driftExitCode,
+		}
+	}
+	return nil
+}
+
+func printDrift(out io.Writer, res *services.GetReleaseDriftResponse) {
+	if len(res.Resources) == 0 {
+		fmt.Fprintf(out, "The resources of %s match revision %d.\n", res.Name, res.Version)
+		return
+	}
+	fmt.Fprintf(out, "The resources of %s differ from revision %d:\n", res.Name, res.Version)
+	for _, r := range res.Resources {
+		fmt.Fprintf(out, "\n%s/%s (namespace %s)", r.Kind, r.Name, r.Namespace)
+		if r.Missing {
+			fmt.Fprintf(out, ": missing\n")
+			continue
+		}
+		fmt.Fprintln(out)
+		for _, f := range r.Fields {
+			actual := f.Actual
+			if actual == "" {
+				actual = "<unset>"
+			}
+			fmt.Fprintf(out, "  %s: expected %s, got %s\n", f.Path, f.Expected, actual)
+		}
+	}
+}
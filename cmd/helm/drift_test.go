@@ -0,0 +1,94 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestDriftCmd(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		drifts   []*services.ResourceDrift
+		err      error
+		expected string
+		code     int
+	}{
+		{
+			name:     "in sync",
+			args:     []string{"flummoxed-chickadee"},
+			expected: "The resources of flummoxed-chickadee match revision 1.\n",
+		},
+		{
+			name: "drifted",
+			args: []string{"flummoxed-chickadee"},
+			drifts: []*services.ResourceDrift{
+				{Kind: "Deployment", Namespace: "default", Name: "web", Fields: []*services.FieldDrift{
+					{Path: "spec.replicas", Expected: "2", Actual: "0"},
+					{Path: "metadata.labels.tier", Expected: `"front"`},
+				}},
+				{Kind: "Service", Namespace: "default", Name: "web", Missing: true},
+			},
+			expected: `The resources of flummoxed-chickadee differ from revision 1:
+
+Deployment/web (namespace default)
+  spec.replicas: expected 2, got 0
+  metadata.labels.tier: expected "front", got <unset>
+
+Service/web (namespace default): missing
+`,
+			code: driftExitCode,
+		},
+		{
+			name: "without a release",
+			code: 1,
+		},
+		{
+			name: "failing",
+			args: []string{"flummoxed-chickadee"},
+			err:  errors.New("connection refused"),
+			code: 1,
+		},
+	}
+
+	var buf bytes.Buffer
+	for _, tt := range tests {
+		c := &helm.FakeClient{Drifts: tt.drifts, Err: tt.err}
+		cmd := newDriftCmd(c, &buf)
+		err := cmd.RunE(cmd, tt.args)
+
+		code := 0
+		if e, ok := err.(exitError); ok {
+			code = e.code
+		} else if err != nil {
+			code = 1
+		}
+		if code != tt.code {
+			t.Errorf("%q. expected exit code %d, got %d (%v)", tt.name, tt.code, code, err)
+		}
+		if tt.expected != "" && buf.String() != tt.expected {
+			t.Errorf("%q. expected\n%s\ngot\n%s", tt.name, tt.expected, buf.String())
+		}
+		buf.Reset()
+	}
+}
@@ -36,6 +36,9 @@ It can be used to get extended information about the release, including:
 
 By default, this prints a human readable collection of information about the
 chart, the supplied values, and the generated manifest file.
+
+The values that the chart marks as sensitive, and the data of Secrets, are
+masked unless '--show-secrets' is set.
 `
 
 var errReleaseRequired = errors.New("release name is required")
@@ -45,6 +48,8 @@ type getCmd struct {
 	out     io.Writer
 	client  helm.Interface
 	version int32
+
+	showSecrets bool
 }
 
 func newGetCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -71,6 +76,7 @@ func newGetCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	}
 
 	cmd.Flags().Int32Var(&get.version, "revision", 0, "get the named release with revision")
+	cmd.Flags().BoolVar(&get.showSecrets, "show-secrets", false, "do not mask the values that the chart marks as sensitive")
 
 	cmd.AddCommand(newGetValuesCmd(nil, out))
 	cmd.AddCommand(newGetManifestCmd(nil, out))
@@ -85,5 +91,5 @@ func (g *getCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
-	return printRelease(g.out, res.Release)
+	return printRelease(g.out, res.Release, g.showSecrets)
 }
@@ -28,14 +28,26 @@ import (
 
 var getValuesHelp = `
 This command downloads a values file for a given release.
+
+With '--explain', the computed values are printed along with where each came
+from: the user-supplied values, or the defaults of the chart or one of its
+dependencies. The defaults are the ones the release was deployed with, in
+which the values file, the '--environment' values and import-values are
+already merged. Use 'helm template --explain-values' on the chart to tell
+those apart.
+
+The values that the chart marks as sensitive are masked unless
+'--show-secrets' is set.
 `
 
 type getValuesCmd struct {
-	release   string
-	allValues bool
-	out       io.Writer
-	client    helm.Interface
-	version   int32
+	release     string
+	allValues   bool
+	explain     bool
+	showSecrets bool
+	out         io.Writer
+	client      helm.Interface
+	version     int32
 }
 
 func newGetValuesCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -60,6 +72,8 @@ func newGetValuesCmd(client helm.Interface, out io.Writer) *cobra.Command {
 
 	cmd.Flags().Int32Var(&get.version, "revision", 0, "get the named release with revision")
 	cmd.Flags().BoolVarP(&get.allValues, "all", "a", false, "dump all (computed) values")
+	cmd.Flags().BoolVar(&get.explain, "explain", false, "print where each computed value came from")
+	cmd.Flags().BoolVar(&get.showSecrets, "show-secrets", false, "do not mask the values that the chart marks as sensitive")
 	return cmd
 }
 
@@ -69,6 +83,19 @@ func (g *getValuesCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
+	red, err := newRedactor(res.Release, g.showSecrets)
+	if err != nil {
+		return err
+	}
+
+	if g.explain {
+		_, origins, err := chartutil.ExplainReleaseValues(res.Release.Chart, res.Release.Config)
+		if err != nil {
+			return err
+		}
+		printValueOrigins(g.out, red.Origins(origins))
+		return nil
+	}
 
 	// If the user wants all values, compute the values and return.
 	if g.allValues {
@@ -76,7 +103,7 @@ func (g *getValuesCmd) run() error {
 		if err != nil {
 			return err
 		}
-		cfgStr, err := cfg.YAML()
+		cfgStr, err := chartutil.Values(red.Values(cfg)).YAML()
 		if err != nil {
 			return err
 		}
@@ -84,6 +111,10 @@ func (g *getValuesCmd) run() error {
 		return nil
 	}
 
-	fmt.Fprintln(g.out, res.Release.Config.Raw)
+	cfg, err := red.Config(res.Release.Config)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(g.out, cfg.Raw)
 	return nil
 }
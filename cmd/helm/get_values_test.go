@@ -23,8 +23,18 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 )
 
+var sensitiveChart = &chart.Chart{
+	Metadata: &chart.Metadata{Name: "foo", Version: "0.1.0", Sensitive: []string{"name"}},
+}
+
+var defaultsChart = &chart.Chart{
+	Metadata: &chart.Metadata{Name: "foo", Version: "0.1.0"},
+	Values:   &chart.Config{Raw: "replicas: 3\n"},
+}
+
 func TestGetValuesCmd(t *testing.T) {
 	tests := []releaseCase{
 		{
@@ -33,6 +43,47 @@ func TestGetValuesCmd(t *testing.T) {
 			args:     []string{"thomas-guide"},
 			expected: "name: \"value\"",
 		},
+		{
+			name:     "get values with explanations",
+			resp:     releaseMock(&releaseOptions{name: "thomas-guide"}),
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--explain"},
+			expected: `name\s+"value"\s+user-supplied values`,
+		},
+		{
+			name:     "get values with explanations of chart defaults",
+			resp:     releaseMock(&releaseOptions{name: "thomas-guide", chart: defaultsChart}),
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--explain"},
+			expected: `replicas\s+3\s+deployed defaults of foo`,
+		},
+		{
+			name:     "get values masks sensitive values",
+			resp:     releaseMock(&releaseOptions{name: "thomas-guide", chart: sensitiveChart}),
+			args:     []string{"thomas-guide"},
+			expected: `name: '\*\*\*\*\*\*'`,
+		},
+		{
+			name:     "get all values masks sensitive values",
+			resp:     releaseMock(&releaseOptions{name: "thomas-guide", chart: sensitiveChart}),
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--all"},
+			expected: `name: '\*\*\*\*\*\*'`,
+		},
+		{
+			name:     "get values with explanations masks sensitive values",
+			resp:     releaseMock(&releaseOptions{name: "thomas-guide", chart: sensitiveChart}),
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--explain"},
+			expected: `name\s+"\*\*\*\*\*\*"\s+user-supplied values`,
+		},
+		{
+			name:     "get values shows sensitive values with --show-secrets",
+			resp:     releaseMock(&releaseOptions{name: "thomas-guide", chart: sensitiveChart}),
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--show-secrets"},
+			expected: "name: \"value\"",
+		},
 		{
 			name: "get values requires release name arg",
 			err:  true,
@@ -103,12 +103,15 @@ func newRootCmd(args []string) *cobra.Command {
 		newLintCmd(out),
 		newPackageCmd(out),
 		newRepoCmd(out),
+		newSecretsCmd(out),
 		newSearchCmd(out),
 		newServeCmd(out),
+		newTemplateCmd(out),
 		newVerifyCmd(out),
 
 		// release commands
 		addFlagsTLS(newDeleteCmd(nil, out)),
+		addFlagsTLS(newDriftCmd(nil, out)),
 		addFlagsTLS(newGetCmd(nil, out)),
 		addFlagsTLS(newHistoryCmd(nil, out)),
 		addFlagsTLS(newInstallCmd(nil, out)),
@@ -149,9 +152,18 @@ func init() {
 	grpclog.SetLogger(log.New(ioutil.Discard, "", log.LstdFlags))
 }
 
+// exitError is an error that makes helm exit with a status other than 1.
+type exitError struct {
+	error
+	code int
+}
+
 func main() {
 	cmd := newRootCmd(os.Args[1:])
 	if err := cmd.Execute(); err != nil {
+		if e, ok := err.(exitError); ok {
+			os.Exit(e.code)
+		}
 		os.Exit(1)
 	}
 }
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/provenance"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/strvals"
 )
@@ -64,17 +66,65 @@ contained a key called 'Test', the value set in override.yaml would take precede
 
 	$ helm install -f myvalues.yaml -f override.yaml ./redis
 
+Values files can also be fetched from a URL, over HTTP(S) or any scheme
+provided by a downloader plugin. URLs served by one of the configured chart
+repositories are fetched with that repository's TLS settings, unless
+'--cert-file', '--key-file' or '--ca-file' is given:
+
+	$ helm install -f https://config.example.com/prod/values.yaml ./redis
+
+Values files encrypted with 'helm secrets encrypt' (or 'gpg --armor --encrypt')
+are decrypted on the client with the private keys in '--secret-keyring'; the
+decrypted values are never written to disk:
+
+	$ helm install -f values.yaml -f secrets.yaml.asc ./redis
+
 You can specify the '--set' flag multiple times. The priority will be given to the
 last (right-most) set specified. For example, if both 'bar' and 'newbar' values are
 set for a key called 'foo', the 'newbar' value would take precedence:
 
 	$ helm install --set foo=bar --set foo=newbar ./redis
 
+Values set with '--set' are typed: 'tag=1' sets the integer 1 and 'debug=true'
+the boolean true. Use '--set-string' to set them as strings instead, and
+'--set-file' to set a key to the contents of a local file:
+
+	$ helm install --set-string image.tag=1 --set-file config=./app.conf ./redis
+
+When a key is set more than once, '--set-file' takes precedence over
+'--set-string', which takes precedence over '--set'.
+
+Charts may declare named environments, each with a values file in
+'environments/<name>/values.yaml' that can inherit from another environment
+(see 'inherits' in 'environments/<name>/environment.yaml'). Use
+'--environment' to apply the values of one of them, and the values of the
+environments it inherits from, on top of the chart's values file. Values
+files and '--set' flags still take precedence:
+
+	$ helm install --environment prod ./redis
+
+Installing fails when a resource of the chart already exists in the cluster,
+for instance one deployed by hand. To take such resources over instead, pass
+'--adopt', or annotate them in the chart with 'helm.sh/adopt: "true"' to
+adopt only those. Adopted resources are patched to their rendered state,
+become part of the release, and are listed by 'helm status':
+
+	$ helm install --adopt --name redis ./redis
+
 
 To check the generated manifests of a release without installing the chart,
 the '--debug' and '--dry-run' flags can be combined. This will still require a
 round-trip to the Tiller server.
 
+The '--dry-run' flag only checks that the manifests describe known kinds of
+resources. To also have the API server check them, with its admission webhooks,
+quotas and validation, pass '--validate': every resource is submitted as a
+server-side dry run before anything is installed, and the errors of all the
+rejected resources are reported together. Combined with '--dry-run', nothing is
+installed either way. This requires Kubernetes 1.13 or later:
+
+	$ helm install --dry-run --validate ./redis
+
 If --verify is set, the chart MUST have a provenance file, and the provenenace
 fall MUST pass all verification steps.
 
@@ -100,25 +150,40 @@ charts in a repository, use 'helm search'.
 `
 
 type installCmd struct {
-	name         string     //指定release名
-	namespace    string     //release安装的目录
-	valueFiles   valueFiles //chart的模板配置文件
-	chartPath    string     //安装的chart的绝对路径
-	dryRun       bool       //模拟安装
+	name         string //指定release名
+	namespace    string //release安装的目录
+	chartPath    string //安装的chart的绝对路径
+	dryRun       bool   //模拟安装
 	disableHooks bool
 	replace      bool
 	verify       bool
 	keyring      string
+	environment  string
+	showSecrets  bool
+	adopt        bool
+	validate     bool
 	out          io.Writer
 	client       helm.Interface //helm客户端,最终实现是k8s.io/helm/pkg/helm/client.go的Client
-	values       []string
-	nameTemplate string //指定的release名的模板.
+	nameTemplate string         //指定的release名的模板.
 	version      string
 	timeout      int64
 	wait         bool   //等待所有的pod就绪
 	repoURL      string //安装的chart所在的repo的URL
 	devel        bool
+	capabilities capabilityFlags
+
+	valuesOptions
+}
 
+// valuesOptions are the sources of the values a user supplies to a chart.
+type valuesOptions struct {
+	valueFiles    valueFiles //chart的模板配置文件
+	values        []string
+	stringValues  []string
+	fileValues    []string
+	secretKeyring string
+
+	// TLS settings for values files and charts fetched from a URL
 	certFile string
 	keyFile  string
 	caFile   string
@@ -178,16 +243,23 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	}
 
 	f := cmd.Flags()
-	f.VarP(&inst.valueFiles, "values", "f", "specify values in a YAML file (can specify multiple)")
+	f.VarP(&inst.valueFiles, "values", "f", "specify values in a YAML file or a URL (can specify multiple)")
 	f.StringVarP(&inst.name, "name", "n", "", "release name. If unspecified, it will autogenerate one for you")
 	f.StringVar(&inst.namespace, "namespace", "", "namespace to install the release into")
 	f.BoolVar(&inst.dryRun, "dry-run", false, "simulate an install")
 	f.BoolVar(&inst.disableHooks, "no-hooks", false, "prevent hooks from running during install")
 	f.BoolVar(&inst.replace, "replace", false, "re-use the given name, even if that name is already used. This is unsafe in production")
 	f.StringArrayVar(&inst.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&inst.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&inst.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 	f.StringVar(&inst.nameTemplate, "name-template", "", "specify template used to name the release")
 	f.BoolVar(&inst.verify, "verify", false, "verify the package before installing it")
 	f.StringVar(&inst.keyring, "keyring", defaultKeyring(), "location of public keys used for verification")
+	f.StringVar(&inst.secretKeyring, "secret-keyring", defaultSecretKeyring(), "location of private keys used to decrypt encrypted values files")
+	f.StringVar(&inst.environment, "environment", "", "apply the values of the named environment of the chart")
+	f.BoolVar(&inst.showSecrets, "show-secrets", false, "do not mask the values that the chart marks as sensitive")
+	f.BoolVar(&inst.adopt, "adopt", false, "take over resources of the chart that already exist in the cluster, instead of failing")
+	f.BoolVar(&inst.validate, "validate", false, "validate the resources with a server-side dry run against the API server before applying them. Requires Kubernetes 1.13 or later")
 	f.StringVar(&inst.version, "version", "", "specify the exact chart version to install. If this is not specified, the latest version is installed")
 	f.Int64Var(&inst.timeout, "timeout", 300, "time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&inst.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
@@ -196,6 +268,7 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.StringVar(&inst.keyFile, "key-file", "", "identify HTTPS client using this SSL key file")
 	f.StringVar(&inst.caFile, "ca-file", "", "verify certificates of HTTPS-enabled servers using this CA bundle")
 	f.BoolVar(&inst.devel, "devel", false, "use development versions, too. Equivalent to version '>0.0.0-a'. If --version is set, this is ignored.")
+	inst.capabilities.addFlags(f)
 
 	return cmd
 }
@@ -208,7 +281,12 @@ func (i *installCmd) run() error {
 	}
 
 	//如果install参数中指定了--set或者--values, 提取覆盖的values并marshal
-	rawVals, err := vals(i.valueFiles, i.values)
+	rawVals, err := vals(i.valuesOptions)
+	if err != nil {
+		return err
+	}
+
+	caps, err := i.capabilities.dryRunOverride(i.dryRun)
 	if err != nil {
 		return err
 	}
@@ -244,6 +322,12 @@ func (i *installCmd) run() error {
 		return fmt.Errorf("cannot load requirements: %v", err)
 	}
 
+	if i.environment != "" {
+		if err := chartutil.ApplyEnvironment(chartRequested, i.environment); err != nil {
+			return err
+		}
+	}
+
 	//调用helm.Interface
 	res, err := i.client.InstallReleaseFromChart(
 		chartRequested,
@@ -255,7 +339,11 @@ func (i *installCmd) run() error {
 		helm.InstallReuseName(i.replace),
 		helm.InstallDisableHooks(i.disableHooks),
 		helm.InstallTimeout(i.timeout),
-		helm.InstallWait(i.wait))
+		helm.InstallWait(i.wait),
+		helm.InstallAdopt(i.adopt),
+		helm.InstallValidate(i.validate),
+		helm.InstallProgress(progressPrinter(i.out, i.wait, !i.disableHooks && !i.dryRun)),
+		helm.InstallCapabilities(caps))
 	if err != nil {
 		return prettyError(err)
 	}
@@ -265,7 +353,9 @@ func (i *installCmd) run() error {
 	if rel == nil {
 		return nil
 	}
-	i.printRelease(rel)
+	if err := i.printRelease(rel); err != nil {
+		return err
+	}
 
 	// If this is a dry run, we can't display status.
 	if i.dryRun {
@@ -277,6 +367,9 @@ func (i *installCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
+	if err := redactStatus(status, rel, i.showSecrets); err != nil {
+		return err
+	}
 	PrintStatus(i.out, status)
 	return nil
 }
@@ -313,46 +406,152 @@ func mergeValues(dest map[string]interface{}, src map[string]interface{}) map[st
 	return dest
 }
 
-// vals merges values from files specified via -f/--values and
-// directly via --set, marshaling them to YAML
-func vals(valueFiles valueFiles, values []string) ([]byte, error) {
+// vals merges values from files specified via -f/--values and directly
+// via --set, --set-string or --set-file, marshaling them to YAML
+//
+// Values files may be URLs; see readFile. Files given with --set-file are
+// always read from the local filesystem.
+func vals(opts valuesOptions) ([]byte, error) {
+	base, err := mergeVals(opts, nil)
+	if err != nil {
+		return []byte{}, err
+	}
+	return yaml.Marshal(base)
+}
+
+// mergeVals merges the values files and --set flags into one values map.
+//
+// If sources is not nil, the values file or flag that set each leaf value is
+// recorded in it, keyed by the dotted path of the value.
+func mergeVals(opts valuesOptions, sources map[string]string) (map[string]interface{}, error) {
 	base := map[string]interface{}{}
 
 	// User specified a values files via -f/--values
-	for _, filePath := range valueFiles {
+	for _, filePath := range opts.valueFiles {
 		currentMap := map[string]interface{}{}
-		bytes, err := ioutil.ReadFile(filePath)
+		bytes, err := readFile(filePath, opts.certFile, opts.keyFile, opts.caFile)
 		if err != nil {
-			return []byte{}, err
+			return nil, err
+		}
+		if provenance.IsEncrypted(bytes) {
+			if bytes, err = decryptValues(bytes, opts.secretKeyring); err != nil {
+				return nil, fmt.Errorf("cannot decrypt %s: %s", filePath, err)
+			}
 		}
 
 		if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
-			return []byte{}, fmt.Errorf("failed to parse %s: %s", filePath, err)
+			return nil, fmt.Errorf("failed to parse %s: %s", filePath, err)
 		}
 		// Merge with the previous map
 		base = mergeValues(base, currentMap)
+		recordSources(sources, "", currentMap, filePath)
 	}
 
 	// User specified a value via --set
-	for _, value := range values {
+	for _, value := range opts.values {
 		if err := strvals.ParseInto(value, base); err != nil {
-			return []byte{}, fmt.Errorf("failed parsing --set data: %s", err)
+			return nil, fmt.Errorf("failed parsing --set data: %s", err)
+		}
+		if sources != nil {
+			m, _ := strvals.Parse(value)
+			recordSources(sources, "", m, "--set "+value)
 		}
 	}
 
-	return yaml.Marshal(base)
+	// User specified a value via --set-string
+	for _, value := range opts.stringValues {
+		if err := strvals.ParseIntoString(value, base); err != nil {
+			return nil, fmt.Errorf("failed parsing --set-string data: %s", err)
+		}
+		if sources != nil {
+			m, _ := strvals.ParseString(value)
+			recordSources(sources, "", m, "--set-string "+value)
+		}
+	}
+
+	// User specified a value via --set-file
+	for _, value := range opts.fileValues {
+		reader := func(rs []rune) (interface{}, error) {
+			bytes, err := ioutil.ReadFile(string(rs))
+			return string(bytes), err
+		}
+		if err := strvals.ParseIntoFile(value, base, reader); err != nil {
+			return nil, fmt.Errorf("failed parsing --set-file data: %s", err)
+		}
+		if sources != nil {
+			// Only the keys are needed, so the files are not read again.
+			m, _ := strvals.ParseFile(value, func([]rune) (interface{}, error) { return "", nil })
+			recordSources(sources, "", m, "--set-file "+value)
+		}
+	}
+
+	return base, nil
+}
+
+// recordSources records source as the source of every leaf value in vals.
+func recordSources(sources map[string]string, prefix string, vals map[string]interface{}, source string) {
+	if sources == nil {
+		return
+	}
+	for k, v := range vals {
+		p := k
+		if prefix != "" {
+			p = prefix + "." + k
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			recordSources(sources, p, m, source)
+			continue
+		}
+		sources[p] = source
+	}
+}
+
+// readFile reads a values file from the local filesystem, or fetches it with
+// the getter for its URL scheme (http, https or a downloader plugin).
+//
+// A URL served by one of the configured chart repositories is fetched with
+// that repository's TLS settings, unless certificates were given explicitly.
+func readFile(filePath, certFile, keyFile, caFile string) ([]byte, error) {
+	u, err := url.Parse(filePath)
+	if err != nil || u.Scheme == "" {
+		return ioutil.ReadFile(filePath)
+	}
+	getterConstructor, err := getter.All(settings).ByScheme(u.Scheme)
+	if err != nil {
+		// Not a scheme we know, e.g. a Windows drive letter.
+		return ioutil.ReadFile(filePath)
+	}
+
+	if certFile == "" && keyFile == "" && caFile == "" {
+		if rf, err := repo.LoadRepositoriesFile(settings.Home.RepositoryFile()); err == nil {
+			if rc := rf.EntryForURL(filePath); rc != nil {
+				certFile, keyFile, caFile = rc.CertFile, rc.KeyFile, rc.CAFile
+			}
+		}
+	}
+
+	g, err := getterConstructor(filePath, certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	data, err := g.Get(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch values file %s: %s", filePath, err)
+	}
+	return data.Bytes(), nil
 }
 
 // printRelease prints info about a release if the Debug is true.
-func (i *installCmd) printRelease(rel *release.Release) {
+func (i *installCmd) printRelease(rel *release.Release) error {
 	if rel == nil {
-		return
+		return nil
 	}
 	// TODO: Switch to text/template like everything else.
 	fmt.Fprintf(i.out, "NAME:   %s\n", rel.Name)
 	if settings.Debug {
-		printRelease(i.out, rel)
+		return printRelease(i.out, rel, i.showSecrets)
 	}
+	return nil
 }
 
 // locateChartPath looks for a chart directory in known places, and returns either the full path or an error.
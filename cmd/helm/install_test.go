@@ -18,6 +18,8 @@ package main
 
 import (
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"regexp"
 	"strings"
@@ -62,6 +64,29 @@ func TestInstall(t *testing.T) {
 			resp:     releaseMock(&releaseOptions{name: "virgil"}),
 			expected: "virgil",
 		},
+		// Install, string values from cli
+		{
+			name:     "install with string values",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--set-string tag=1.10,debug=true", " "),
+			resp:     releaseMock(&releaseOptions{name: "virgil"}),
+			expected: "virgil",
+		},
+		// Install, values from files via cli
+		{
+			name:     "install with file values",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--set-file extra=testdata/testcharts/alpine/extra_values.yaml", " "),
+			resp:     releaseMock(&releaseOptions{name: "virgil"}),
+			expected: "virgil",
+		},
+		// Install, missing file via --set-file
+		{
+			name:  "install with missing file values",
+			args:  []string{"testdata/testcharts/alpine"},
+			flags: strings.Split("--set-file extra=testdata/testcharts/alpine/missing.yaml", " "),
+			err:   true,
+		},
 		// Install, values from yaml
 		{
 			name:     "install with values",
@@ -108,6 +133,20 @@ func TestInstall(t *testing.T) {
 			expected: "apollo",
 			resp:     releaseMock(&releaseOptions{name: "apollo"}),
 		},
+		// Install, with a capabilities override
+		{
+			name:     "install with capabilities",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--dry-run --kube-version 1.8 --capabilities testdata/capabilities.yaml", " "),
+			expected: "mercury",
+			resp:     releaseMock(&releaseOptions{name: "mercury"}),
+		},
+		{
+			name:  "install with capabilities, not a dry run",
+			args:  []string{"testdata/testcharts/alpine"},
+			flags: strings.Split("--kube-version 1.8", " "),
+			err:   true,
+		},
 		// Install, using the name-template
 		{
 			name:     "install with name-template",
@@ -282,3 +321,71 @@ func TestMergeValues(t *testing.T) {
 		t.Errorf("Expected a map with different keys to merge properly with another map. Expected: %v, got %v", expectedMap, testMap)
 	}
 }
+
+func TestVals(t *testing.T) {
+	values := []string{"name=value,tag=1", "debug=true"}
+	stringValues := []string{"tag=1,list={2,false}"}
+	fileValues := []string{"config=testdata/testcharts/alpine/extra_values.yaml"}
+
+	got, err := vals(valuesOptions{
+		valueFiles:   valueFiles{"testdata/testcharts/alpine/more_values.yaml"},
+		values:       values,
+		stringValues: stringValues,
+		fileValues:   fileValues,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `config: |
+  test:
+    Name: extra-values
+debug: true
+list:
+- "2"
+- "false"
+name: value
+tag: "1"
+test:
+  Name: more-values
+`
+	if string(got) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/config/values.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("remote: true\n"))
+	}))
+	defer srv.Close()
+
+	data, err := readFile(srv.URL+"/config/values.yaml", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "remote: true\n" {
+		t.Errorf("Expected the remote values file, got %q", data)
+	}
+
+	if _, err := readFile(srv.URL+"/config/missing.yaml", "", "", ""); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected a 404 error, got %v", err)
+	}
+
+	data, err = readFile("testdata/testcharts/alpine/extra_values.yaml", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "extra-values") {
+		t.Errorf("Expected the local values file, got %q", data)
+	}
+
+	// --set-file only reads local files.
+	opts := valuesOptions{fileValues: []string{"remote=" + srv.URL + "/config/values.yaml"}}
+	if _, err := vals(opts); err == nil || !strings.Contains(err.Error(), "no such file") {
+		t.Errorf("Expected --set-file not to fetch a URL, got %v", err)
+	}
+}
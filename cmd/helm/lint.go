@@ -28,6 +28,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/lint"
 	"k8s.io/helm/pkg/lint/support"
 )
@@ -39,12 +40,17 @@ the chart is well-formed.
 If the linter encounters things that will cause the chart to fail installation,
 it will emit [ERROR] messages. If it encounters issues that break with convention
 or recommendation, it will emit [WARNING] messages.
+
+If '--profile' is set, the time spent rendering each template and each named
+template (invoked through 'include' or 'template') is printed after the lint
+messages.
 `
 
 type lintCmd struct {
-	strict bool
-	paths  []string
-	out    io.Writer
+	strict  bool
+	profile bool
+	paths   []string
+	out     io.Writer
 }
 
 func newLintCmd(out io.Writer) *cobra.Command {
@@ -65,6 +71,7 @@ func newLintCmd(out io.Writer) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&l.strict, "strict", false, "fail on lint warnings")
+	cmd.Flags().BoolVar(&l.profile, "profile", false, "print the time spent rendering each template")
 
 	return cmd
 }
@@ -82,7 +89,7 @@ func (l *lintCmd) run() error {
 	var total int
 	var failures int
 	for _, path := range l.paths {
-		if linter, err := lintChart(path); err != nil {
+		if linter, prof, err := lintChart(path, l.profile); err != nil {
 			fmt.Println("==> Skipping", path)
 			fmt.Println(err)
 		} else {
@@ -96,6 +103,10 @@ func (l *lintCmd) run() error {
 				fmt.Println(msg)
 			}
 
+			if l.profile {
+				printProfile(l.out, prof)
+			}
+
 			total = total + 1
 			if linter.HighestSeverity >= lowestTolerance {
 				failures = failures + 1
@@ -114,25 +125,27 @@ func (l *lintCmd) run() error {
 	return nil
 }
 
-func lintChart(path string) (support.Linter, error) {
+// lintChart lints the chart at path. If profile is set, the render profile of
+// the chart's templates is returned as well.
+func lintChart(path string, profile bool) (support.Linter, *engine.Profile, error) {
 	var chartPath string
 	linter := support.Linter{}
 
 	if strings.HasSuffix(path, ".tgz") {
 		tempDir, err := ioutil.TempDir("", "helm-lint")
 		if err != nil {
-			return linter, err
+			return linter, nil, err
 		}
 		defer os.RemoveAll(tempDir)
 
 		file, err := os.Open(path)
 		if err != nil {
-			return linter, err
+			return linter, nil, err
 		}
 		defer file.Close()
 
 		if err = chartutil.Expand(tempDir, file); err != nil {
-			return linter, err
+			return linter, nil, err
 		}
 
 		base := strings.Split(filepath.Base(path), "-")[0]
@@ -143,8 +156,13 @@ func lintChart(path string) (support.Linter, error) {
 
 	// Guard: Error out of this is not a chart.
 	if _, err := os.Stat(filepath.Join(chartPath, "Chart.yaml")); err != nil {
-		return linter, errLintNoChart
+		return linter, nil, errLintNoChart
 	}
 
-	return lint.All(chartPath), nil
+	e, err := newTemplateEngine()
+	if err != nil {
+		return linter, nil, err
+	}
+	linter, prof := lint.AllWithEngine(chartPath, e, profile)
+	return linter, prof, nil
 }
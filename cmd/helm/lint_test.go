@@ -26,11 +26,11 @@ var (
 )
 
 func TestLintChart(t *testing.T) {
-	if _, err := lintChart(chartDirPath); err != nil {
+	if _, _, err := lintChart(chartDirPath, false); err != nil {
 		t.Errorf("%s", err)
 	}
 
-	if _, err := lintChart(archivedChartPath); err != nil {
+	if _, _, err := lintChart(archivedChartPath, false); err != nil {
 		t.Errorf("%s", err)
 	}
 
@@ -25,6 +25,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/plugin"
 )
 
@@ -157,3 +158,27 @@ func findPlugins(plugdirs string) ([]*plugin.Plugin, error) {
 	}
 	return found, nil
 }
+
+// newTemplateEngine returns a template engine with the template functions
+// declared by the installed plugins registered.
+func newTemplateEngine() (*engine.Engine, error) {
+	e := engine.New()
+
+	// If HELM_NO_PLUGINS is set to 1, do not load plugins.
+	if os.Getenv("HELM_NO_PLUGINS") == "1" {
+		return e, nil
+	}
+
+	found, err := findPlugins(settings.PluginDirs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plugins: %s", err)
+	}
+	funcs, err := plugin.TemplateFuncs(found)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.AddFuncs(funcs); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -185,3 +185,28 @@ func TestSetupEnv(t *testing.T) {
 		}
 	}
 }
+
+func TestNewTemplateEngine(t *testing.T) {
+	cleanup := resetEnv()
+	defer cleanup()
+
+	settings.Home = "testdata/helmhome"
+	os.Unsetenv("HELM_NO_PLUGINS")
+
+	e, err := newTemplateEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := e.FuncMap["echoArgs"]; !ok {
+		t.Error("Expected the echoArgs template function from the echo plugin")
+	}
+
+	os.Setenv("HELM_NO_PLUGINS", "1")
+	e, err = newTemplateEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := e.FuncMap["echoArgs"]; ok {
+		t.Error("Expected no plugin template functions with HELM_NO_PLUGINS=1")
+	}
+}
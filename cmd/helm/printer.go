@@ -17,13 +17,19 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"text/template"
 	"time"
 
+	"github.com/gosuri/uitable"
+
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/timeconv"
 )
 
@@ -44,7 +50,7 @@ MANIFEST:
 {{.Release.Manifest}}
 `
 
-func printRelease(out io.Writer, rel *release.Release) error {
+func printRelease(out io.Writer, rel *release.Release, showSecrets bool) error {
 	if rel == nil {
 		return nil
 	}
@@ -53,7 +59,14 @@ func printRelease(out io.Writer, rel *release.Release) error {
 	if err != nil {
 		return err
 	}
-	cfgStr, err := cfg.YAML()
+	red, err := newRedactor(rel, showSecrets)
+	if err != nil {
+		return err
+	}
+	if rel, err = red.Release(rel); err != nil {
+		return err
+	}
+	cfgStr, err := chartutil.Values(red.Values(cfg)).YAML()
 	if err != nil {
 		return err
 	}
@@ -66,6 +79,82 @@ func printRelease(out io.Writer, rel *release.Release) error {
 	return tpl(printReleaseTemplate, data, out)
 }
 
+// newRedactor returns a Redactor that masks the sensitive values of a release,
+// or nil if they are to be shown.
+func newRedactor(rel *release.Release, showSecrets bool) (*releaseutil.Redactor, error) {
+	if showSecrets || rel == nil {
+		return nil, nil
+	}
+	return releaseutil.NewRedactor(rel)
+}
+
+// progressPrinter returns a function that prints the progress of a release
+// to out, or nil when neither hooks are run nor the release is waited for.
+func progressPrinter(out io.Writer, wait, hooks bool) func(*services.ReleaseEvent) {
+	if !wait && !hooks {
+		return nil
+	}
+	return func(e *services.ReleaseEvent) {
+		printReleaseEvent(out, e)
+	}
+}
+
+func printReleaseEvent(out io.Writer, e *services.ReleaseEvent) {
+	switch e.Phase {
+	case services.ReleaseEvent_HOOKS_STARTED:
+		fmt.Fprintf(out, "HOOKS: %s\n", e.Message)
+	case services.ReleaseEvent_HOOKS_FINISHED:
+		fmt.Fprintf(out, "HOOKS: %s hooks complete\n", e.Hook)
+	case services.ReleaseEvent_RESOURCES_APPLIED:
+		fmt.Fprintf(out, "APPLIED: %s\n", e.Message)
+	case services.ReleaseEvent_RESOURCE_WAITING:
+		fmt.Fprintf(out, "WAITING: %s: %s\n", e.Resource, e.Message)
+	case services.ReleaseEvent_RESOURCE_READY:
+		fmt.Fprintf(out, "READY: %s\n", e.Resource)
+	}
+}
+
+// printProfile prints the per-template and per-define render times of a chart.
+func printProfile(out io.Writer, prof *engine.Profile) {
+	if prof == nil {
+		return
+	}
+	fmt.Fprintf(out, "RENDER PROFILE (total %v):\n", prof.Total)
+	fmt.Fprintln(out, profileTable("TEMPLATE", prof.SortedTemplates()))
+	if len(prof.Defines) > 0 {
+		fmt.Fprintln(out, profileTable("DEFINE", prof.SortedDefines()))
+	}
+}
+
+// printValueOrigins prints each value along with where it came from.
+func printValueOrigins(out io.Writer, origins []chartutil.ValueOrigin) {
+	table := uitable.New()
+	table.AddRow("KEY", "VALUE", "SOURCE")
+	for _, o := range origins {
+		table.AddRow(o.Path, formatValue(o.Value), o.Source)
+	}
+	fmt.Fprintln(out, table)
+}
+
+// formatValue formats a value as JSON, which keeps strings apart from numbers
+// and booleans.
+func formatValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func profileTable(heading string, entries []*engine.ProfileEntry) string {
+	table := uitable.New()
+	table.AddRow(heading, "CALLS", "TIME")
+	for _, e := range entries {
+		table.AddRow(e.Name, e.Calls, e.Duration)
+	}
+	return table.String()
+}
+
 func tpl(t string, vals map[string]interface{}, out io.Writer) error {
 	tt, err := template.New("_").Parse(t)
 	if err != nil {
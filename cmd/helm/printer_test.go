@@ -0,0 +1,56 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestProgressPrinter(t *testing.T) {
+	var buf bytes.Buffer
+	if progressPrinter(&buf, false, false) != nil {
+		t.Fatal("expected no progress without --wait or hooks")
+	}
+	if progressPrinter(&buf, false, true) == nil {
+		t.Fatal("expected the progress of hooks without --wait")
+	}
+
+	print := progressPrinter(&buf, true, false)
+	for _, e := range []*services.ReleaseEvent{
+		{Phase: services.ReleaseEvent_HOOKS_STARTED, Hook: "pre-install", Message: "running 1 pre-install hook(s)"},
+		{Phase: services.ReleaseEvent_HOOKS_FINISHED, Hook: "pre-install"},
+		{Phase: services.ReleaseEvent_RESOURCES_APPLIED, Message: "created 2 resource(s)"},
+		{Phase: services.ReleaseEvent_RESOURCE_WAITING, Resource: "Deployment/web", Message: "1 of 2 new pods ready"},
+		{Phase: services.ReleaseEvent_RESOURCE_READY, Resource: "Deployment/web"},
+		{Phase: services.ReleaseEvent_UNKNOWN, Message: "ignored"},
+	} {
+		print(e)
+	}
+
+	expect := `HOOKS: running 1 pre-install hook(s)
+HOOKS: pre-install hooks complete
+APPLIED: created 2 resource(s)
+WAITING: Deployment/web: 1 of 2 new pods ready
+READY: Deployment/web
+`
+	if buf.String() != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, buf.String())
+	}
+}
@@ -96,7 +96,8 @@ func (r *rollbackCmd) run() error {
 		helm.RollbackDisableHooks(r.disableHooks),
 		helm.RollbackVersion(r.revision),
 		helm.RollbackTimeout(r.timeout),
-		helm.RollbackWait(r.wait))
+		helm.RollbackWait(r.wait),
+		helm.RollbackProgress(progressPrinter(r.out, r.wait, !r.disableHooks && !r.dryRun)))
 	if err != nil {
 		return prettyError(err)
 	}
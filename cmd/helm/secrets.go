@@ -0,0 +1,73 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/provenance"
+)
+
+const secretsDesc = `
+This command consists of multiple subcommands to work with encrypted values files.
+
+Encrypted values files let secrets be kept in version control next to the rest
+of a chart's configuration. They are ASCII-armored OpenPGP messages, as written
+by 'gpg --armor --encrypt', and are decrypted on the client when passed to
+'helm install', 'helm upgrade' or 'helm template' with '-f':
+
+	$ helm secrets encrypt --key 'Ops Team' secrets.yaml
+	$ helm install -f values.yaml -f secrets.yaml.asc ./mychart
+
+Decryption uses the private keys in the keyring given with '--secret-keyring'
+(by default ~/.gnupg/secring.gpg). GnuPG 2.1 and later no longer write that
+file; create it with 'gpg --export-secret-keys > ~/.gnupg/secring.gpg'.
+`
+
+func newSecretsCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secrets [FLAGS] encrypt|decrypt|edit [ARGS]",
+		Short: "encrypt, decrypt and edit encrypted values files",
+		Long:  secretsDesc,
+	}
+
+	cmd.AddCommand(newSecretsEncryptCmd(out))
+	cmd.AddCommand(newSecretsDecryptCmd(out))
+	cmd.AddCommand(newSecretsEditCmd(out))
+
+	return cmd
+}
+
+// defaultSecretKeyring returns the keyring holding the private keys that
+// decrypt values files.
+func defaultSecretKeyring() string {
+	return os.ExpandEnv("$HOME/.gnupg/secring.gpg")
+}
+
+// decryptValues decrypts an encrypted values file with the private keys in
+// the keyring, asking for the passphrase of the key if needed.
+func decryptValues(data []byte, keyring string) ([]byte, error) {
+	signer, err := provenance.NewFromKeyring(keyring, "")
+	if err != nil {
+		return nil, err
+	}
+	plain, _, err := signer.Decrypt(data, promptUser)
+	return plain, err
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+)
+
+const secretsDecryptDesc = `
+Decrypt an encrypted values file and print it, or write it to '--output'.
+`
+
+type secretsDecryptCmd struct {
+	out     io.Writer
+	file    string
+	output  string
+	keyring string
+}
+
+func newSecretsDecryptCmd(out io.Writer) *cobra.Command {
+	dec := &secretsDecryptCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "decrypt [flags] FILE",
+		Short: "decrypt an encrypted values file",
+		Long:  secretsDecryptDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "encrypted values file"); err != nil {
+				return err
+			}
+			dec.file = args[0]
+			return dec.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&dec.keyring, "keyring", defaultSecretKeyring(), "location of a private keyring")
+	f.StringVarP(&dec.output, "output", "o", "", "write the decrypted file here instead of printing it")
+
+	return cmd
+}
+
+func (d *secretsDecryptCmd) run() error {
+	data, err := ioutil.ReadFile(d.file)
+	if err != nil {
+		return err
+	}
+	plain, err := decryptValues(data, d.keyring)
+	if err != nil {
+		return err
+	}
+	if d.output != "" {
+		return ioutil.WriteFile(d.output, plain, 0600)
+	}
+	_, err = d.out.Write(plain)
+	return err
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/provenance"
+)
+
+const secretsEditDesc = `
+Edit an encrypted values file in place.
+
+The file is decrypted to a temporary file, which is opened with $EDITOR (or vi).
+When the editor exits, the file is encrypted again for every key it was
+encrypted for. The public keys of the other recipients are looked up in
+'--public-keyring' if they are not in '--keyring'. Nothing is written if the
+file was not changed or is not valid YAML.
+`
+
+type secretsEditCmd struct {
+	out     io.Writer
+	file    string
+	keyring string
+	pubring string
+}
+
+func newSecretsEditCmd(out io.Writer) *cobra.Command {
+	edit := &secretsEditCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "edit [flags] FILE",
+		Short: "edit an encrypted values file",
+		Long:  secretsEditDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "encrypted values file"); err != nil {
+				return err
+			}
+			edit.file = args[0]
+			return edit.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&edit.keyring, "keyring", defaultSecretKeyring(), "location of a private keyring")
+	f.StringVar(&edit.pubring, "public-keyring", defaultKeyring(), "location of a public keyring holding the keys of the other recipients")
+
+	return cmd
+}
+
+func (e *secretsEditCmd) run() error {
+	data, err := ioutil.ReadFile(e.file)
+	if err != nil {
+		return err
+	}
+	signer, err := provenance.NewFromKeyring(e.keyring, "")
+	if err != nil {
+		return err
+	}
+	plain, ids, err := signer.Decrypt(data, promptUser)
+	if err != nil {
+		return err
+	}
+	recipients, err := signer.Recipients(ids, e.pubring)
+	if err != nil {
+		return fmt.Errorf("cannot encrypt %s again for all its recipients: %s", e.file, err)
+	}
+
+	// TempFile creates the file readable by the current user only.
+	tmp, err := ioutil.TempFile("", "helm-secrets-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(plain)
+	tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	if err := runEditor(tmp.Name()); err != nil {
+		return err
+	}
+
+	edited, err := ioutil.ReadFile(tmp.Name())
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(edited, plain) {
+		fmt.Fprintf(e.out, "%s was not changed\n", e.file)
+		return nil
+	}
+	if _, err := chartutil.ReadValues(edited); err != nil {
+		return fmt.Errorf("not saving %s, the edited file is not valid YAML: %s", e.file, err)
+	}
+
+	enc, err := signer.Encrypt(edited, recipients...)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(e.file, enc, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.out, "Saved %s\n", e.file)
+	return nil
+}
+
+// runEditor opens a file in the user's editor and waits for it to exit.
+func runEditor(path string) error {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %s", editor, err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/provenance"
+)
+
+const secretsEncryptDesc = `
+Encrypt a values file for the given key.
+
+Only the public key is needed, so '--keyring' defaults to the public keyring.
+The encrypted file is written next to the original, with an '.asc' extension,
+unless '--output' is given.
+`
+
+type secretsEncryptCmd struct {
+	out     io.Writer
+	file    string
+	output  string
+	key     string
+	keyring string
+}
+
+func newSecretsEncryptCmd(out io.Writer) *cobra.Command {
+	enc := &secretsEncryptCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "encrypt [flags] FILE",
+		Short: "encrypt a values file",
+		Long:  secretsEncryptDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "values file"); err != nil {
+				return err
+			}
+			if enc.key == "" {
+				return errors.New("--key is required to encrypt a values file")
+			}
+			enc.file = args[0]
+			return enc.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&enc.key, "key", "", "name of the key to encrypt the file for")
+	f.StringVar(&enc.keyring, "keyring", defaultKeyring(), "location of a public keyring")
+	f.StringVarP(&enc.output, "output", "o", "", "write the encrypted file here instead of FILE.asc")
+
+	return cmd
+}
+
+func (e *secretsEncryptCmd) run() error {
+	data, err := ioutil.ReadFile(e.file)
+	if err != nil {
+		return err
+	}
+	if provenance.IsEncrypted(data) {
+		return fmt.Errorf("%s is already encrypted", e.file)
+	}
+	if _, err := chartutil.ReadValues(data); err != nil {
+		return fmt.Errorf("%s is not a valid values file: %s", e.file, err)
+	}
+
+	signer, err := provenance.NewFromKeyring(e.keyring, e.key)
+	if err != nil {
+		return err
+	}
+	enc, err := signer.Encrypt(data)
+	if err != nil {
+		return err
+	}
+
+	output := e.output
+	if output == "" {
+		output = e.file + ".asc"
+	}
+	if err := ioutil.WriteFile(output, enc, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.out, "Encrypted %s to %s\n", e.file, output)
+	return nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/provenance"
+)
+
+const (
+	testPubring      = "testdata/helm-test-key.pub"
+	testSecring      = "testdata/helm-test-key.secret"
+	testOtherSecring = "testdata/helm-password-key.secret"
+)
+
+// encryptTestValues writes values to an encrypted file in dir.
+func encryptTestValues(t *testing.T, dir, values string) string {
+	plain := filepath.Join(dir, "secrets.yaml")
+	if err := ioutil.WriteFile(plain, []byte(values), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	cmd := newSecretsEncryptCmd(&buf)
+	cmd.SetArgs([]string{"--key", "helm-test", "--keyring", testPubring, plain})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); !strings.Contains(got, "secrets.yaml.asc") {
+		t.Errorf("Expected the encrypted file to be reported, got %q", got)
+	}
+	return plain + ".asc"
+}
+
+func TestSecretsEncryptDecrypt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-secrets-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	enc := encryptTestValues(t, dir, "password: hunter2\n")
+	data, err := ioutil.ReadFile(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !provenance.IsEncrypted(data) || strings.Contains(string(data), "hunter2") {
+		t.Fatalf("Expected an encrypted file, got %q", data)
+	}
+
+	var buf bytes.Buffer
+	cmd := newSecretsDecryptCmd(&buf)
+	cmd.SetArgs([]string{"--keyring", testSecring, enc})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "password: hunter2\n" {
+		t.Errorf("Expected the decrypted values, got %q", got)
+	}
+
+	// Values files are decrypted transparently.
+	got, err := vals(valuesOptions{valueFiles: valueFiles{enc}, secretKeyring: testSecring})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "password: hunter2\n" {
+		t.Errorf("Expected the decrypted values, got %q", got)
+	}
+
+	if _, err := vals(valuesOptions{valueFiles: valueFiles{enc}, secretKeyring: testPubring}); err == nil || !strings.Contains(err.Error(), "cannot decrypt") {
+		t.Errorf("Expected a decryption error without the private key, got %v", err)
+	}
+}
+
+func TestSecretsEncryptErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-secrets-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := ioutil.WriteFile(invalid, []byte("- not\n- a map\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	enc := encryptTestValues(t, dir, "password: hunter2\n")
+
+	tests := []struct {
+		name   string
+		args   []string
+		expect string
+	}{
+		{"no key", []string{"--keyring", testPubring, invalid}, "--key is required"},
+		{"invalid values", []string{"--key", "helm-test", "--keyring", testPubring, invalid}, "not a valid values file"},
+		{"already encrypted", []string{"--key", "helm-test", "--keyring", testPubring, enc}, "already encrypted"},
+	}
+	for _, tt := range tests {
+		cmd := newSecretsEncryptCmd(ioutil.Discard)
+		cmd.SetArgs(tt.args)
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.expect, err)
+		}
+	}
+}
+
+func TestSecretsEdit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-secrets-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer resetEnv()()
+
+	enc := encryptTestValues(t, dir, "password: hunter2\n")
+
+	os.Setenv("EDITOR", "sed -i s/hunter2/swordfish/")
+	var buf bytes.Buffer
+	cmd := newSecretsEditCmd(&buf)
+	cmd.SetArgs([]string{"--keyring", testSecring, enc})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); !strings.Contains(got, "Saved") {
+		t.Errorf("Expected the file to be saved, got %q", got)
+	}
+
+	data, err := ioutil.ReadFile(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !provenance.IsEncrypted(data) {
+		t.Fatalf("Expected the edited file to be encrypted, got %q", data)
+	}
+	plain, err := decryptValues(data, testSecring)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plain) != "password: swordfish\n" {
+		t.Errorf("Expected the edited values, got %q", plain)
+	}
+
+	// An edit that breaks the YAML is not saved.
+	os.Setenv("EDITOR", "sed -i s/password:/-/")
+	cmd = newSecretsEditCmd(ioutil.Discard)
+	cmd.SetArgs([]string{"--keyring", testSecring, enc})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "not valid YAML") {
+		t.Errorf("Expected an invalid YAML error, got %v", err)
+	}
+	if after, _ := ioutil.ReadFile(enc); !bytes.Equal(after, data) {
+		t.Error("Expected the file to be left alone")
+	}
+}
+
+func TestSecretsEditKeepsRecipients(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-secrets-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer resetEnv()()
+
+	// Encrypt the file for two keys, as a team sharing it would.
+	s, err := provenance.NewFromKeyring(testPubring, "helm-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := provenance.NewFromKeyring(testOtherSecring, "password key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := s.Encrypt([]byte("password: hunter2\n"), s.Entity, other.Entity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := filepath.Join(dir, "secrets.yaml.asc")
+	if err := ioutil.WriteFile(enc, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the public key of the other recipient, nothing is edited.
+	os.Setenv("EDITOR", "sed -i s/hunter2/swordfish/")
+	cmd := newSecretsEditCmd(ioutil.Discard)
+	cmd.SetArgs([]string{"--keyring", testSecring, "--public-keyring", testPubring, enc})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "all its recipients") {
+		t.Errorf("Expected a missing recipient error, got %v", err)
+	}
+
+	cmd = newSecretsEditCmd(ioutil.Discard)
+	cmd.SetArgs([]string{"--keyring", testSecring, "--public-keyring", testOtherSecring, enc})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	data, err = ioutil.ReadFile(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both keys can still decrypt the edited file.
+	for _, keyring := range []string{testSecring, testOtherSecring} {
+		s, err := provenance.NewFromKeyring(keyring, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		plain, _, err := s.Decrypt(data, func(string) ([]byte, error) {
+			return []byte("secret"), nil
+		})
+		if err != nil {
+			t.Errorf("%s: %s", keyring, err)
+			continue
+		}
+		if string(plain) != "password: swordfish\n" {
+			t.Errorf("%s: expected the edited values, got %q", keyring, plain)
+		}
+	}
+}
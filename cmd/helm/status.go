@@ -20,8 +20,11 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/gosuri/uitable"
 	"github.com/gosuri/uitable/util/strutil"
 	"github.com/spf13/cobra"
@@ -38,9 +41,18 @@ The status consists of:
 - last deployment time
 - k8s namespace in which the release lives
 - state of the release (can be: UNKNOWN, DEPLOYED, DELETED, SUPERSEDED, FAILED or DELETING)
-- list of resources that this release consists of, sorted by kind
+- list of resources that this release consists of, grouped by kind, with
+  whether each one is ready
 - details on last test suite run, if applicable
 - additional notes provided by the chart
+
+The values that the chart marks as sensitive are masked in the notes and the
+resources unless '--show-secrets' is set.
+
+With '--output json' or '--output yaml', the status is printed in that format
+instead, for other tools to read. Besides the table of resources, it then lists
+the state of each resource under 'info.status.resource_statuses': its kind,
+namespace and name, whether it is ready, why not, and its age in seconds.
 `
 
 type statusCmd struct {
@@ -48,6 +60,9 @@ type statusCmd struct {
 	out     io.Writer
 	client  helm.Interface
 	version int32
+
+	showSecrets bool
+	outfmt      string
 }
 
 func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -74,6 +89,8 @@ func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	}
 
 	cmd.PersistentFlags().Int32Var(&status.version, "revision", 0, "if set, display the status of the named release with revision")
+	cmd.PersistentFlags().BoolVar(&status.showSecrets, "show-secrets", false, "do not mask the values that the chart marks as sensitive")
+	cmd.PersistentFlags().StringVarP(&status.outfmt, "output", "o", "", "output the status in the specified format (json or yaml)")
 
 	return cmd
 }
@@ -84,7 +101,60 @@ func (s *statusCmd) run() error {
 		return prettyError(err)
 	}
 
-	PrintStatus(s.out, res)
+	if !s.showSecrets {
+		// The status holds no chart, so the release is fetched to tell
+		// which values are sensitive.
+		rel, err := s.client.ReleaseContent(s.release, helm.ContentReleaseVersion(s.version))
+		if err != nil {
+			return prettyError(err)
+		}
+		if err := redactStatus(res, rel.Release, false); err != nil {
+			return err
+		}
+	}
+
+	switch s.outfmt {
+	case "":
+		PrintStatus(s.out, res)
+		return nil
+	case "json":
+		data, err := marshalStatus(res)
+		if err != nil {
+			return fmt.Errorf("Failed to Marshal JSON output: %s", err)
+		}
+		fmt.Fprintln(s.out, string(data))
+		return nil
+	case "yaml":
+		data, err := marshalStatus(res)
+		if err == nil {
+			data, err = yaml.JSONToYAML(data)
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to Marshal YAML output: %s", err)
+		}
+		fmt.Fprint(s.out, string(data))
+		return nil
+	}
+	return fmt.Errorf("Unknown output format %q", s.outfmt)
+}
+
+// marshalStatus marshals a status to JSON with the protobuf mapping, so that
+// status codes and timestamps are readable, and fields with default values,
+// such as "ready": false, are kept.
+func marshalStatus(res *services.GetReleaseStatusResponse) ([]byte, error) {
+	m := jsonpb.Marshaler{EmitDefaults: true, OrigName: true}
+	s, err := m.MarshalToString(res)
+	return []byte(s), err
+}
+
+// redactStatus masks the sensitive values of a release in its status, unless
+// showSecrets is set.
+func redactStatus(res *services.GetReleaseStatusResponse, rel *release.Release, showSecrets bool) error {
+	red, err := newRedactor(rel, showSecrets)
+	if err != nil {
+		return err
+	}
+	res.Info = red.Info(res.Info)
 	return nil
 }
 
@@ -97,6 +167,9 @@ func PrintStatus(out io.Writer, res *services.GetReleaseStatusResponse) {
 	fmt.Fprintf(out, "NAMESPACE: %s\n", res.Namespace)
 	fmt.Fprintf(out, "STATUS: %s\n", res.Info.Status.Code)
 	fmt.Fprintf(out, "\n")
+	if len(res.Info.Adopted) > 0 {
+		fmt.Fprintf(out, "ADOPTED:\n%s\n\n", strings.Join(res.Info.Adopted, "\n"))
+	}
 	if len(res.Info.Status.Resources) > 0 {
 		re := regexp.MustCompile("  +")
 
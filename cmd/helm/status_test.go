@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/timeconv"
 )
@@ -105,6 +106,82 @@ func TestStatusCmd(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name:     "get status of a release with adopted resources",
+			args:     []string{"flummoxed-chickadee"},
+			expected: outputWithStatus("DEPLOYED\n\nADOPTED:\nDeployment/web\nService/web\n\n"),
+			rel:      releaseMockWithAdopted("Deployment/web", "Service/web"),
+		},
+		{
+			name:     "get status masks sensitive values",
+			args:     []string{"flummoxed-chickadee"},
+			expected: outputWithStatus("DEPLOYED\n\nNOTES:\nLog in with ******\n"),
+			rel:      releaseMockWithSensitiveNotes(),
+		},
+		{
+			name:     "get status shows sensitive values with --show-secrets",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"--show-secrets"},
+			expected: outputWithStatus("DEPLOYED\n\nNOTES:\nLog in with hunter22\n"),
+			rel:      releaseMockWithSensitiveNotes(),
+		},
+		{
+			name:  "get status as json",
+			args:  []string{"flummoxed-chickadee"},
+			flags: []string{"--output", "json"},
+			expected: `{"name":"flummoxed-chickadee","info":{"status":{"code":"DEPLOYED","resources":"==\u003e v1/Service\nNAME READY MESSAGE AGE\nweb true 2m\n","notes":"","last_test_suite_run":null,` +
+				`"resource_statuses":[{"kind":"Service","namespace":"default","name":"web","ready":true,"message":"","age":"120","api_version":"v1","missing":false},` +
+				`{"kind":"Deployment","namespace":"default","name":"web","ready":false,"message":"not found","age":"0","api_version":"extensions/v1beta1","missing":true}]},` +
+				`"first_deployed":"1977-09-02T22:04:05Z","last_deployed":"1977-09-02T22:04:05Z","deleted":null,"Description":"","adopted":[]},"namespace":""}` + "\n",
+			rel: releaseMockWithResourceStatuses(),
+		},
+		{
+			name:  "get status as yaml",
+			args:  []string{"flummoxed-chickadee"},
+			flags: []string{"-o", "yaml"},
+			expected: `info:
+  Description: ""
+  adopted: []
+  deleted: null
+  first_deployed: "1977-09-02T22:04:05Z"
+  last_deployed: "1977-09-02T22:04:05Z"
+  status:
+    code: DEPLOYED
+    last_test_suite_run: null
+    notes: ""
+    resource_statuses:
+    - age: "120"
+      api_version: v1
+      kind: Service
+      message: ""
+      missing: false
+      name: web
+      namespace: default
+      ready: true
+    - age: "0"
+      api_version: extensions/v1beta1
+      kind: Deployment
+      message: not found
+      missing: true
+      name: web
+      namespace: default
+      ready: false
+    resources: |
+      ==> v1/Service
+      NAME READY MESSAGE AGE
+      web true 2m
+name: flummoxed-chickadee
+namespace: ""
+`,
+			rel: releaseMockWithResourceStatuses(),
+		},
+		{
+			name:  "get status in an unknown format",
+			args:  []string{"flummoxed-chickadee"},
+			flags: []string{"-o", "xml"},
+			err:   true,
+			rel:   releaseMockWithResourceStatuses(),
+		},
 	}
 
 	scmd := func(c *helm.FakeClient, out io.Writer) *cobra.Command {
@@ -148,3 +225,34 @@ func releaseMockWithStatus(status *release.Status) *release.Release {
 		},
 	}
 }
+
+func releaseMockWithAdopted(adopted ...string) *release.Release {
+	rel := releaseMockWithStatus(&release.Status{
+		Code: release.Status_DEPLOYED,
+	})
+	rel.Info.Adopted = adopted
+	return rel
+}
+
+func releaseMockWithResourceStatuses() *release.Release {
+	return releaseMockWithStatus(&release.Status{
+		Code:      release.Status_DEPLOYED,
+		Resources: "==> v1/Service\nNAME READY MESSAGE AGE\nweb true 2m\n",
+		ResourceStatuses: []*release.ResourceStatus{
+			{ApiVersion: "v1", Kind: "Service", Namespace: "default", Name: "web", Ready: true, Age: 120},
+			{ApiVersion: "extensions/v1beta1", Kind: "Deployment", Namespace: "default", Name: "web", Message: "not found", Missing: true},
+		},
+	})
+}
+
+func releaseMockWithSensitiveNotes() *release.Release {
+	rel := releaseMockWithStatus(&release.Status{
+		Code:  release.Status_DEPLOYED,
+		Notes: "Log in with hunter22",
+	})
+	rel.Chart = &chart.Chart{
+		Metadata: &chart.Metadata{Name: "db", Version: "0.1.0", Sensitive: []string{"password"}},
+	}
+	rel.Config = &chart.Config{Raw: "password: hunter22\n"}
+	return rel
+}
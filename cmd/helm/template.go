@@ -0,0 +1,291 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/version"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/engine"
+	"k8s.io/helm/pkg/engine/overlay"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/timeconv"
+	tversion "k8s.io/helm/pkg/version"
+)
+
+const templateDesc = `
+Render chart templates locally and display the output.
+
+This does not require Tiller. However, any values that would normally be
+looked up or retrieved in-cluster will be faked locally. Additionally, none
+of the server-side testing of chart validity (e.g. whether an API is supported)
+is done.
+
+Template functions provided by installed plugins are available to the chart.
+
+To render just one template in a chart, use '-x':
+
+	$ helm template mychart -x templates/deployment.yaml
+
+By default templates are rendered against Kubernetes 1.7 with only the core
+"v1" API available. Use '--kube-version', '--api-versions' and
+'--tiller-version', or a capabilities file passed with '--capabilities',
+to render against a different cluster:
+
+	$ cat capabilities.yaml
+	kubeVersion: v1.8.0
+	apiVersions:
+	  - v1
+	  - apps/v1beta2
+	$ helm template mychart --capabilities capabilities.yaml
+
+If '--profile' is set, the time spent rendering each template and each named
+template (invoked through 'include' or 'template') is printed after the
+manifests.
+Profiling is not available for charts that use the overlay engine.
+
+If '--explain-values' is set, nothing is rendered. Instead, every value the
+chart would be rendered with is printed along with where it came from: a
+values file or '--set' flag, the values file of the chart or one of its
+dependencies, one of the chart's environments, or the import-values of a
+dependency.
+
+Use '--environment' to render the chart with the values of one of its
+environments applied, as 'helm install --environment' does.
+`
+
+const (
+	defaultTemplateReleaseName = "RELEASE-NAME"
+	notesFileSuffix            = "NOTES.txt"
+)
+
+type templateCmd struct {
+	namespace    string
+	chartPath    string
+	out          io.Writer
+	environment  string
+	nameTemplate string
+	releaseName  string
+	showNotes    bool
+	renderFiles  []string
+	profile      bool
+	explain      bool
+	capabilities capabilityFlags
+
+	valuesOptions
+}
+
+func newTemplateCmd(out io.Writer) *cobra.Command {
+	t := &templateCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "template [flags] CHART",
+		Short: "locally render templates",
+		Long:  templateDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "chart path"); err != nil {
+				return err
+			}
+			t.chartPath = args[0]
+			return t.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.VarP(&t.valueFiles, "values", "f", "specify values in a YAML file or a URL (can specify multiple)")
+	f.StringVarP(&t.releaseName, "name", "n", defaultTemplateReleaseName, "release name")
+	f.StringVar(&t.namespace, "namespace", "", "namespace to install the release into")
+	f.StringArrayVar(&t.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&t.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&t.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.StringVar(&t.secretKeyring, "secret-keyring", defaultSecretKeyring(), "location of private keys used to decrypt encrypted values files")
+	f.StringVar(&t.environment, "environment", "", "apply the values of the named environment of the chart")
+	f.StringVar(&t.nameTemplate, "name-template", "", "specify template used to name the release")
+	f.BoolVar(&t.showNotes, "notes", false, "show the computed NOTES.txt file as well")
+	f.StringArrayVarP(&t.renderFiles, "execute", "x", []string{}, "only execute the given templates")
+	f.BoolVar(&t.profile, "profile", false, "print the time spent rendering each template")
+	f.BoolVar(&t.explain, "explain-values", false, "print where each value came from instead of rendering the templates")
+	t.capabilities.addFlags(f)
+
+	return cmd
+}
+
+func (t *templateCmd) run() error {
+	if fi, err := os.Stat(t.chartPath); err != nil {
+		return fmt.Errorf("could not find chart %q: %s", t.chartPath, err)
+	} else if !fi.IsDir() && filepath.Ext(t.chartPath) != ".tgz" {
+		return errors.New("chart must be a chart directory or a packaged chart")
+	}
+
+	if t.namespace == "" {
+		t.namespace = defaultNamespace()
+	}
+
+	sources := map[string]string{}
+	base, err := mergeVals(t.valuesOptions, sources)
+	if err != nil {
+		return err
+	}
+	rawVals, err := yaml.Marshal(base)
+	if err != nil {
+		return err
+	}
+	config := &chart.Config{Raw: string(rawVals), Values: map[string]*chart.Value{}}
+
+	override, err := t.capabilities.override()
+	if err != nil {
+		return err
+	}
+
+	if t.nameTemplate != "" {
+		t.releaseName, err = generateName(t.nameTemplate)
+		if err != nil {
+			return err
+		}
+	}
+
+	c, err := chartutil.Load(t.chartPath)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	if req, err := chartutil.LoadRequirements(c); err == nil {
+		if err := checkDependencies(c, req); err != nil {
+			return prettyError(err)
+		}
+	} else if err != chartutil.ErrRequirementsNotFound {
+		return fmt.Errorf("cannot load requirements: %v", err)
+	}
+	defaults := c.Values
+	if t.environment != "" {
+		if err := chartutil.ApplyEnvironment(c, t.environment); err != nil {
+			return err
+		}
+	}
+	if err := chartutil.ProcessRequirementsEnabled(c, config); err != nil {
+		return err
+	}
+	if t.explain {
+		// ExplainEnvironmentValues applies the environment and imports the
+		// values of dependencies itself.
+		c.Values = defaults
+		_, origins, err := chartutil.ExplainEnvironmentValues(c, t.environment, config, sources)
+		if err != nil {
+			return err
+		}
+		printValueOrigins(t.out, origins)
+		return nil
+	}
+	if err := chartutil.ProcessRequirementsImportValues(c); err != nil {
+		return err
+	}
+
+	options := chartutil.ReleaseOptions{
+		Name:      t.releaseName,
+		Time:      timeconv.Now(),
+		Namespace: t.namespace,
+		Revision:  1,
+		IsInstall: true,
+	}
+	caps := chartutil.OverrideCapabilities(&chartutil.Capabilities{
+		APIVersions: chartutil.DefaultVersionSet,
+		KubeVersion: &version.Info{
+			Major:     "1",
+			Minor:     "7",
+			GoVersion: runtime.Version(),
+			Compiler:  runtime.Compiler,
+			Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		},
+		TillerVersion: tversion.GetVersionProto(),
+	}, override)
+	valuesToRender, err := chartutil.ToRenderValuesCaps(c, config, options, caps)
+	if err != nil {
+		return err
+	}
+
+	var (
+		out  map[string]string
+		prof *engine.Profile
+	)
+	renderer, err := newTemplateEngine()
+	if err != nil {
+		return err
+	}
+	switch {
+	case c.Metadata.Engine == overlay.Name:
+		if t.profile {
+			return errors.New("--profile is not supported for charts using the overlay engine")
+		}
+		out, err = overlay.New().Render(c, valuesToRender)
+	case t.profile:
+		out, prof, err = renderer.RenderWithProfile(c, valuesToRender)
+	default:
+		out, err = renderer.Render(c, valuesToRender)
+	}
+	if err != nil {
+		return err
+	}
+
+	t.printManifests(c.Metadata.Name, out)
+	if t.profile {
+		printProfile(t.out, prof)
+	}
+	return nil
+}
+
+// printManifests prints the rendered templates in a stable order, skipping
+// partials, empty files and (unless requested) the chart's NOTES.txt.
+func (t *templateCmd) printManifests(chartName string, out map[string]string) {
+	only := map[string]bool{}
+	for _, f := range t.renderFiles {
+		only[path.Join(chartName, filepath.ToSlash(f))] = true
+	}
+
+	names := make([]string, 0, len(out))
+	for name := range out {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data := out[name]
+		if len(only) > 0 && !only[name] {
+			continue
+		}
+		if strings.HasPrefix(path.Base(name), "_") || len(strings.TrimSpace(data)) == 0 {
+			continue
+		}
+		if strings.HasSuffix(name, notesFileSuffix) && !t.showNotes {
+			continue
+		}
+		fmt.Fprintf(t.out, "---\n# Source: %s\n%s\n", name, data)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestTemplateCmd(t *testing.T) {
+	chartPath := filepath.Join("testdata", "testcharts", "alpine")
+	capsChartPath := filepath.Join("testdata", "testcharts", "capabilities")
+	overlayChartPath := filepath.Join("testdata", "testcharts", "overlay")
+	envChartPath := filepath.Join("testdata", "testcharts", "environments")
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "check_name",
+			args:     []string{chartPath, "--namespace", "default", "--set", "test.Name=bar"},
+			expected: []string{"# Source: alpine/templates/alpine-pod.yaml", `release: "RELEASE-NAME"`, "values: bar"},
+		},
+		{
+			name:     "check_release_name",
+			args:     []string{chartPath, "--namespace", "default", "--name", "ahab", "--set", "test.Name=bar"},
+			expected: []string{`name: "ahab-my-alpine"`},
+		},
+		{
+			name:     "check_profile",
+			args:     []string{chartPath, "--namespace", "default", "--set", "test.Name=bar", "--profile"},
+			expected: []string{"RENDER PROFILE", "alpine/templates/alpine-pod.yaml"},
+		},
+		{
+			name:     "check_default_capabilities",
+			args:     []string{capsChartPath, "--namespace", "default"},
+			expected: []string{`kubeVersion: "1.7"`, `appsV1beta2: "false"`},
+		},
+		{
+			name:     "check_capabilities_flags",
+			args:     []string{capsChartPath, "--namespace", "default", "--kube-version", "1.8", "--api-versions", "v1", "--api-versions", "apps/v1beta2"},
+			expected: []string{`kubeVersion: "1.8"`, `appsV1beta2: "true"`},
+		},
+		{
+			name:     "check_capabilities_file",
+			args:     []string{capsChartPath, "--namespace", "default", "--capabilities", "testdata/capabilities.yaml", "--kube-version", "1.10"},
+			expected: []string{`kubeVersion: "1.10"`, `appsV1beta2: "true"`, `tillerVersion: "v2.9.0"`},
+		},
+		{
+			name:     "check_overlay_engine",
+			args:     []string{overlayChartPath, "--namespace", "default"},
+			expected: []string{"# Source: overlay/templates/service.yaml", "type: NodePort", "port: 80"},
+		},
+		{
+			name:     "check_overlay_engine_set",
+			args:     []string{overlayChartPath, "--namespace", "default", "--set", "overlays[0].merge.spec.type=LoadBalancer"},
+			expected: []string{"type: LoadBalancer"},
+		},
+		{
+			name:     "check_environment",
+			args:     []string{envChartPath, "--namespace", "default", "--environment", "staging"},
+			expected: []string{`replicas: "2"`, `tag: "stable"`, `pullPolicy: "Always"`},
+		},
+		{
+			name:     "check_environment_inherits",
+			args:     []string{envChartPath, "--namespace", "default", "--environment", "prod", "--set", "image.tag=1.0"},
+			expected: []string{`replicas: "5"`, `tag: "1.0"`, `pullPolicy: "IfNotPresent"`},
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		cmd := newTemplateCmd(&buf)
+		cmd.SetArgs(tt.args)
+		if err := cmd.Execute(); err != nil {
+			t.Errorf("%q: unexpected error: %s", tt.name, err)
+			continue
+		}
+		out := buf.String()
+		for _, e := range tt.expected {
+			if !strings.Contains(out, e) {
+				t.Errorf("%q: expected output to contain %q, got:\n%s", tt.name, e, out)
+			}
+		}
+	}
+}
+
+func TestTemplateCmdSchema(t *testing.T) {
+	chartPath := filepath.Join("testdata", "testcharts", "schema")
+
+	var buf bytes.Buffer
+	cmd := newTemplateCmd(&buf)
+	cmd.SetArgs([]string{chartPath, "--set", "replicas=0"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "- replicas: Must be greater than or equal to 1") {
+		t.Errorf("Expected the values to be rejected by the schema, got %v", err)
+	}
+}
+
+func TestTemplateCmdEnvironmentNotFound(t *testing.T) {
+	chartPath := filepath.Join("testdata", "testcharts", "environments")
+
+	var buf bytes.Buffer
+	cmd := newTemplateCmd(&buf)
+	cmd.SetArgs([]string{chartPath, "--environment", "qa"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `environment "qa" not found`) {
+		t.Errorf("Expected the environment not to be found, got %v", err)
+	}
+}
+
+func TestTemplateCmdExplainValues(t *testing.T) {
+	chartPath := filepath.Join("testdata", "testcharts", "alpine")
+	valuesPath := filepath.Join(chartPath, "more_values.yaml")
+	envChartPath := filepath.Join("testdata", "testcharts", "environments")
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name: "chart defaults and values file",
+			args: []string{chartPath, "--explain-values", "-f", valuesPath},
+			expected: []string{
+				`Name\s+"my-alpine"\s+values.yaml in alpine`,
+				`test.Name\s+"more-values"\s+` + regexp.QuoteMeta(valuesPath),
+			},
+		},
+		{
+			name: "set overrides values file",
+			args: []string{chartPath, "--explain-values", "-f", valuesPath, "--set", "test.Name=bar,replicas=2"},
+			expected: []string{
+				`replicas\s+2\s+--set test.Name=bar,replicas=2`,
+				`test.Name\s+"bar"\s+--set test.Name=bar,replicas=2`,
+			},
+		},
+		{
+			name: "environment",
+			args: []string{envChartPath, "--explain-values", "--environment", "prod"},
+			expected: []string{
+				`image.pullPolicy\s+"IfNotPresent"\s+environments/prod/values.yaml in environments`,
+				`image.tag\s+"stable"\s+environments/staging/values.yaml in environments`,
+				`replicas\s+5\s+environments/prod/values.yaml in environments`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		cmd := newTemplateCmd(&buf)
+		cmd.SetArgs(tt.args)
+		if err := cmd.Execute(); err != nil {
+			t.Errorf("%q: unexpected error: %s", tt.name, err)
+			continue
+		}
+		out := buf.String()
+		if strings.Contains(out, "# Source:") {
+			t.Errorf("%q: expected no templates to be rendered, got:\n%s", tt.name, out)
+		}
+		for _, e := range tt.expected {
+			if !regexp.MustCompile(e).MatchString(out) {
+				t.Errorf("%q: expected output to match %q, got:\n%s", tt.name, e, out)
+			}
+		}
+	}
+}
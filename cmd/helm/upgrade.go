@@ -50,34 +50,57 @@ last (right-most) set specified. For example, if both 'bar' and 'newbar' values
 set for a key called 'foo', the 'newbar' value would take precedence:
 
 	$ helm upgrade --set foo=bar --set foo=newbar redis ./redis
+
+To see which values an upgrade would use before running it, pass
+'--preview-values'. Nothing is upgraded: the upgrade is simulated like with
+'--dry-run', and the user-supplied values it would use are printed, along with
+how they differ from the values of the current revision. This accounts for
+'--reuse-values' and '--reset-values', and for an upgrade without values
+reusing the values of the current revision:
+
+	$ helm upgrade --reuse-values --set image.tag=2.0 --preview-values redis ./redis
+
+To take over resources that the new version of the chart adds and that
+already exist in the cluster, pass '--adopt', or annotate them in the chart
+with 'helm.sh/adopt: "true"'. Without either, the upgrade fails on them.
+
+To have the API server check the upgraded resources before anything changes,
+with its admission webhooks, quotas and immutable fields, pass '--validate'.
+Every resource is created or patched as a server-side dry run first, and the
+upgrade stops with the errors of all the rejected resources. This requires
+Kubernetes 1.13 or later:
+
+	$ helm upgrade --validate redis ./redis
 `
 
 type upgradeCmd struct {
-	release      string
-	chart        string
-	out          io.Writer
-	client       helm.Interface
-	dryRun       bool
-	recreate     bool
-	force        bool
-	disableHooks bool
-	valueFiles   valueFiles
-	values       []string
-	verify       bool
-	keyring      string
-	install      bool
-	namespace    string
-	version      string
-	timeout      int64
-	resetValues  bool
-	reuseValues  bool
-	wait         bool
-	repoURL      string
-	devel        bool
-
-	certFile string
-	keyFile  string
-	caFile   string
+	release       string
+	chart         string
+	out           io.Writer
+	client        helm.Interface
+	dryRun        bool
+	recreate      bool
+	force         bool
+	disableHooks  bool
+	verify        bool
+	keyring       string
+	environment   string
+	install       bool
+	namespace     string
+	version       string
+	timeout       int64
+	resetValues   bool
+	reuseValues   bool
+	previewValues bool
+	showSecrets   bool
+	adopt         bool
+	validate      bool
+	wait          bool
+	repoURL       string
+	devel         bool
+	capabilities  capabilityFlags
+
+	valuesOptions
 }
 
 func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -111,27 +134,36 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	}
 
 	f := cmd.Flags()
-	f.VarP(&upgrade.valueFiles, "values", "f", "specify values in a YAML file (can specify multiple)")
+	f.VarP(&upgrade.valueFiles, "values", "f", "specify values in a YAML file or a URL (can specify multiple)")
 	f.BoolVar(&upgrade.dryRun, "dry-run", false, "simulate an upgrade")
 	f.BoolVar(&upgrade.recreate, "recreate-pods", false, "performs pods restart for the resource if applicable")
 	f.BoolVar(&upgrade.force, "force", false, "force resource update through delete/recreate if needed")
 	f.StringArrayVar(&upgrade.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&upgrade.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&upgrade.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 	f.BoolVar(&upgrade.disableHooks, "disable-hooks", false, "disable pre/post upgrade hooks. DEPRECATED. Use no-hooks")
 	f.BoolVar(&upgrade.disableHooks, "no-hooks", false, "disable pre/post upgrade hooks")
 	f.BoolVar(&upgrade.verify, "verify", false, "verify the provenance of the chart before upgrading")
 	f.StringVar(&upgrade.keyring, "keyring", defaultKeyring(), "path to the keyring that contains public signing keys")
+	f.StringVar(&upgrade.secretKeyring, "secret-keyring", defaultSecretKeyring(), "location of private keys used to decrypt encrypted values files")
+	f.StringVar(&upgrade.environment, "environment", "", "apply the values of the named environment of the chart")
 	f.BoolVarP(&upgrade.install, "install", "i", false, "if a release by this name doesn't already exist, run an install")
 	f.StringVar(&upgrade.namespace, "namespace", "default", "namespace to install the release into (only used if --install is set)")
 	f.StringVar(&upgrade.version, "version", "", "specify the exact chart version to use. If this is not specified, the latest version is used")
 	f.Int64Var(&upgrade.timeout, "timeout", 300, "time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&upgrade.resetValues, "reset-values", false, "when upgrading, reset the values to the ones built into the chart")
 	f.BoolVar(&upgrade.reuseValues, "reuse-values", false, "when upgrading, reuse the last release's values, and merge in any new values. If '--reset-values' is specified, this is ignored.")
+	f.BoolVar(&upgrade.previewValues, "preview-values", false, "simulate an upgrade, and print the values it would use and how they differ from the current release's values")
+	f.BoolVar(&upgrade.showSecrets, "show-secrets", false, "do not mask the values that the chart marks as sensitive")
+	f.BoolVar(&upgrade.adopt, "adopt", false, "take over resources of the chart that already exist in the cluster, instead of failing")
+	f.BoolVar(&upgrade.validate, "validate", false, "validate the resources with a server-side dry run against the API server before applying them. Requires Kubernetes 1.13 or later")
 	f.BoolVar(&upgrade.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
 	f.StringVar(&upgrade.repoURL, "repo", "", "chart repository url where to locate the requested chart")
 	f.StringVar(&upgrade.certFile, "cert-file", "", "identify HTTPS client using this SSL certificate file")
 	f.StringVar(&upgrade.keyFile, "key-file", "", "identify HTTPS client using this SSL key file")
 	f.StringVar(&upgrade.caFile, "ca-file", "", "verify certificates of HTTPS-enabled servers using this CA bundle")
 	f.BoolVar(&upgrade.devel, "devel", false, "use development versions, too. Equivalent to version '>0.0.0-a'. If --version is set, this is ignored.")
+	upgrade.capabilities.addFlags(f)
 
 	f.MarkDeprecated("disable-hooks", "use --no-hooks instead")
 
@@ -139,6 +171,10 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 }
 
 func (u *upgradeCmd) run() error {
+	if u.previewValues {
+		u.dryRun = true
+	}
+
 	chartPath, err := locateChartPath(u.repoURL, u.chart, u.version, u.verify, u.keyring, u.certFile, u.keyFile, u.caFile)
 	if err != nil {
 		return err
@@ -155,45 +191,60 @@ func (u *upgradeCmd) run() error {
 		if err != nil && strings.Contains(err.Error(), driver.ErrReleaseNotFound(u.release).Error()) {
 			fmt.Fprintf(u.out, "Release %q does not exist. Installing it now.\n", u.release)
 			ic := &installCmd{
-				chartPath:    chartPath,
-				client:       u.client,
-				out:          u.out,
-				name:         u.release,
-				valueFiles:   u.valueFiles,
-				dryRun:       u.dryRun,
-				verify:       u.verify,
-				disableHooks: u.disableHooks,
-				keyring:      u.keyring,
-				values:       u.values,
-				namespace:    u.namespace,
-				timeout:      u.timeout,
-				wait:         u.wait,
+				chartPath:     chartPath,
+				client:        u.client,
+				out:           u.out,
+				name:          u.release,
+				dryRun:        u.dryRun,
+				verify:        u.verify,
+				disableHooks:  u.disableHooks,
+				keyring:       u.keyring,
+				environment:   u.environment,
+				showSecrets:   u.showSecrets,
+				adopt:         u.adopt,
+				validate:      u.validate,
+				namespace:     u.namespace,
+				timeout:       u.timeout,
+				wait:          u.wait,
+				capabilities:  u.capabilities,
+				valuesOptions: u.valuesOptions,
 			}
 			return ic.run()
 		}
 	}
 
-	rawVals, err := vals(u.valueFiles, u.values)
+	rawVals, err := vals(u.valuesOptions)
+	if err != nil {
+		return err
+	}
+
+	caps, err := u.capabilities.dryRunOverride(u.dryRun)
 	if err != nil {
 		return err
 	}
 
 	// Check chart requirements to make sure all dependencies are present in /charts
-	if ch, err := chartutil.Load(chartPath); err == nil {
-		if req, err := chartutil.LoadRequirements(ch); err == nil {
-			if err := checkDependencies(ch, req); err != nil {
-				return err
-			}
-		} else if err != chartutil.ErrRequirementsNotFound {
-			return fmt.Errorf("cannot load requirements: %v", err)
-		}
-	} else {
+	ch, err := chartutil.Load(chartPath)
+	if err != nil {
 		return prettyError(err)
 	}
+	if req, err := chartutil.LoadRequirements(ch); err == nil {
+		if err := checkDependencies(ch, req); err != nil {
+			return err
+		}
+	} else if err != chartutil.ErrRequirementsNotFound {
+		return fmt.Errorf("cannot load requirements: %v", err)
+	}
 
-	resp, err := u.client.UpdateRelease(
+	if u.environment != "" {
+		if err := chartutil.ApplyEnvironment(ch, u.environment); err != nil {
+			return err
+		}
+	}
+
+	resp, err := u.client.UpdateReleaseFromChart(
 		u.release,
-		chartPath,
+		ch,
 		helm.UpdateValueOverrides(rawVals),
 		helm.UpgradeDryRun(u.dryRun),
 		helm.UpgradeRecreate(u.recreate),
@@ -202,13 +253,31 @@ func (u *upgradeCmd) run() error {
 		helm.UpgradeTimeout(u.timeout),
 		helm.ResetValues(u.resetValues),
 		helm.ReuseValues(u.reuseValues),
-		helm.UpgradeWait(u.wait))
+		helm.UpgradeWait(u.wait),
+		helm.UpgradeAdopt(u.adopt),
+		helm.UpgradeValidate(u.validate),
+		helm.UpgradeProgress(progressPrinter(u.out, u.wait, !u.disableHooks && !u.dryRun)),
+		helm.UpgradeCapabilities(caps))
 	if err != nil {
 		return fmt.Errorf("UPGRADE FAILED: %v", prettyError(err))
 	}
 
+	if u.previewValues {
+		// Tiller upgrades against the last revision of the release.
+		h, err := u.client.ReleaseHistory(u.release, helm.WithMaxHistory(1))
+		if err != nil {
+			return prettyError(err)
+		}
+		if len(h.Releases) == 0 {
+			return fmt.Errorf("release %q has no revisions", u.release)
+		}
+		return printValuesPreview(u.out, h.Releases[0], resp.Release, u.reuseValues && !u.resetValues, u.showSecrets)
+	}
+
 	if settings.Debug {
-		printRelease(u.out, resp.Release)
+		if err := printRelease(u.out, resp.Release, u.showSecrets); err != nil {
+			return err
+		}
 	}
 
 	fmt.Fprintf(u.out, "Release %q has been upgraded. Happy Helming!\n", u.release)
@@ -218,6 +287,9 @@ func (u *upgradeCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
+	if err := redactStatus(status, resp.Release, u.showSecrets); err != nil {
+		return err
+	}
 	PrintStatus(u.out, status)
 
 	return nil
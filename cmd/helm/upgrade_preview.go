@@ -0,0 +1,190 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+// valueChange is a difference between the user-supplied values of two
+// revisions of a release.
+type valueChange struct {
+	path          string
+	before, after interface{}
+	// added and removed tell a value that is only in one revision from a
+	// null one.
+	added, removed bool
+}
+
+type byChangePath []valueChange
+
+func (c byChangePath) Len() int           { return len(c) }
+func (c byChangePath) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c byChangePath) Less(i, j int) bool { return c[i].path < c[j].path }
+
+// printValuesPreview prints the user-supplied values an upgrade of current
+// would use, as returned by a dry-run upgrade, and how they differ from the
+// values of current.
+//
+// When reuse is set, Tiller coalesces the supplied values over the values of
+// current, so the preview does the same. Unless showSecrets is set, the values
+// that the upgraded chart marks as sensitive are masked.
+func printValuesPreview(out io.Writer, current, upgraded *release.Release, reuse, showSecrets bool) error {
+	old, err := readConfig(current.Config)
+	if err != nil {
+		return fmt.Errorf("cannot read the values of revision %d: %s", current.Version, err)
+	}
+	vals, err := readConfig(upgraded.Config)
+	if err != nil {
+		return fmt.Errorf("cannot read the values of the upgrade: %s", err)
+	}
+	if reuse {
+		// The values of current are coalesced under the supplied ones as
+		// if they were the defaults of the chart.
+		supplied := upgraded.Config
+		if supplied == nil {
+			supplied = &chart.Config{}
+		}
+		coalesced, err := chartutil.CoalesceValues(&chart.Chart{Values: current.Config}, supplied)
+		if err != nil {
+			return fmt.Errorf("cannot reuse the values of revision %d: %s", current.Version, err)
+		}
+		vals = coalesced.AsMap()
+	}
+	changes := diffValues(old, vals)
+
+	red, err := newRedactor(upgraded, showSecrets)
+	if err != nil {
+		return err
+	}
+	maskChanges(red, changes)
+	data, err := yaml.Marshal(red.Values(vals))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "USER-SUPPLIED VALUES:")
+	fmt.Fprint(out, string(data))
+	if reuse {
+		fmt.Fprintf(out, "\nThe values of revision %d, coalesced with the defaults of its chart, are used instead of the defaults of the new chart.\n", current.Version)
+	}
+
+	fmt.Fprintf(out, "\nCHANGES FROM REVISION %d:\n", current.Version)
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "none")
+		return nil
+	}
+	for _, c := range changes {
+		switch {
+		case c.added:
+			fmt.Fprintf(out, "+ %s: %s\n", c.path, formatValue(c.after))
+		case c.removed:
+			fmt.Fprintf(out, "- %s: %s\n", c.path, formatValue(c.before))
+		default:
+			fmt.Fprintf(out, "~ %s: %s -> %s\n", c.path, formatValue(c.before), formatValue(c.after))
+		}
+	}
+	return nil
+}
+
+// maskChanges masks the sensitive values of changes. The changes themselves
+// are kept, so that changed secrets still show up.
+func maskChanges(red *releaseutil.Redactor, changes []valueChange) {
+	for i := range changes {
+		c := &changes[i]
+		masked := red.Origins([]chartutil.ValueOrigin{
+			{Path: c.path, Value: c.before},
+			{Path: c.path, Value: c.after},
+		})
+		c.before, c.after = masked[0].Value, masked[1].Value
+	}
+}
+
+// readConfig reads the values of a release config. A missing config has no
+// values.
+func readConfig(config *chart.Config) (map[string]interface{}, error) {
+	if config == nil {
+		return map[string]interface{}{}, nil
+	}
+	vals, err := chartutil.ReadValues([]byte(config.Raw))
+	return vals.AsMap(), err
+}
+
+// diffValues returns the changes from the values before to the values after,
+// sorted by path. Tables are compared key by key, other values as a whole.
+func diffValues(before, after map[string]interface{}) []valueChange {
+	var changes []valueChange
+	diffTables("", before, after, &changes)
+	sort.Sort(byChangePath(changes))
+	return changes
+}
+
+func diffTables(prefix string, before, after map[string]interface{}, changes *[]valueChange) {
+	for k, bv := range before {
+		p := joinValuesPath(prefix, k)
+		av, ok := after[k]
+		if !ok {
+			removeValue(p, bv, changes)
+			continue
+		}
+		bt, beforeTable := bv.(map[string]interface{})
+		at, afterTable := av.(map[string]interface{})
+		switch {
+		case beforeTable && afterTable:
+			diffTables(p, bt, at, changes)
+		case !reflect.DeepEqual(bv, av):
+			*changes = append(*changes, valueChange{path: p, before: bv, after: av})
+		}
+	}
+	for k, av := range after {
+		if _, ok := before[k]; !ok {
+			addValue(joinValuesPath(prefix, k), av, changes)
+		}
+	}
+}
+
+func addValue(path string, v interface{}, changes *[]valueChange) {
+	if t, ok := v.(map[string]interface{}); ok && len(t) > 0 {
+		diffTables(path, map[string]interface{}{}, t, changes)
+		return
+	}
+	*changes = append(*changes, valueChange{path: path, after: v, added: true})
+}
+
+func removeValue(path string, v interface{}, changes *[]valueChange) {
+	if t, ok := v.(map[string]interface{}); ok && len(t) > 0 {
+		diffTables(path, t, map[string]interface{}{}, changes)
+		return
+	}
+	*changes = append(*changes, valueChange{path: path, before: v, removed: true})
+}
+
+func joinValuesPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestPrintValuesPreview(t *testing.T) {
+	current := &release.Release{
+		Name:    "funny-bunny",
+		Version: 3,
+		Config:  &chart.Config{Raw: "image:\n  tag: \"1.0\"\n  pullPolicy: Always\nreplicas: 2\ndebug: true\n"},
+	}
+
+	tests := []struct {
+		name     string
+		supplied string
+		reuse    bool
+		expected string
+	}{
+		{
+			name:     "new values replace the current ones",
+			supplied: "image:\n  tag: \"2.0\"\nreplicas: 3\n",
+			expected: `USER-SUPPLIED VALUES:
+image:
+  tag: "2.0"
+replicas: 3
+
+CHANGES FROM REVISION 3:
+- debug: true
+- image.pullPolicy: "Always"
+~ image.tag: "1.0" -> "2.0"
+~ replicas: 2 -> 3
+`,
+		},
+		{
+			name:     "reused values are merged with the new ones",
+			supplied: "image:\n  tag: \"2.0\"\ndebug: null\nextra:\n  enabled: true\n",
+			reuse:    true,
+			expected: `USER-SUPPLIED VALUES:
+extra:
+  enabled: true
+image:
+  pullPolicy: Always
+  tag: "2.0"
+replicas: 2
+
+The values of revision 3, coalesced with the defaults of its chart, are used instead of the defaults of the new chart.
+
+CHANGES FROM REVISION 3:
+- debug: true
++ extra.enabled: true
+~ image.tag: "1.0" -> "2.0"
+`,
+		},
+		{
+			name:     "unchanged values",
+			supplied: current.Config.Raw,
+			expected: "CHANGES FROM REVISION 3:\nnone\n",
+		},
+	}
+
+	for _, tt := range tests {
+		upgraded := &release.Release{Name: "funny-bunny", Config: &chart.Config{Raw: tt.supplied}}
+		var buf bytes.Buffer
+		if err := printValuesPreview(&buf, current, upgraded, tt.reuse, false); err != nil {
+			t.Errorf("%q: unexpected error: %s", tt.name, err)
+			continue
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte(tt.expected)) {
+			t.Errorf("%q: expected output to end with\n%s\ngot\n%s", tt.name, tt.expected, buf.String())
+		}
+	}
+}
+
+func TestPrintValuesPreviewNoValues(t *testing.T) {
+	current := &release.Release{Name: "funny-bunny", Version: 1}
+	upgraded := &release.Release{Name: "funny-bunny", Config: &chart.Config{Raw: "replicas: 2\n"}}
+
+	var buf bytes.Buffer
+	if err := printValuesPreview(&buf, current, upgraded, true, false); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "CHANGES FROM REVISION 1:\n+ replicas: 2\n"; !bytes.HasSuffix(buf.Bytes(), []byte(expect)) {
+		t.Errorf("expected output to end with\n%s\ngot\n%s", expect, buf.String())
+	}
+}
+
+func TestPrintValuesPreviewSensitive(t *testing.T) {
+	current := &release.Release{
+		Name:    "funny-bunny",
+		Version: 2,
+		Config:  &chart.Config{Raw: "db:\n  password: hunter22\n  user: admin\n"},
+	}
+	upgraded := &release.Release{
+		Name: "funny-bunny",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "funny", Sensitive: []string{"db.password"}},
+		},
+		Config: &chart.Config{Raw: "db:\n  password: s3cr3t-pass\n  user: root\n"},
+	}
+
+	var buf bytes.Buffer
+	if err := printValuesPreview(&buf, current, upgraded, false, false); err != nil {
+		t.Fatal(err)
+	}
+	expect := `USER-SUPPLIED VALUES:
+db:
+  password: '******'
+  user: root
+
+CHANGES FROM REVISION 2:
+~ db.password: "******" -> "******"
+~ db.user: "admin" -> "root"
+`
+	if buf.String() != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, buf.String())
+	}
+
+	buf.Reset()
+	if err := printValuesPreview(&buf, current, upgraded, false, true); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`~ db.password: "hunter22" -> "s3cr3t-pass"`)) {
+		t.Errorf("expected the secrets to be shown, got\n%s", buf.String())
+	}
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package main
 
 import (
-	"bytes"
 	"fmt"
 	"net"
 
@@ -68,11 +67,11 @@ func (r *ReleaseModuleServiceServer) Version(ctx context.Context, in *rudderAPI.
 	}, nil
 }
 
-// InstallRelease creates a release using kubeClient.Create
+// InstallRelease creates a release using tiller.CreateInWaves
 func (r *ReleaseModuleServiceServer) InstallRelease(ctx context.Context, in *rudderAPI.InstallReleaseRequest) (*rudderAPI.InstallReleaseResponse, error) {
 	grpclog.Print("install")
-	b := bytes.NewBufferString(in.Release.Manifest)
-	err := kubeClient.Create(in.Release.Namespace, b, 500, false)
+	kc := kubeClient.WithOwner(in.Release.Name, in.Release.Namespace)
+	err := tiller.CreateInWaves(kc, in.Release.Namespace, in.Release.Manifest, 500, false)
 	if err != nil {
 		grpclog.Printf("error when creating release: %v", err)
 	}
@@ -90,7 +89,7 @@ func (r *ReleaseModuleServiceServer) DeleteRelease(ctx context.Context, in *rudd
 		return resp, fmt.Errorf("Could not get apiVersions from Kubernetes: %v", err)
 	}
 
-	kept, errs := tiller.DeleteRelease(rel, vs, kubeClient)
+	kept, errs := tiller.DeleteRelease(rel, vs, kubeClient.WithOwner(rel.Name, rel.Namespace))
 	rel.Manifest = kept
 
 	allErrors := ""
@@ -110,18 +109,16 @@ func (r *ReleaseModuleServiceServer) DeleteRelease(ctx context.Context, in *rudd
 // RollbackRelease rolls back the release
 func (r *ReleaseModuleServiceServer) RollbackRelease(ctx context.Context, in *rudderAPI.RollbackReleaseRequest) (*rudderAPI.RollbackReleaseResponse, error) {
 	grpclog.Print("rollback")
-	c := bytes.NewBufferString(in.Current.Manifest)
-	t := bytes.NewBufferString(in.Target.Manifest)
-	err := kubeClient.Update(in.Target.Namespace, c, t, in.Force, in.Recreate, in.Timeout, in.Wait)
+	kc := kubeClient.WithOwner(in.Target.Name, in.Target.Namespace)
+	err := tiller.UpdateInWaves(kc, in.Target.Namespace, in.Current.Manifest, in.Target.Manifest, in.Force, in.Recreate, in.Timeout, in.Wait)
 	return &rudderAPI.RollbackReleaseResponse{}, err
 }
 
 // UpgradeRelease upgrades manifests using kubernetes client
 func (r *ReleaseModuleServiceServer) UpgradeRelease(ctx context.Context, in *rudderAPI.UpgradeReleaseRequest) (*rudderAPI.UpgradeReleaseResponse, error) {
 	grpclog.Print("upgrade")
-	c := bytes.NewBufferString(in.Current.Manifest)
-	t := bytes.NewBufferString(in.Target.Manifest)
-	err := kubeClient.Update(in.Target.Namespace, c, t, in.Force, in.Recreate, in.Timeout, in.Wait)
+	kc := kubeClient.WithOwner(in.Target.Name, in.Target.Namespace)
+	err := tiller.UpdateInWaves(kc, in.Target.Namespace, in.Current.Manifest, in.Target.Manifest, in.Force, in.Recreate, in.Timeout, in.Wait)
 	// upgrade response object should be changed to include status
 	return &rudderAPI.UpgradeReleaseResponse{}, err
 }
@@ -130,8 +127,8 @@ func (r *ReleaseModuleServiceServer) UpgradeRelease(ctx context.Context, in *rud
 func (r *ReleaseModuleServiceServer) ReleaseStatus(ctx context.Context, in *rudderAPI.ReleaseStatusRequest) (*rudderAPI.ReleaseStatusResponse, error) {
 	grpclog.Print("status")
 
-	resp, err := kubeClient.Get(in.Release.Namespace, bytes.NewBufferString(in.Release.Manifest))
-	in.Release.Info.Status.Resources = resp
+	statuses, err := tiller.GetResourceStatuses(kubeClient, in.Release)
+	in.Release.Info.Status.ResourceStatuses = statuses
 	return &rudderAPI.ReleaseStatusResponse{
 		Release: in.Release,
 		Info:    in.Release.Info,
@@ -18,6 +18,7 @@ package main // import "k8s.io/helm/cmd/tiller"
 
 import (
 	"crypto/tls"
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -32,7 +33,9 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/plugin"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/storage"
 	"k8s.io/helm/pkg/storage/driver"
@@ -69,6 +72,7 @@ var (
 	keyFile              = flag.String("tls-key", tlsDefaultsFromEnv("tls-key"), "path to TLS private key file")
 	certFile             = flag.String("tls-cert", tlsDefaultsFromEnv("tls-cert"), "path to TLS certificate file")
 	caCertFile           = flag.String("tls-ca-cert", tlsDefaultsFromEnv("tls-ca-cert"), "trust certificates signed by this CA")
+	pluginDirs           = flag.String("plugin-dirs", "", "list of directories holding Helm plugins whose template functions are made available to charts")
 
 	// rootServer is the root gRPC server.
 	//
@@ -119,6 +123,12 @@ func start() {
 	kubeClient.Log = newLogger("kube").Printf
 	env.KubeClient = kubeClient
 
+	if *pluginDirs != "" {
+		if err := addPluginTemplateFuncs(*pluginDirs); err != nil {
+			logger.Fatalf("Cannot load template function plugins: %s", err)
+		}
+	}
+
 	if *tlsEnable || *tlsVerify {
 		opts := tlsutil.Options{CertFile: *certFile, KeyFile: *keyFile}
 		if *tlsVerify {
@@ -207,6 +217,28 @@ func namespace() string {
 	return environment.DefaultTillerNamespace
 }
 
+// addPluginTemplateFuncs registers the template functions declared by the
+// plugins found in dirs with the Go template engine.
+func addPluginTemplateFuncs(dirs string) error {
+	plugins, err := plugin.FindPlugins(dirs)
+	if err != nil {
+		return err
+	}
+	funcs, err := plugin.TemplateFuncs(plugins)
+	if err != nil {
+		return err
+	}
+	e, ok := env.EngineYard[environment.GoTplEngine].(*engine.Engine)
+	if !ok {
+		return errors.New("the Go template engine does not support extra functions")
+	}
+	if err := e.AddFuncs(funcs); err != nil {
+		return err
+	}
+	logger.Printf("Registered %d template functions from plugins", len(funcs))
+	return nil
+}
+
 func tlsOptions() tlsutil.Options {
 	opts := tlsutil.Options{CertFile: *certFile, KeyFile: *keyFile}
 	if *tlsVerify {
@@ -16,10 +16,21 @@ limitations under the License.
 package chartutil
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/Masterminds/semver"
+	"github.com/ghodss/yaml"
 	"k8s.io/apimachinery/pkg/version"
+
 	tversion "k8s.io/helm/pkg/proto/hapi/version"
 )
 
+// ErrCapabilitiesNotDryRun indicates that capabilities were overridden outside of a dry run.
+var ErrCapabilitiesNotDryRun = errors.New("capabilities can only be overridden on a dry run")
+
 // DefaultVersionSet is the default version set, which includes only Core V1 ("v1").
 var DefaultVersionSet = NewVersionSet("v1")
 
@@ -56,3 +67,112 @@ func (v VersionSet) Has(apiVersion string) bool {
 	_, ok := v[apiVersion]
 	return ok
 }
+
+// Versions returns the API versions in the set, sorted.
+func (v VersionSet) Versions() []string {
+	versions := make([]string, 0, len(v))
+	for k := range v {
+		versions = append(versions, k)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// capabilitiesFile is the on-disk format of a capabilities file:
+//
+//	kubeVersion: v1.8.0
+//	apiVersions:
+//	  - v1
+//	  - apps/v1beta1
+//	tillerVersion: v2.6.0
+type capabilitiesFile struct {
+	KubeVersion   string   `json:"kubeVersion,omitempty"`
+	APIVersions   []string `json:"apiVersions,omitempty"`
+	TillerVersion string   `json:"tillerVersion,omitempty"`
+}
+
+// ReadCapabilities parses the contents of a capabilities file.
+//
+// Fields that are absent from the file are left empty in the returned
+// Capabilities, so that it can be used as an override.
+func ReadCapabilities(data []byte) (*Capabilities, error) {
+	f := capabilitiesFile{}
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	caps := &Capabilities{}
+	if len(f.APIVersions) > 0 {
+		caps.APIVersions = NewVersionSet(f.APIVersions...)
+	}
+	if f.KubeVersion != "" {
+		kv, err := ParseKubeVersion(f.KubeVersion)
+		if err != nil {
+			return nil, err
+		}
+		caps.KubeVersion = kv
+	}
+	if f.TillerVersion != "" {
+		tv, err := ParseTillerVersion(f.TillerVersion)
+		if err != nil {
+			return nil, err
+		}
+		caps.TillerVersion = tv
+	}
+	return caps, nil
+}
+
+// ReadCapabilitiesFile reads and parses a capabilities file.
+func ReadCapabilitiesFile(filename string) (*Capabilities, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	caps, err := ReadCapabilities(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse capabilities file %s: %s", filename, err)
+	}
+	return caps, nil
+}
+
+// ParseKubeVersion parses a Kubernetes version such as "1.8" or "v1.8.2".
+func ParseKubeVersion(v string) (*version.Info, error) {
+	sv, err := semver.NewVersion(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Kubernetes version %q: %s", v, err)
+	}
+	return &version.Info{
+		Major:      fmt.Sprint(sv.Major()),
+		Minor:      fmt.Sprint(sv.Minor()),
+		GitVersion: "v" + sv.String(),
+	}, nil
+}
+
+// ParseTillerVersion parses a Tiller version such as "v2.6.0".
+func ParseTillerVersion(v string) (*tversion.Version, error) {
+	sv, err := semver.NewVersion(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Tiller version %q: %s", v, err)
+	}
+	return &tversion.Version{SemVer: "v" + sv.String()}, nil
+}
+
+// OverrideCapabilities returns a copy of base in which every field that is
+// set in override replaces the corresponding field of base.
+//
+// A nil override returns base unchanged.
+func OverrideCapabilities(base, override *Capabilities) *Capabilities {
+	if override == nil {
+		return base
+	}
+	caps := *base
+	if len(override.APIVersions) > 0 {
+		caps.APIVersions = override.APIVersions
+	}
+	if override.KubeVersion != nil {
+		caps.KubeVersion = override.KubeVersion
+	}
+	if override.TillerVersion != nil {
+		caps.TillerVersion = override.TillerVersion
+	}
+	return &caps
+}
@@ -17,6 +17,10 @@ package chartutil
 
 import (
 	"testing"
+
+	"k8s.io/apimachinery/pkg/version"
+
+	tversion "k8s.io/helm/pkg/proto/hapi/version"
 )
 
 func TestVersionSet(t *testing.T) {
@@ -52,3 +56,59 @@ func TestCapabilities(t *testing.T) {
 		t.Error("APIVersions should have v1")
 	}
 }
+
+func TestReadCapabilities(t *testing.T) {
+	data := []byte(`kubeVersion: "1.8"
+apiVersions:
+  - v1
+  - apps/v1beta1
+tillerVersion: 2.6.0
+`)
+	caps, err := ReadCapabilities(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps.KubeVersion.Major != "1" || caps.KubeVersion.Minor != "8" {
+		t.Errorf("Expected Kubernetes 1.8, got %s.%s", caps.KubeVersion.Major, caps.KubeVersion.Minor)
+	}
+	if caps.KubeVersion.GitVersion != "v1.8.0" {
+		t.Errorf("Expected git version v1.8.0, got %s", caps.KubeVersion.GitVersion)
+	}
+	if !caps.APIVersions.Has("apps/v1beta1") || len(caps.APIVersions) != 2 {
+		t.Errorf("Unexpected API versions %v", caps.APIVersions.Versions())
+	}
+	if caps.TillerVersion.SemVer != "v2.6.0" {
+		t.Errorf("Expected Tiller v2.6.0, got %s", caps.TillerVersion.SemVer)
+	}
+
+	if _, err := ReadCapabilities([]byte(`kubeVersion: one.eight`)); err == nil {
+		t.Error("Expected an error for an invalid Kubernetes version")
+	}
+}
+
+func TestOverrideCapabilities(t *testing.T) {
+	base := &Capabilities{
+		APIVersions:   DefaultVersionSet,
+		KubeVersion:   &version.Info{Major: "1", Minor: "7"},
+		TillerVersion: &tversion.Version{SemVer: "v2.5.0"},
+	}
+
+	if OverrideCapabilities(base, nil) != base {
+		t.Error("Expected a nil override to return the base capabilities")
+	}
+
+	kv, err := ParseKubeVersion("v1.9.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	caps := OverrideCapabilities(base, &Capabilities{KubeVersion: kv})
+	if caps.KubeVersion.Minor != "9" {
+		t.Errorf("Expected overridden Kubernetes minor version 9, got %s", caps.KubeVersion.Minor)
+	}
+	if !caps.APIVersions.Has("v1") || caps.TillerVersion.SemVer != "v2.5.0" {
+		t.Error("Expected unset fields to fall back to the base capabilities")
+	}
+	if base.KubeVersion.Minor != "7" {
+		t.Error("Expected the base capabilities to be left unchanged")
+	}
+}
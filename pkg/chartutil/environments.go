@@ -0,0 +1,205 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const (
+	// EnvironmentsDir is the directory of a chart that holds its environments.
+	//
+	// Each environment is a directory named after it, holding a values file
+	// and, optionally, an environment file:
+	//
+	//	environments/
+	//	  staging/
+	//	    values.yaml
+	//	  prod/
+	//	    environment.yaml  # inherits: staging
+	//	    values.yaml
+	EnvironmentsDir = "environments"
+	// EnvironmentValuesfileName is the name of the values file of an environment.
+	EnvironmentValuesfileName = "values.yaml"
+	// EnvironmentfileName is the name of the file describing an environment.
+	EnvironmentfileName = "environment.yaml"
+)
+
+// Environment describes a named environment of a chart.
+type Environment struct {
+	// Inherits is the name of the environment whose values this one overrides.
+	Inherits string `json:"inherits,omitempty"`
+}
+
+// Environments returns the sorted names of the environments of a chart.
+func Environments(c *chart.Chart) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, f := range c.Files {
+		name, file := splitEnvironmentPath(f.TypeUrl)
+		if name == "" || (file != EnvironmentValuesfileName && file != EnvironmentfileName) {
+			continue
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadEnvironment reads the environment file of a named environment of a chart.
+//
+// An environment without an environment file inherits from no other one.
+func LoadEnvironment(c *chart.Chart, name string) (*Environment, error) {
+	if !hasEnvironment(c, name) {
+		return nil, fmt.Errorf("environment %q not found in chart %s", name, c.Metadata.Name)
+	}
+	env := &Environment{}
+	data, ok := environmentFile(c, name, EnvironmentfileName)
+	if !ok {
+		return env, nil
+	}
+	if err := yaml.Unmarshal(data, env); err != nil {
+		return nil, fmt.Errorf("cannot load %s: %s", path.Join(EnvironmentsDir, name, EnvironmentfileName), err)
+	}
+	return env, nil
+}
+
+// EnvironmentStack returns the names of the environments whose values make up
+// a named environment, starting with the environment it ultimately inherits
+// from and ending with the named one.
+func EnvironmentStack(c *chart.Chart, name string) ([]string, error) {
+	stack := []string{}
+	seen := map[string]bool{}
+	for n := name; ; {
+		if seen[n] {
+			return nil, fmt.Errorf("environment %q inherits from itself: %s", name, strings.Join(append(stack, n), " -> "))
+		}
+		seen[n] = true
+		env, err := LoadEnvironment(c, n)
+		if err != nil {
+			return nil, err
+		}
+		stack = append(stack, n)
+		if env.Inherits == "" {
+			break
+		}
+		n = env.Inherits
+	}
+	for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+		stack[i], stack[j] = stack[j], stack[i]
+	}
+	return stack, nil
+}
+
+// EnvironmentValues returns the values of a named environment of a chart,
+// coalesced with the values of the environments it inherits from. The values
+// of an environment override the ones it inherits.
+func EnvironmentValues(c *chart.Chart, name string) (Values, error) {
+	return coalesceEnvironment(c, name, map[string]interface{}{}, nil)
+}
+
+// ApplyEnvironment makes the values of a named environment of a chart part
+// of the chart's default values.
+//
+// The environment values override the ones in the chart's values file, and
+// are overridden in turn by the supplied values. Values of dependency charts
+// and globals may be set in an environment just as in the values file.
+func ApplyEnvironment(c *chart.Chart, name string) error {
+	defaults, err := readDefaults(c)
+	if err != nil {
+		return err
+	}
+	if defaults == nil {
+		defaults = map[string]interface{}{}
+	}
+	vals, err := coalesceEnvironment(c, name, defaults, nil)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(vals)
+	if err != nil {
+		return err
+	}
+	// The values are replaced rather than changed in place, so that callers
+	// can keep the chart's own values.
+	c.Values = &chart.Config{Raw: string(data)}
+	return nil
+}
+
+// coalesceEnvironment coalesces the values of a named environment of a chart
+// and of the environments it inherits from over vals. If tag is not nil, the
+// values of each environment are passed through it first.
+func coalesceEnvironment(c *chart.Chart, name string, vals map[string]interface{}, tag func(env string, ev map[string]interface{}) map[string]interface{}) (map[string]interface{}, error) {
+	stack, err := EnvironmentStack(c, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range stack {
+		data, ok := environmentFile(c, n, EnvironmentValuesfileName)
+		if !ok {
+			continue
+		}
+		ev, err := ReadValues(data)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load %s: %s", path.Join(EnvironmentsDir, n, EnvironmentValuesfileName), err)
+		}
+		if tag != nil {
+			ev = tag(n, ev)
+		}
+		vals = coalesceTables(ev, vals)
+	}
+	return vals, nil
+}
+
+// splitEnvironmentPath returns the environment and the file name of a path in
+// the environments directory of a chart.
+func splitEnvironmentPath(name string) (env, file string) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 || parts[0] != EnvironmentsDir {
+		return "", ""
+	}
+	return parts[1], parts[2]
+}
+
+func hasEnvironment(c *chart.Chart, name string) bool {
+	if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+		return false
+	}
+	_, values := environmentFile(c, name, EnvironmentValuesfileName)
+	_, env := environmentFile(c, name, EnvironmentfileName)
+	return values || env
+}
+
+func environmentFile(c *chart.Chart, env, file string) ([]byte, bool) {
+	name := path.Join(EnvironmentsDir, env, file)
+	for _, f := range c.Files {
+		if f.TypeUrl == name {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
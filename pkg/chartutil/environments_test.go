@@ -0,0 +1,191 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func environmentsChart(files map[string]string) *chart.Chart {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "envs"},
+		Values: &chart.Config{Raw: `
+replicas: 1
+image:
+  tag: latest
+  pullPolicy: Always
+debug: true
+$merge:
+  hosts: append
+hosts:
+- localhost
+`},
+	}
+	for name, data := range files {
+		c.Files = append(c.Files, &any.Any{TypeUrl: name, Value: []byte(data)})
+	}
+	return c
+}
+
+var environmentFiles = map[string]string{
+	"environments/staging/values.yaml":     "replicas: 2\nimage:\n  tag: stable\nhosts:\n- staging.example.com\n",
+	"environments/prod/environment.yaml":   "inherits: staging\n",
+	"environments/prod/values.yaml":        "replicas: 5\nimage:\n  pullPolicy: IfNotPresent\ndebug: null\n",
+	"environments/canary/environment.yaml": "inherits: prod\n",
+	"environments/README.md":               "not an environment",
+	"templates/NOTES.txt":                  "",
+}
+
+func TestEnvironments(t *testing.T) {
+	c := environmentsChart(environmentFiles)
+	expect := []string{"canary", "prod", "staging"}
+	if envs := Environments(c); !reflect.DeepEqual(envs, expect) {
+		t.Errorf("expected environments %v, got %v", expect, envs)
+	}
+}
+
+func TestEnvironmentStack(t *testing.T) {
+	c := environmentsChart(environmentFiles)
+	stack, err := EnvironmentStack(c, "canary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := []string{"staging", "prod", "canary"}; !reflect.DeepEqual(stack, expect) {
+		t.Errorf("expected stack %v, got %v", expect, stack)
+	}
+
+	for _, name := range []string{"qa", "", "..", "../templates"} {
+		if _, err := EnvironmentStack(c, name); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("expected environment %q not to be found, got %v", name, err)
+		}
+	}
+}
+
+func TestEnvironmentStackErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string
+		expect string
+	}{
+		{
+			name: "cycle",
+			files: map[string]string{
+				"environments/a/environment.yaml": "inherits: b\n",
+				"environments/b/environment.yaml": "inherits: a\n",
+			},
+			expect: "inherits from itself: a -> b -> a",
+		},
+		{
+			name: "missing parent",
+			files: map[string]string{
+				"environments/a/environment.yaml": "inherits: b\n",
+			},
+			expect: `environment "b" not found`,
+		},
+		{
+			name: "invalid environment file",
+			files: map[string]string{
+				"environments/a/environment.yaml": "inherits: [b\n",
+			},
+			expect: "cannot load environments/a/environment.yaml",
+		},
+	}
+	for _, tt := range tests {
+		c := environmentsChart(tt.files)
+		_, err := EnvironmentStack(c, "a")
+		if err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.expect, err)
+		}
+	}
+}
+
+func TestApplyEnvironment(t *testing.T) {
+	c := environmentsChart(environmentFiles)
+	if err := ApplyEnvironment(c, "canary"); err != nil {
+		t.Fatal(err)
+	}
+	vals, err := CoalesceValues(c, &chart.Config{Raw: "replicas: 10\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string]interface{}{
+		"replicas": float64(10),
+		"image": map[string]interface{}{
+			"tag":        "stable",
+			"pullPolicy": "IfNotPresent",
+		},
+		"$merge": map[string]interface{}{"hosts": "append"},
+		"hosts":  []interface{}{"localhost", "staging.example.com"},
+	}
+	if !reflect.DeepEqual(map[string]interface{}(vals), expect) {
+		t.Errorf("expected values %v, got %v", expect, vals)
+	}
+}
+
+func TestApplyEnvironmentInvalidValues(t *testing.T) {
+	c := environmentsChart(map[string]string{
+		"environments/dev/values.yaml": "replicas: [1\n",
+	})
+	err := ApplyEnvironment(c, "dev")
+	if err == nil || !strings.Contains(err.Error(), "cannot load environments/dev/values.yaml") {
+		t.Errorf("expected an error loading the values file, got %v", err)
+	}
+}
+
+func TestExplainEnvironmentValues(t *testing.T) {
+	c := environmentsChart(environmentFiles)
+	vals, origins, err := ExplainEnvironmentValues(c, "prod", &chart.Config{Raw: "replicas: 10\n"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		chartValues = "values.yaml in envs"
+		staging     = "environments/staging/values.yaml in envs"
+		prod        = "environments/prod/values.yaml in envs"
+	)
+	expect := []ValueOrigin{
+		{"hosts", []interface{}{"localhost", "staging.example.com"}, chartValues + " + " + staging},
+		{"image.pullPolicy", "IfNotPresent", prod},
+		{"image.tag", "stable", staging},
+		{"replicas", float64(10), SuppliedValuesSource},
+	}
+	if !reflect.DeepEqual(origins, expect) {
+		t.Errorf("Expected origins:\n%v\nGot:\n%v", expect, origins)
+	}
+
+	// The values are the same as with the environment applied.
+	applied := environmentsChart(environmentFiles)
+	if err := ApplyEnvironment(applied, "prod"); err != nil {
+		t.Fatal(err)
+	}
+	cvals, err := CoalesceValues(applied, &chart.Config{Raw: "replicas: 10\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Templates do not see the merge strategies either.
+	stripMergeKeys(cvals)
+	if !reflect.DeepEqual(vals, cvals) {
+		t.Errorf("Expected values %v, got %v", cvals, vals)
+	}
+}
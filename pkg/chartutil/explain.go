@@ -0,0 +1,270 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const importMarker = " (import-values from "
+
+// SuppliedValuesSource is the source reported for supplied values that have
+// no more specific source.
+const SuppliedValuesSource = "user-supplied values"
+
+// ValueOrigin describes where a coalesced value came from.
+type ValueOrigin struct {
+	// Path is the dotted path of the value, e.g. "image.tag".
+	Path string
+	// Value is the coalesced value.
+	Value interface{}
+	// Source is the values file, flag or chart that set the value.
+	Source string
+}
+
+type byPath []ValueOrigin
+
+func (p byPath) Len() int           { return len(p) }
+func (p byPath) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p byPath) Less(i, j int) bool { return p[i].Path < p[j].Path }
+
+// sourcedValue is a leaf value tagged with where it came from.
+//
+// While values are explained, every leaf value is wrapped in a sourcedValue.
+// The coalescing functions treat it like any other scalar, so the tags follow
+// the values through coalescing, globals and import-values.
+type sourcedValue struct {
+	value  interface{}
+	source string
+}
+
+// ExplainValues coalesces the values of a chart like CoalesceValues, and
+// reports where each leaf value came from: the supplied values, the values
+// file of the chart or one of its dependencies, or import-values.
+//
+// Unlike CoalesceValues, the values that dependencies export through
+// import-values are merged in, so the chart must not have been passed to
+// ProcessRequirementsImportValues already.
+//
+// sources maps the dotted paths of the supplied values to the file or flag
+// that set them. Supplied values not found in it are attributed to
+// SuppliedValuesSource.
+func ExplainValues(chrt *chart.Chart, vals *chart.Config, sources map[string]string) (Values, []ValueOrigin, error) {
+	return ExplainEnvironmentValues(chrt, "", vals, sources)
+}
+
+// ExplainEnvironmentValues is like ExplainValues, for a chart to which the
+// named environment is applied as ApplyEnvironment does. The chart must not
+// have been passed to ApplyEnvironment already, so that the values of the
+// environment can be told apart from the ones in the chart's values file.
+func ExplainEnvironmentValues(chrt *chart.Chart, environment string, vals *chart.Config, sources map[string]string) (Values, []ValueOrigin, error) {
+	names := map[*chart.Chart]string{}
+	chartNames(chrt, chrt.Metadata.Name, names)
+
+	// Defaults are tagged with their chart, unless import-values replaced them.
+	imported := map[*chart.Chart]map[string]interface{}{}
+	defaults := func(c *chart.Chart) (map[string]interface{}, error) {
+		if v, ok := imported[c]; ok {
+			return copyTree(v), nil
+		}
+		nv, err := readDefaults(c)
+		if err != nil {
+			return nil, err
+		}
+		source := fmt.Sprintf("values.yaml in %s", names[c])
+		tagged := tagValues(nv, "", func(string) string { return source })
+		if c != chrt || environment == "" {
+			return tagged, nil
+		}
+		return coalesceEnvironment(c, environment, tagged, func(env string, ev map[string]interface{}) map[string]interface{} {
+			source := fmt.Sprintf("%s in %s", path.Join(EnvironmentsDir, env, EnvironmentValuesfileName), names[c])
+			return tagValues(ev, "", func(string) string { return source })
+		})
+	}
+
+	// Import values bottom up, like ProcessRequirementsImportValues.
+	pc := getParents(chrt, nil)
+	for i := len(pc) - 1; i >= 0; i-- {
+		b, err := importValues(pc[i], defaults, func(v Values, dep string) Values {
+			return retagValues(v, dep)
+		})
+		if err != nil {
+			// Charts without requirements have nothing to import.
+			continue
+		}
+		imported[pc[i]] = b
+	}
+
+	return explainValues(chrt, vals, sources, defaults)
+}
+
+// ExplainReleaseValues is like ExplainValues, for the chart of a release.
+//
+// The default values of a release's chart already include the values that
+// were imported from its dependencies and those of the environment it was
+// installed with, so they cannot be told apart from the chart's values file.
+// They are reported as the deployed defaults of their chart, and the
+// supplied values are attributed to SuppliedValuesSource.
+func ExplainReleaseValues(chrt *chart.Chart, vals *chart.Config) (Values, []ValueOrigin, error) {
+	names := map[*chart.Chart]string{}
+	chartNames(chrt, chrt.Metadata.Name, names)
+
+	defaults := func(c *chart.Chart) (map[string]interface{}, error) {
+		nv, err := readDefaults(c)
+		if err != nil {
+			return nil, err
+		}
+		source := fmt.Sprintf("deployed defaults of %s", names[c])
+		return tagValues(nv, "", func(string) string { return source }), nil
+	}
+	return explainValues(chrt, vals, nil, defaults)
+}
+
+// explainValues coalesces the tagged supplied values with the tagged
+// defaults of the chart and its dependencies, and returns the origins.
+func explainValues(chrt *chart.Chart, vals *chart.Config, sources map[string]string, defaults func(*chart.Chart) (map[string]interface{}, error)) (Values, []ValueOrigin, error) {
+	cvals := map[string]interface{}{}
+	if vals != nil {
+		evals, err := ReadValues([]byte(vals.Raw))
+		if err != nil {
+			return nil, nil, err
+		}
+		cvals = tagValues(evals, "", func(p string) string {
+			if s, ok := sources[p]; ok {
+				return s
+			}
+			return SuppliedValuesSource
+		})
+		if cvals, err = coalesce(chrt, cvals, defaults); err != nil {
+			return nil, nil, err
+		}
+	}
+	cvals, err := coalesceDeps(chrt, cvals, defaults)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The merge strategies are not values of the chart.
+	stripMergeKeys(cvals)
+	var origins []ValueOrigin
+	out := untagValues(cvals, "", &origins)
+	sort.Sort(byPath(origins))
+	return out, origins, nil
+}
+
+// chartNames records the path of a chart and its dependencies, e.g.
+// "mychart/charts/mysubchart".
+func chartNames(c *chart.Chart, name string, names map[*chart.Chart]string) {
+	names[c] = name
+	for _, dep := range c.Dependencies {
+		chartNames(dep, path.Join(name, "charts", dep.Metadata.Name), names)
+	}
+}
+
+// joinPath appends a key to a dotted path.
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// tagValues returns a copy of vals with every leaf value tagged with the
+// source for its path. Null values are left untagged, so they still delete
+// keys while coalescing.
+func tagValues(vals map[string]interface{}, prefix string, source func(path string) string) map[string]interface{} {
+	out := make(map[string]interface{}, len(vals))
+	for k, v := range vals {
+		p := joinPath(prefix, k)
+		switch vv := v.(type) {
+		case nil:
+			out[k] = nil
+		case map[string]interface{}:
+			out[k] = tagValues(vv, p, source)
+		default:
+			out[k] = sourcedValue{value: v, source: source(p)}
+		}
+	}
+	return out
+}
+
+// retagValues returns a copy of vals with the sources marked as imported
+// from a dependency.
+func retagValues(vals map[string]interface{}, dep string) map[string]interface{} {
+	out := make(map[string]interface{}, len(vals))
+	for k, v := range vals {
+		switch vv := v.(type) {
+		case map[string]interface{}:
+			out[k] = retagValues(vv, dep)
+		case sourcedValue:
+			out[k] = sourcedValue{value: vv.value, source: importedSource(vv.source, dep)}
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// importedSource marks a source as imported from a dependency. Values that
+// are imported through several charts list all of them, innermost first.
+func importedSource(source, dep string) string {
+	if strings.Contains(source, importMarker) {
+		return strings.TrimSuffix(source, ")") + ", " + dep + ")"
+	}
+	return source + importMarker + dep + ")"
+}
+
+// untagValues returns a copy of vals without tags and null values, and
+// appends the origin of each tagged leaf value to origins.
+func untagValues(vals map[string]interface{}, prefix string, origins *[]ValueOrigin) map[string]interface{} {
+	out := make(map[string]interface{}, len(vals))
+	for k, v := range vals {
+		p := joinPath(prefix, k)
+		switch vv := v.(type) {
+		case map[string]interface{}:
+			out[k] = untagValues(vv, p, origins)
+		case sourcedValue:
+			stripListMergeKeys(vv.value)
+			out[k] = vv.value
+			*origins = append(*origins, ValueOrigin{Path: p, Value: vv.value, Source: vv.source})
+		case nil:
+			// Null values are removed, like CoalesceValues does.
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// copyTree returns a copy of vals in which all tables are copied, so that
+// coalescing the copy leaves vals untouched.
+func copyTree(vals map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(vals))
+	for k, v := range vals {
+		if vv, ok := v.(map[string]interface{}); ok {
+			out[k] = copyTree(vv)
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
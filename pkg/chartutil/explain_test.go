@@ -0,0 +1,211 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func explainTestChart() *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "parent"},
+		Values: &chart.Config{Raw: `name: parent
+global:
+  env: prod
+image:
+  tag: "1.0"
+  pullPolicy: IfNotPresent
+`},
+		Files: []*any.Any{
+			{TypeUrl: "requirements.yaml", Value: []byte("dependencies:\n- name: sub\n  import-values:\n  - data\n")},
+		},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "sub"},
+				Values: &chart.Config{Raw: `exports:
+  data:
+    imported: from-sub
+port: 80
+`},
+			},
+		},
+	}
+}
+
+func TestExplainValues(t *testing.T) {
+	supplied := &chart.Config{Raw: "image:\n  tag: \"2.0\"\nsub:\n  port: 8080\n"}
+	sources := map[string]string{"image.tag": "prod.yaml"}
+
+	vals, origins, err := ExplainValues(explainTestChart(), supplied, sources)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		parent = "values.yaml in parent"
+		sub    = "values.yaml in parent/charts/sub"
+	)
+	expect := []ValueOrigin{
+		{"global.env", "prod", parent},
+		{"image.pullPolicy", "IfNotPresent", parent},
+		{"image.tag", "2.0", "prod.yaml"},
+		{"imported", "from-sub", sub + " (import-values from sub)"},
+		{"name", "parent", parent},
+		{"sub.exports.data.imported", "from-sub", sub},
+		{"sub.global.env", "prod", parent},
+		{"sub.port", float64(8080), SuppliedValuesSource},
+	}
+	if !reflect.DeepEqual(origins, expect) {
+		t.Errorf("Expected origins:\n%v\nGot:\n%v", expect, origins)
+	}
+
+	// The explained values are the values the chart is rendered with.
+	c := explainTestChart()
+	if err := ProcessRequirementsImportValues(c); err != nil {
+		t.Fatal(err)
+	}
+	cvals, err := CoalesceValues(c, supplied)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(vals, cvals) {
+		t.Errorf("Expected values:\n%v\nGot:\n%v", cvals, vals)
+	}
+}
+
+func TestExplainReleaseValues(t *testing.T) {
+	// The chart of a release was processed before it was installed.
+	c := explainTestChart()
+	if err := ProcessRequirementsImportValues(c); err != nil {
+		t.Fatal(err)
+	}
+	supplied := &chart.Config{Raw: "image:\n  tag: \"2.0\"\n"}
+
+	vals, origins, err := ExplainReleaseValues(c, supplied)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Processing the chart merged the values of sub into the ones of parent,
+	// so they are all reported as the defaults of parent.
+	const parent = "deployed defaults of parent"
+	expect := []ValueOrigin{
+		{"global.env", "prod", parent},
+		{"image.pullPolicy", "IfNotPresent", parent},
+		{"image.tag", "2.0", SuppliedValuesSource},
+		{"imported", "from-sub", parent},
+		{"name", "parent", parent},
+		{"sub.exports.data.imported", "from-sub", parent},
+		{"sub.global.env", "prod", parent},
+		{"sub.port", float64(80), parent},
+	}
+	if !reflect.DeepEqual(origins, expect) {
+		t.Errorf("Expected origins:\n%v\nGot:\n%v", expect, origins)
+	}
+
+	cvals, err := CoalesceValues(c, supplied)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(vals, cvals) {
+		t.Errorf("Expected values:\n%v\nGot:\n%v", cvals, vals)
+	}
+}
+
+func TestExplainValuesNull(t *testing.T) {
+	supplied := &chart.Config{Raw: "name: null\n"}
+	vals, origins, err := ExplainValues(explainTestChart(), supplied, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vals.PathValue("name"); err == nil {
+		t.Error("Expected a null value to delete the default")
+	}
+	for _, o := range origins {
+		if o.Path == "name" {
+			t.Errorf("Expected no origin for a deleted value, got %v", o)
+		}
+	}
+}
+
+func TestImportedSource(t *testing.T) {
+	src := importedSource("values.yaml in a/charts/b/charts/c", "c")
+	if expect := "values.yaml in a/charts/b/charts/c (import-values from c)"; src != expect {
+		t.Errorf("Expected %q, got %q", expect, src)
+	}
+	src = importedSource(src, "b")
+	if expect := "values.yaml in a/charts/b/charts/c (import-values from c, b)"; src != expect {
+		t.Errorf("Expected %q, got %q", expect, src)
+	}
+}
+
+func TestExplainValuesMergedList(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "merge"},
+		Values:   &chart.Config{Raw: "$merge:\n  args: append\nargs:\n- --verbose\n"},
+	}
+	_, origins, err := ExplainValues(c, &chart.Config{Raw: "args:\n- --debug\n"}, map[string]string{"args": "--set args={--debug}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := ValueOrigin{"args", []interface{}{"--verbose", "--debug"}, "values.yaml in merge + --set args={--debug}"}
+	for _, o := range origins {
+		if o.Path == "args" {
+			if !reflect.DeepEqual(o, expect) {
+				t.Errorf("Expected %v, got %v", expect, o)
+			}
+			return
+		}
+	}
+	t.Errorf("Expected an origin for args, got %v", origins)
+}
+
+func TestExplainValuesStripsMergeKeys(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "merge"},
+		Values: &chart.Config{Raw: `$merge:
+  containers: merge:name
+containers:
+- name: app
+  $merge:
+    env: append
+  env:
+  - PORT
+`},
+	}
+	vals, origins, err := ExplainValues(c, &chart.Config{Raw: "$merge:\n  args: append\n"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := vals[MergeKey]; ok {
+		t.Errorf("Expected the merge strategies to be removed, got %v", vals)
+	}
+	expect := []interface{}{map[string]interface{}{"name": "app", "env": []interface{}{"PORT"}}}
+	if !reflect.DeepEqual(vals["containers"], expect) {
+		t.Errorf("Expected %v, got %v", expect, vals["containers"])
+	}
+	for _, o := range origins {
+		if o.Path != "containers" {
+			t.Errorf("Expected only the origin of containers, got %v", o)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// ValidateAgainstSchema checks the coalesced values of a chart against the
+// chart's values.schema.json, and the values of each dependency against the
+// dependency's schema.
+//
+// Every violation is reported, with the path of the offending value, e.g.
+//
+//	values do not match the schema:
+//	- image.tag: Invalid type. Expected: string, given: integer
+//	- mysql: port is required
+func ValidateAgainstSchema(chrt *chart.Chart, values Values) error {
+	errs := []string{}
+	if err := validateChartSchema(chrt, values, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("values do not match the schema:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func validateChartSchema(c *chart.Chart, values Values, prefix string, errs *[]string) error {
+	if len(c.Schema) > 0 {
+		res, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(c.Schema), gojsonschema.NewGoLoader(values.AsMap()))
+		if err != nil {
+			return fmt.Errorf("cannot validate values against the schema of chart %q: %s", c.Metadata.Name, err)
+		}
+		for _, e := range res.Errors() {
+			*errs = append(*errs, fmt.Sprintf("- %s: %s", schemaErrorPath(prefix, e.Field()), e.Description()))
+		}
+	}
+
+	for _, dep := range c.Dependencies {
+		name := dep.Metadata.Name
+		sub, err := tableLookup(values, name)
+		if err != nil {
+			sub = Values{}
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		if err := validateChartSchema(dep, sub, name, errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaErrorPath returns the full path of a value a schema error is about.
+func schemaErrorPath(prefix, field string) string {
+	if field == gojsonschema.STRING_CONTEXT_ROOT {
+		field = ""
+	}
+	switch {
+	case prefix == "" && field == "":
+		return "(root)"
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	}
+	return prefix + "." + field
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const topSchema = `{
+  "type": "object",
+  "required": ["image"],
+  "properties": {
+    "image": {
+      "type": "object",
+      "properties": {
+        "tag": {"type": "string"}
+      }
+    },
+    "replicas": {"type": "integer", "minimum": 1}
+  }
+}`
+
+const subSchema = `{
+  "type": "object",
+  "required": ["port"],
+  "properties": {
+    "port": {"type": "integer"}
+  }
+}`
+
+func schemaChart() *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "top"},
+		Schema:   []byte(topSchema),
+		Values:   &chart.Config{Raw: "image:\n  tag: \"1.0\"\nreplicas: 1\n"},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "db"},
+				Schema:   []byte(subSchema),
+				Values:   &chart.Config{Raw: "port: 5432\n"},
+			},
+			{
+				Metadata: &chart.Metadata{Name: "noschema"},
+				Values:   &chart.Config{Raw: "anything: [1, 2]\n"},
+			},
+		},
+	}
+}
+
+func TestValidateAgainstSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		errors []string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:   "wrong type",
+			raw:    "image:\n  tag: 1.1\n",
+			errors: []string{"- image.tag: Invalid type. Expected: string, given: number"},
+		},
+		{
+			name:   "several errors",
+			raw:    "replicas: 0\ndb:\n  port: http\n",
+			errors: []string{"- replicas: Must be greater than or equal to 1", "- db.port: Invalid type. Expected: integer, given: string"},
+		},
+		{
+			name:   "missing",
+			raw:    "image: null\n",
+			errors: []string{"- (root): image is required"},
+		},
+	}
+
+	for _, tt := range tests {
+		c := schemaChart()
+		vals, err := CoalesceValues(c, &chart.Config{Raw: tt.raw})
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		err = ValidateAgainstSchema(c, vals)
+		if len(tt.errors) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", tt.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+			continue
+		}
+		for _, e := range tt.errors {
+			if !strings.Contains(err.Error(), e) {
+				t.Errorf("%s: expected error to contain %q, got %q", tt.name, e, err)
+			}
+		}
+	}
+}
+
+func TestValidateAgainstInvalidSchema(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "broken"},
+		Schema:   []byte(`{"type": `),
+	}
+	err := ValidateAgainstSchema(c, Values{})
+	if err == nil || !strings.Contains(err.Error(), `schema of chart "broken"`) {
+		t.Errorf("Expected an error for an invalid schema, got %v", err)
+	}
+}
+
+func TestToRenderValuesSchema(t *testing.T) {
+	c := schemaChart()
+	_, err := ToRenderValuesCaps(c, &chart.Config{Raw: "replicas: many"}, ReleaseOptions{}, &Capabilities{})
+	if err == nil || !strings.Contains(err.Error(), "- replicas: Invalid type") {
+		t.Errorf("Expected the values to be validated, got %v", err)
+	}
+}
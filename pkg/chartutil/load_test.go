@@ -70,6 +70,10 @@ icon: https://example.com/64x64.png
 			Name: ValuesfileName,
 			Data: []byte(defaultValues),
 		},
+		{
+			Name: SchemafileName,
+			Data: []byte(`{"type": "object"}`),
+		},
 		{
 			Name: path.Join("templates", DeploymentName),
 			Data: []byte(defaultDeployment),
@@ -93,6 +97,10 @@ icon: https://example.com/64x64.png
 		t.Error("Expected chart values to be populated with default values")
 	}
 
+	if string(c.Schema) != `{"type": "object"}` {
+		t.Errorf("Expected chart schema to be populated, got %q", c.Schema)
+	}
+
 	if len(c.Templates) != 2 {
 		t.Errorf("Expected number of templates == 2, got %d", len(c.Templates))
 	}
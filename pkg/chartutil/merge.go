@@ -0,0 +1,222 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"log"
+	"reflect"
+	"strings"
+)
+
+// MergeKey is the name of the Values key that declares how the lists in a
+// table are merged. It maps the keys of lists to a merge strategy:
+//
+//	env:
+//	  $merge:
+//	    extraEnv: merge:name
+//	    args: append
+//
+// The strategies are:
+//
+//   - replace: the list with the higher precedence replaces the other one.
+//     This is the default.
+//   - append: the entries of the list with the higher precedence are added
+//     to the end of the other one, unless they are already in it.
+//   - merge:FIELD: entries with the same FIELD, such as merge:name, are
+//     merged, with the higher precedence entry winning. Other entries are
+//     added to the end of the list.
+//
+// Strategies may be declared in a chart's values or in the supplied values.
+// A strategy declared in the values with the higher precedence wins.
+const MergeKey = "$merge"
+
+const (
+	mergeReplace     = "replace"
+	mergeAppend      = "append"
+	mergeByKeyPrefix = "merge:"
+	sourceSeparator  = " + "
+)
+
+// coalesceLists merges the list src[key] into the list dst[key], following
+// the merge strategy declared for key. dst has the higher precedence.
+//
+// It returns false if the values are not both lists, or if the strategy is
+// to replace the list, in which case dst[key] is kept as it is.
+func coalesceLists(dst, src map[string]interface{}, key string) (interface{}, bool) {
+	dl, dsource, ok := listValue(dst[key])
+	if !ok {
+		return nil, false
+	}
+	sl, ssource, ok := listValue(src[key])
+	if !ok {
+		return nil, false
+	}
+
+	var merged []interface{}
+	switch strategy := mergeStrategy(dst, src, key); {
+	case strategy == "" || strategy == mergeReplace:
+		return nil, false
+	case strategy == mergeAppend:
+		merged = appendList(dl, sl)
+	case strings.HasPrefix(strategy, mergeByKeyPrefix):
+		merged = mergeListByKey(dl, sl, strings.TrimPrefix(strategy, mergeByKeyPrefix))
+	default:
+		log.Printf("warning: unknown merge strategy %q for %s. Replacing the list.", strategy, key)
+		return nil, false
+	}
+
+	// While values are explained, the merged list came from both sources.
+	if dsource != "" || ssource != "" {
+		return sourcedValue{value: merged, source: joinSources(ssource, dsource)}, true
+	}
+	return merged, true
+}
+
+// listValue returns v as a list, along with its source if it is tagged.
+func listValue(v interface{}) ([]interface{}, string, bool) {
+	if sv, ok := v.(sourcedValue); ok {
+		l, ok := sv.value.([]interface{})
+		return l, sv.source, ok
+	}
+	l, ok := v.([]interface{})
+	return l, "", ok
+}
+
+// mergeStrategy returns the merge strategy for key, preferring the one
+// declared in dst.
+func mergeStrategy(dst, src map[string]interface{}, key string) string {
+	for _, m := range []map[string]interface{}{dst, src} {
+		strategies, ok := m[MergeKey].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch s := strategies[key].(type) {
+		case string:
+			return s
+		case sourcedValue:
+			if str, ok := s.value.(string); ok {
+				return str
+			}
+		}
+	}
+	return ""
+}
+
+// appendList adds the entries of dst that are not in src to the end of src.
+//
+// Entries already in the list are skipped, so merging the result with src
+// again does not change it.
+func appendList(dst, src []interface{}) []interface{} {
+	out := make([]interface{}, 0, len(src)+len(dst))
+	out = append(out, src...)
+	for _, d := range dst {
+		if !containsValue(out, d) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// mergeListByKey merges the entries of dst into the entries of src that have
+// the same value for field. Entries of dst without a match are added to the
+// end of the list.
+func mergeListByKey(dst, src []interface{}, field string) []interface{} {
+	out := make([]interface{}, 0, len(src)+len(dst))
+	used := make([]bool, len(dst))
+	for _, s := range src {
+		item := s
+		if sk, ok := listEntryKey(s, field); ok {
+			for i, d := range dst {
+				if dk, ok := listEntryKey(d, field); ok && !used[i] && reflect.DeepEqual(sk, dk) {
+					item = coalesceTables(copyTree(d.(map[string]interface{})), s.(map[string]interface{}))
+					used[i] = true
+					break
+				}
+			}
+		}
+		out = append(out, item)
+	}
+	for i, d := range dst {
+		if !used[i] && !containsValue(out, d) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// listEntryKey returns the value of field in a list entry that is a table.
+func listEntryKey(v interface{}, field string) (interface{}, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	k, ok := m[field]
+	return k, ok && k != nil
+}
+
+func containsValue(list []interface{}, v interface{}) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinSources joins the sources of a merged value, skipping duplicates.
+func joinSources(sources ...string) string {
+	var out []string
+	for _, s := range sources {
+		for _, part := range strings.Split(s, sourceSeparator) {
+			if part != "" && !containsString(out, part) {
+				out = append(out, part)
+			}
+		}
+	}
+	return strings.Join(out, sourceSeparator)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// stripMergeKeys removes the merge strategies from coalesced values, so that
+// templates and schemas only see the values themselves.
+func stripMergeKeys(vals map[string]interface{}) {
+	delete(vals, MergeKey)
+	for _, v := range vals {
+		stripListMergeKeys(v)
+	}
+}
+
+// stripListMergeKeys removes the merge strategies from a value, including
+// the ones declared by the tables in a list.
+func stripListMergeKeys(v interface{}) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		stripMergeKeys(vv)
+	case []interface{}:
+		for _, item := range vv {
+			stripListMergeKeys(item)
+		}
+	}
+}
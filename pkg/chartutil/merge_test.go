@@ -0,0 +1,283 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const mergeTestDefaults = `
+env:
+  $merge:
+    extraEnv: merge:name
+    args: append
+  extraEnv:
+  - name: LOG_LEVEL
+    value: info
+  - name: PORT
+    value: "8080"
+  args:
+  - --verbose
+  ports:
+  - 80
+`
+
+func TestCoalesceLists(t *testing.T) {
+	tests := []struct {
+		name     string
+		supplied string
+		expected string
+	}{
+		{
+			name:     "merge by key overrides one entry",
+			supplied: "env:\n  extraEnv:\n  - name: LOG_LEVEL\n    value: debug\n  - name: EXTRA\n    value: x\n",
+			expected: `
+extraEnv:
+- name: LOG_LEVEL
+  value: debug
+- name: PORT
+  value: "8080"
+- name: EXTRA
+  value: x
+`,
+		},
+		{
+			name:     "merge by key merges entries",
+			supplied: "env:\n  extraEnv:\n  - name: PORT\n    valueFrom: secret\n",
+			expected: `
+extraEnv:
+- name: LOG_LEVEL
+  value: info
+- name: PORT
+  value: "8080"
+  valueFrom: secret
+`,
+		},
+		{
+			name:     "append skips entries already in the list",
+			supplied: "env:\n  args:\n  - --verbose\n  - --color\n",
+			expected: `
+args:
+- --verbose
+- --color
+`,
+		},
+		{
+			name:     "lists without a strategy are replaced",
+			supplied: "env:\n  ports:\n  - 443\n",
+			expected: `
+ports:
+- 443
+`,
+		},
+		{
+			name:     "supplied strategy wins over the chart",
+			supplied: "env:\n  $merge:\n    args: replace\n  args:\n  - --quiet\n",
+			expected: `
+args:
+- --quiet
+`,
+		},
+		{
+			name:     "supplied strategy for a list the chart replaces",
+			supplied: "env:\n  $merge:\n    ports: append\n  ports:\n  - 443\n",
+			expected: `
+ports:
+- 80
+- 443
+`,
+		},
+		{
+			name:     "unknown strategies replace the list",
+			supplied: "env:\n  $merge:\n    ports: shuffle\n  ports:\n  - 443\n",
+			expected: `
+ports:
+- 443
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		c := &chart.Chart{
+			Metadata: &chart.Metadata{Name: "merge"},
+			Values:   &chart.Config{Raw: mergeTestDefaults},
+		}
+		vals, err := CoalesceValues(c, &chart.Config{Raw: tt.supplied})
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		env, err := vals.Table("env")
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		expectListsIn(t, tt.name, env, tt.expected)
+	}
+}
+
+func TestCoalesceListsSubchart(t *testing.T) {
+	// Subchart values are coalesced more than once; merging must not
+	// duplicate entries.
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "parent"},
+		Values:   &chart.Config{Raw: "sub:\n  env:\n    args:\n    - --color\n"},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "sub"},
+				Values:   &chart.Config{Raw: mergeTestDefaults},
+			},
+		},
+	}
+	vals, err := CoalesceValues(c, &chart.Config{Raw: "sub:\n  env:\n    args:\n    - --debug\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := vals.Table("sub.env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The parent's values replace the list, as neither declares a strategy.
+	// The result is then appended to the subchart's defaults.
+	expectListsIn(t, "subchart", env, "args:\n- --verbose\n- --debug\n")
+}
+
+func TestCoalesceListsReuseValues(t *testing.T) {
+	// With --reuse-values, the coalesced values of the last release become
+	// the chart's values, and the new values are coalesced into them.
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "merge"},
+		Values:   &chart.Config{Raw: mergeTestDefaults},
+	}
+	old, err := CoalesceValues(c, &chart.Config{Raw: "env:\n  args:\n  - --color\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldYAML, err := old.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Values = &chart.Config{Raw: oldYAML}
+	vals, err := CoalesceValues(c, &chart.Config{Raw: "env:\n  args:\n  - --color\n  - --debug\n  extraEnv:\n  - name: PORT\n    value: \"9090\"\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := vals.Table("env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectListsIn(t, "reuse", env, `
+args:
+- --verbose
+- --color
+- --debug
+extraEnv:
+- name: LOG_LEVEL
+  value: info
+- name: PORT
+  value: "9090"
+`)
+}
+
+func TestCoalesceListsPrunesNulls(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "merge"},
+		Values:   &chart.Config{Raw: mergeTestDefaults},
+	}
+	vals, err := CoalesceValues(c, &chart.Config{Raw: "env:\n  extraEnv:\n  - name: LOG_LEVEL\n    value: null\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := vals.Table("env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectListsIn(t, "null in a merged entry", env, `
+args:
+- --verbose
+extraEnv:
+- name: LOG_LEVEL
+- name: PORT
+  value: "8080"
+`)
+}
+
+func TestToRenderValuesStripsMergeKeys(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "merge"},
+		Values:   &chart.Config{Raw: mergeTestDefaults},
+	}
+	top, err := ToRenderValues(c, &chart.Config{}, ReleaseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vals := top["Values"].(Values)
+	if _, err := vals.Table("env." + MergeKey); err == nil {
+		t.Errorf("Expected the merge strategies to be removed, got %v", vals)
+	}
+}
+
+func TestToRenderValuesStripsNestedMergeKeys(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "merge"},
+		Values: &chart.Config{Raw: `containers:
+- name: app
+  $merge:
+    env: merge:name
+  env:
+  - name: PORT
+    value: "8080"
+`},
+		Schema: []byte(`{
+  "properties": {
+    "containers": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "properties": {"name": {"type": "string"}, "env": {"type": "array"}}
+      }
+    }
+  }
+}`),
+	}
+	top, err := ToRenderValues(c, &chart.Config{}, ReleaseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	containers := top["Values"].(Values)["containers"].([]interface{})
+	if _, ok := containers[0].(map[string]interface{})[MergeKey]; ok {
+		t.Errorf("Expected the merge strategies in lists to be removed, got %v", containers)
+	}
+}
+
+// expectListsIn checks the lists in expected against the same keys of table.
+func expectListsIn(t *testing.T, name string, table Values, expected string) {
+	var expect map[string]interface{}
+	if err := yaml.Unmarshal([]byte(expected), &expect); err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range expect {
+		if !reflect.DeepEqual(table[k], v) {
+			t.Errorf("%s: expected %s to be %v, got %v", name, k, v, table[k])
+		}
+	}
+}
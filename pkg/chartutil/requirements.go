@@ -381,15 +381,38 @@ func getParents(c *chart.Chart, out []*chart.Chart) []*chart.Chart {
 
 // processImportValues merges values from child to parent based on the chart's dependencies' ImportValues field.
 func processImportValues(c *chart.Chart) error {
-	reqs, err := LoadRequirements(c)
+	b, err := importValues(c, readDefaults, nil)
 	if err != nil {
 		return err
 	}
-	// combine chart values and empty config to get Values
-	cvals, err := CoalesceValues(c, &chart.Config{})
+	y, err := yaml.Marshal(b)
 	if err != nil {
 		return err
 	}
+
+	// set the new values
+	c.Values = &chart.Config{Raw: string(y)}
+
+	return nil
+}
+
+// importValues returns the values of a chart with the values imported from
+// its dependencies merged in. If imported is not nil, each imported table is
+// passed through it along with the name of the dependency it came from.
+func importValues(c *chart.Chart, defaults defaultsFunc, imported func(vals Values, dep string) Values) (map[string]interface{}, error) {
+	reqs, err := LoadRequirements(c)
+	if err != nil {
+		return nil, err
+	}
+	// combine chart values and empty config to get Values
+	m, err := coalesce(c, map[string]interface{}{}, defaults)
+	if err == nil {
+		m, err = coalesceDeps(c, m, defaults)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cvals := Values(m)
 	b := make(map[string]interface{}, 0)
 	// import values from each dependency if specified in import-values
 	for _, r := range reqs.Dependencies {
@@ -410,6 +433,9 @@ func processImportValues(c *chart.Chart) error {
 						log.Printf("Warning: ImportValues missing table: %v", err)
 						continue
 					}
+					if imported != nil {
+						vv = imported(vv, r.Name)
+					}
 					// create value map from child to be merged into parent
 					vm := pathToMap(nm["parent"], vv.AsMap())
 					b = coalesceTables(cvals, vm)
@@ -425,6 +451,9 @@ func processImportValues(c *chart.Chart) error {
 						log.Printf("Warning: ImportValues missing table: %v", err)
 						continue
 					}
+					if imported != nil {
+						vm = imported(vm, r.Name)
+					}
 					b = coalesceTables(b, vm.AsMap())
 				}
 			}
@@ -432,16 +461,7 @@ func processImportValues(c *chart.Chart) error {
 			r.ImportValues = outiv
 		}
 	}
-	b = coalesceTables(b, cvals)
-	y, err := yaml.Marshal(b)
-	if err != nil {
-		return err
-	}
-
-	// set the new values
-	c.Values = &chart.Config{Raw: string(y)}
-
-	return nil
+	return coalesceTables(b, cvals), nil
 }
 
 // ProcessRequirementsImportValues imports specified chart values from child to parent.
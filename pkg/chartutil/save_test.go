@@ -40,6 +40,7 @@ func TestSave(t *testing.T) {
 		Values: &chart.Config{
 			Raw: "ship: Pequod",
 		},
+		Schema: []byte(`{"type": "object"}`),
 	}
 
 	where, err := Save(c, tmp)
@@ -64,6 +65,9 @@ func TestSave(t *testing.T) {
 	if c2.Values.Raw != c.Values.Raw {
 		t.Fatal("Values data did not match")
 	}
+	if string(c2.Schema) != string(c.Schema) {
+		t.Fatal("Schema data did not match")
+	}
 }
 
 func TestSaveDir(t *testing.T) {
@@ -81,6 +85,7 @@ func TestSaveDir(t *testing.T) {
 		Values: &chart.Config{
 			Raw: "ship: Pequod",
 		},
+		Schema: []byte(`{"type": "object"}`),
 	}
 
 	if err := SaveDir(c, tmp); err != nil {
@@ -98,4 +103,7 @@ func TestSaveDir(t *testing.T) {
 	if c2.Values.Raw != c.Values.Raw {
 		t.Fatal("Values data did not match")
 	}
+	if string(c2.Schema) != string(c.Schema) {
+		t.Fatal("Schema data did not match")
+	}
 }
@@ -0,0 +1,271 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// SensitiveMask replaces sensitive values when they are printed.
+const SensitiveMask = "******"
+
+const (
+	// sensitiveKeyword is the keyword that marks a value as sensitive in a
+	// values schema.
+	sensitiveKeyword = "sensitive"
+	// minSecretLength is the length under which masked strings are not
+	// looked for in other places, as short strings show up everywhere.
+	minSecretLength = 4
+)
+
+// SensitivePaths returns the paths of the values that a chart and its
+// dependencies mark as sensitive, sorted.
+//
+// Values are marked as sensitive by listing their paths under 'sensitive' in
+// Chart.yaml, or with '"sensitive": true' in the values schema. In a path, a
+// '*' matches any key of a table or any entry of a list, as in
+// 'users.*.password'. The paths of a dependency are prefixed with the name of
+// the dependency, except for the ones of global values.
+func SensitivePaths(c *chart.Chart) []string {
+	seen := map[string]bool{}
+	collectSensitivePaths(c, "", seen)
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func collectSensitivePaths(c *chart.Chart, prefix string, seen map[string]bool) {
+	var paths []string
+	if c.Metadata != nil {
+		paths = append(paths, c.Metadata.Sensitive...)
+	}
+	if len(c.Schema) > 0 {
+		var schema map[string]interface{}
+		// Invalid schemas are reported when the values are validated.
+		if err := json.Unmarshal(c.Schema, &schema); err == nil {
+			schemaSensitivePaths(schema, "", &paths)
+		}
+	}
+	for _, p := range paths {
+		if prefix != "" && p != GlobalKey && !strings.HasPrefix(p, GlobalKey+".") {
+			p = prefix + "." + p
+		}
+		seen[p] = true
+	}
+	for _, dep := range c.Dependencies {
+		collectSensitivePaths(dep, joinPath(prefix, dep.Metadata.Name), seen)
+	}
+}
+
+// schemaSensitivePaths appends the paths of the values a schema marks as
+// sensitive to paths.
+func schemaSensitivePaths(schema map[string]interface{}, prefix string, paths *[]string) {
+	if s, ok := schema[sensitiveKeyword].(bool); ok && s && prefix != "" {
+		*paths = append(*paths, prefix)
+	}
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		for k, v := range props {
+			if sub, ok := v.(map[string]interface{}); ok {
+				schemaSensitivePaths(sub, joinPath(prefix, k), paths)
+			}
+		}
+	}
+	for _, key := range []string{"items", "additionalProperties", "patternProperties"} {
+		var subs []interface{}
+		switch v := schema[key].(type) {
+		case map[string]interface{}:
+			if key == "patternProperties" {
+				for _, sub := range v {
+					subs = append(subs, sub)
+				}
+			} else {
+				subs = append(subs, v)
+			}
+		case []interface{}:
+			subs = v
+		}
+		for _, sub := range subs {
+			if sub, ok := sub.(map[string]interface{}); ok {
+				schemaSensitivePaths(sub, joinPath(prefix, "*"), paths)
+			}
+		}
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		subs, _ := schema[key].([]interface{})
+		for _, sub := range subs {
+			if sub, ok := sub.(map[string]interface{}); ok {
+				schemaSensitivePaths(sub, prefix, paths)
+			}
+		}
+	}
+}
+
+// RedactValues returns a copy of vals in which the values at the given paths
+// are replaced with SensitiveMask. A table at one of the paths is replaced as
+// a whole.
+//
+// It also returns the strings that were masked, and their base64 encoding,
+// so that they can be masked wherever else they show up, such as in rendered
+// manifests. Strings shorter than four characters are left out.
+func RedactValues(vals map[string]interface{}, paths []string) (map[string]interface{}, []string) {
+	out := copyValues(vals).(map[string]interface{})
+	var masked []interface{}
+	for _, p := range paths {
+		maskPath(out, strings.Split(p, "."), &masked)
+	}
+
+	seen := map[string]bool{}
+	var secrets []string
+	for _, v := range masked {
+		for _, s := range leafStrings(v) {
+			if len(s) < minSecretLength {
+				continue
+			}
+			for _, s := range []string{s, base64.StdEncoding.EncodeToString([]byte(s))} {
+				if !seen[s] {
+					seen[s] = true
+					secrets = append(secrets, s)
+				}
+			}
+		}
+	}
+	return out, secrets
+}
+
+// RedactOrigins returns a copy of origins in which the values at the given
+// paths, or within them, are replaced with SensitiveMask.
+func RedactOrigins(origins []ValueOrigin, paths []string) []ValueOrigin {
+	out := make([]ValueOrigin, len(origins))
+	for i, o := range origins {
+		// Mask the value as part of a tree holding only it, so that paths
+		// that go into lists are matched as in RedactValues.
+		segs := strings.Split(o.Path, ".")
+		parents, key := segs[:len(segs)-1], segs[len(segs)-1]
+		tree := map[string]interface{}{}
+		node := tree
+		for _, seg := range parents {
+			sub := map[string]interface{}{}
+			node[seg] = sub
+			node = sub
+		}
+		node[key] = o.Value
+
+		var masked interface{}
+		masked, _ = RedactValues(tree, paths)
+		for _, seg := range append(parents, key) {
+			table, ok := masked.(map[string]interface{})
+			if !ok {
+				// A table holding the value was masked as a whole.
+				break
+			}
+			masked = table[seg]
+		}
+		o.Value = masked
+		out[i] = o
+	}
+	return out
+}
+
+// maskPath replaces the values at the path segs of node with SensitiveMask,
+// and appends the replaced values to masked.
+func maskPath(node interface{}, segs []string, masked *[]interface{}) {
+	seg, rest := segs[0], segs[1:]
+	visit := func(v interface{}, set func(interface{})) {
+		if len(rest) > 0 {
+			maskPath(v, rest, masked)
+			return
+		}
+		// Null values have nothing to hide.
+		if v != nil {
+			*masked = append(*masked, v)
+			set(SensitiveMask)
+		}
+	}
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, v := range n {
+			if seg == "*" || seg == k {
+				k := k
+				visit(v, func(m interface{}) { n[k] = m })
+			}
+		}
+	case []interface{}:
+		if seg != "*" {
+			return
+		}
+		for i, v := range n {
+			i := i
+			visit(v, func(m interface{}) { n[i] = m })
+		}
+	}
+}
+
+// leafStrings returns the non-empty scalar values of v, formatted as strings.
+// Booleans are left out, as masking every "true" would hide too much.
+func leafStrings(v interface{}) []string {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		var out []string
+		for _, sub := range vv {
+			out = append(out, leafStrings(sub)...)
+		}
+		return out
+	case []interface{}:
+		var out []string
+		for _, sub := range vv {
+			out = append(out, leafStrings(sub)...)
+		}
+		return out
+	case nil, bool:
+		return nil
+	case string:
+		if vv == "" {
+			return nil
+		}
+		return []string{vv}
+	default:
+		return []string{fmt.Sprint(vv)}
+	}
+}
+
+// copyValues returns a copy of v in which all tables and lists are copied.
+func copyValues(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(vv))
+		for k, sub := range vv {
+			out[k] = copyValues(sub)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(vv))
+		for i, sub := range vv {
+			out[i] = copyValues(sub)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestSensitivePaths(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "parent", Sensitive: []string{"auth.token", "global.registryPassword"}},
+		Schema: []byte(`{
+  "properties": {
+    "database": {
+      "properties": {
+        "password": {"type": "string", "sensitive": true},
+        "user": {"type": "string"}
+      }
+    },
+    "users": {
+      "items": {
+        "properties": {"apiKey": {"sensitive": true}}
+      }
+    },
+    "certs": {
+      "additionalProperties": {"sensitive": true}
+    },
+    "extra": {
+      "oneOf": [{"properties": {"secret": {"sensitive": true}}}]
+    }
+  }
+}`),
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "mysql", Sensitive: []string{"rootPassword", "global.dbPassword"}},
+			},
+		},
+	}
+
+	expect := []string{
+		"auth.token",
+		"certs.*",
+		"database.password",
+		"extra.secret",
+		"global.dbPassword",
+		"global.registryPassword",
+		"mysql.rootPassword",
+		"users.*.apiKey",
+	}
+	if paths := SensitivePaths(c); !reflect.DeepEqual(paths, expect) {
+		t.Errorf("Expected sensitive paths %v, got %v", expect, paths)
+	}
+}
+
+func TestRedactValues(t *testing.T) {
+	vals, err := ReadValues([]byte(`
+database:
+  user: admin
+  password: hunter22
+users:
+- name: alice
+  apiKey: alice-key
+- name: bob
+  apiKey: null
+auth:
+  token:
+    id: 1234
+    secret: s3cr3t-token
+  enabled: true
+pin: 42
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{"database.password", "users.*.apiKey", "auth.token", "pin", "missing.path"}
+
+	redacted, secrets := RedactValues(vals, paths)
+
+	expect, err := ReadValues([]byte(`
+database:
+  user: admin
+  password: "******"
+users:
+- name: alice
+  apiKey: "******"
+- name: bob
+  apiKey: null
+auth:
+  token: "******"
+  enabled: true
+pin: "******"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(redacted, expect.AsMap()) {
+		t.Errorf("Expected redacted values %v, got %v", expect, redacted)
+	}
+
+	// The original values are left as they are.
+	if vals["database"].(map[string]interface{})["password"] != "hunter22" {
+		t.Errorf("Expected the original values to be left unchanged, got %v", vals)
+	}
+
+	expectSecrets := []string{
+		"1234", "MTIzNA==",
+		"alice-key", "YWxpY2Uta2V5",
+		"hunter22", "aHVudGVyMjI=",
+		"s3cr3t-token", "czNjcjN0LXRva2Vu",
+	}
+	sort.Strings(secrets)
+	sort.Strings(expectSecrets)
+	if !reflect.DeepEqual(secrets, expectSecrets) {
+		t.Errorf("Expected secrets %v, got %v", expectSecrets, secrets)
+	}
+}
+
+func TestRedactOrigins(t *testing.T) {
+	origins := []ValueOrigin{
+		{Path: "database.password", Value: "hunter22", Source: "values.yaml"},
+		{Path: "database.user", Value: "admin", Source: "values.yaml"},
+		{Path: "auth.token.secret", Value: "s3cr3t", Source: "--set"},
+		{Path: "users", Value: []interface{}{
+			map[string]interface{}{"name": "alice", "apiKey": "alice-key"},
+		}, Source: "values.yaml"},
+	}
+	paths := []string{"auth.token", "database.password", "users.*.apiKey"}
+
+	redacted := RedactOrigins(origins, paths)
+
+	expect := []ValueOrigin{
+		{Path: "database.password", Value: SensitiveMask, Source: "values.yaml"},
+		{Path: "database.user", Value: "admin", Source: "values.yaml"},
+		{Path: "auth.token.secret", Value: SensitiveMask, Source: "--set"},
+		{Path: "users", Value: []interface{}{
+			map[string]interface{}{"name": "alice", "apiKey": SensitiveMask},
+		}, Source: "values.yaml"},
+	}
+	if !reflect.DeepEqual(redacted, expect) {
+		t.Errorf("Expected redacted origins %v, got %v", expect, redacted)
+	}
+	if origins[0].Value != "hunter22" {
+		t.Errorf("Expected the original origins to be left unchanged, got %v", origins)
+	}
+}
@@ -137,9 +137,14 @@ func ReadValuesFile(filename string) (Values, error) {
 //
 //	- Values in a higher level chart always override values in a lower-level
 //		dependency chart
-//	- Scalar values and arrays are replaced, maps are merged
+//	- Scalar values are replaced, maps are merged, and arrays are replaced
+//		unless a merge strategy is declared for them (see MergeKey)
 //	- A chart has access to all of the variables for it, as well as all of
 //		the values destined for its dependencies.
+//	- A null value removes the key, along with any value a lower-level source
+//		gives it. This applies at any depth, to the values of dependency
+//		charts, to globals and to imported values. No null values are left in
+//		the coalesced values.
 func CoalesceValues(chrt *chart.Chart, vals *chart.Config) (Values, error) {
 	cvals := Values{}
 	// Parse values if not nil. We merge these at the top level because
@@ -149,35 +154,85 @@ func CoalesceValues(chrt *chart.Chart, vals *chart.Config) (Values, error) {
 		if err != nil {
 			return cvals, err
 		}
-		cvals, err = coalesce(chrt, evals)
+		cvals, err = coalesce(chrt, evals, readDefaults)
 		if err != nil {
 			return cvals, err
 		}
 	}
 
 	var err error
-	cvals, err = coalesceDeps(chrt, cvals)
+	cvals, err = coalesceDeps(chrt, cvals, readDefaults)
+	pruneNulls(cvals)
 	return cvals, err
 }
 
+// pruneNulls removes the keys with null values from vals and its tables,
+// including the tables in lists.
+//
+// While values are coalesced, a null value is kept as a marker that hides
+// the values of every lower-level source, including the defaults of
+// dependency charts, which are coalesced after their parents. Once all
+// sources are coalesced, the markers are removed.
+func pruneNulls(vals map[string]interface{}) {
+	for k, v := range vals {
+		if v == nil {
+			delete(vals, k)
+			continue
+		}
+		pruneListNulls(v)
+	}
+}
+
+// pruneListNulls removes the keys with null values from a value, including
+// the tables in a list.
+func pruneListNulls(v interface{}) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		pruneNulls(vv)
+	case []interface{}:
+		for _, item := range vv {
+			pruneListNulls(item)
+		}
+	}
+}
+
+// defaultsFunc returns the default values of a chart.
+type defaultsFunc func(c *chart.Chart) (map[string]interface{}, error)
+
+// readDefaults reads the default values of a chart from its values file.
+func readDefaults(c *chart.Chart) (map[string]interface{}, error) {
+	// If there are no values in the chart, there is nothing to coalesce
+	if c.Values == nil || c.Values.Raw == "" {
+		return nil, nil
+	}
+	nv, err := ReadValues([]byte(c.Values.Raw))
+	if err != nil {
+		// FIXME: We should log this error. It indicates that the YAML data
+		// did not parse.
+		return nil, fmt.Errorf("error reading default values: %s", err)
+	}
+	return nv, nil
+}
+
 // coalesce coalesces the dest values and the chart values, giving priority to the dest values.
 //
 // This is a helper function for CoalesceValues.
-func coalesce(ch *chart.Chart, dest map[string]interface{}) (map[string]interface{}, error) {
+func coalesce(ch *chart.Chart, dest map[string]interface{}, defaults defaultsFunc) (map[string]interface{}, error) {
 	var err error
-	dest, err = coalesceValues(ch, dest)
+	dest, err = coalesceValues(ch, dest, defaults)
 	if err != nil {
 		return dest, err
 	}
-	coalesceDeps(ch, dest)
+	coalesceDeps(ch, dest, defaults)
 	return dest, nil
 }
 
 // coalesceDeps coalesces the dependencies of the given chart.
-func coalesceDeps(chrt *chart.Chart, dest map[string]interface{}) (map[string]interface{}, error) {
+func coalesceDeps(chrt *chart.Chart, dest map[string]interface{}, defaults defaultsFunc) (map[string]interface{}, error) {
 	for _, subchart := range chrt.Dependencies {
-		if c, ok := dest[subchart.Metadata.Name]; !ok {
-			// If dest doesn't already have the key, create it.
+		if c, ok := dest[subchart.Metadata.Name]; !ok || c == nil {
+			// If dest doesn't already have the key, create it. A null removes
+			// the values given to the subchart, leaving its own defaults.
 			dest[subchart.Metadata.Name] = map[string]interface{}{}
 		} else if !istable(c) {
 			return dest, fmt.Errorf("type mismatch on %s: %t", subchart.Metadata.Name, c)
@@ -190,7 +245,7 @@ func coalesceDeps(chrt *chart.Chart, dest map[string]interface{}) (map[string]in
 
 			var err error
 			// Now coalesce the rest of the values.
-			dest[subchart.Metadata.Name], err = coalesce(subchart, dvmap)
+			dest[subchart.Metadata.Name], err = coalesce(subchart, dvmap, defaults)
 			if err != nil {
 				return dest, err
 			}
@@ -224,6 +279,11 @@ func coalesceGlobals(dest, src map[string]interface{}) map[string]interface{} {
 	// here, but I haven't found a way. So for the time being, let's allow
 	// tables in globals.
 	for key, val := range sg {
+		if val == nil {
+			// A null global removes the subchart's global, too.
+			dg[key] = nil
+			continue
+		}
 		if istable(val) {
 			vv := copyMap(val.(map[string]interface{}))
 			if destv, ok := dg[key]; ok {
@@ -263,18 +323,11 @@ func copyMap(src map[string]interface{}) map[string]interface{} {
 // coalesceValues builds up a values map for a particular chart.
 //
 // Values in v will override the values in the chart.
-func coalesceValues(c *chart.Chart, v map[string]interface{}) (map[string]interface{}, error) {
-	// If there are no values in the chart, we just return the given values
-	if c.Values == nil || c.Values.Raw == "" {
-		return v, nil
-	}
-
-	nv, err := ReadValues([]byte(c.Values.Raw))
+func coalesceValues(c *chart.Chart, v map[string]interface{}, defaults defaultsFunc) (map[string]interface{}, error) {
+	nv, err := defaults(c)
 	if err != nil {
 		// On error, we return just the overridden values.
-		// FIXME: We should log this error. It indicates that the YAML data
-		// did not parse.
-		return v, fmt.Errorf("error reading default values (%s): %s", c.Values.Raw, err)
+		return v, err
 	}
 
 	for key, val := range nv {
@@ -286,7 +339,8 @@ func coalesceValues(c *chart.Chart, v map[string]interface{}) (map[string]interf
 			// This allows Helm's various sources of values (value files or --set) to
 			// remove incompatible keys from any previous chart, file, or set values.
 			// ref: http://www.yaml.org/spec/1.2/spec.html#id2803362
-			delete(v, key)
+			//
+			// The null is kept until all values are coalesced; see pruneNulls.
 			continue
 		} else if dest, ok := v[key].(map[string]interface{}); ok {
 			// if v[key] is a table, merge nv's val table into v[key].
@@ -298,6 +352,8 @@ func coalesceValues(c *chart.Chart, v map[string]interface{}) (map[string]interf
 			// Because v has higher precedence than nv, dest values override src
 			// values.
 			coalesceTables(dest, src)
+		} else if merged, ok := coalesceLists(v, nv, key); ok {
+			v[key] = merged
 		}
 	}
 	return v, nil
@@ -305,7 +361,9 @@ func coalesceValues(c *chart.Chart, v map[string]interface{}) (map[string]interf
 
 // coalesceTables merges a source map into a destination map.
 //
-// dest is considered authoritative.
+// dest is considered authoritative. Lists are merged following the strategies
+// declared under MergeKey, and null values in dest are kept, hiding the values
+// of src.
 func coalesceTables(dst, src map[string]interface{}) map[string]interface{} {
 	// Because dest has higher precedence than src, dest values override src
 	// values.
@@ -313,6 +371,9 @@ func coalesceTables(dst, src map[string]interface{}) map[string]interface{} {
 		if istable(val) {
 			if innerdst, ok := dst[key]; !ok {
 				dst[key] = val
+			} else if innerdst == nil {
+				// A null removes the whole table.
+				continue
 			} else if istable(innerdst) {
 				coalesceTables(innerdst.(map[string]interface{}), val.(map[string]interface{}))
 			} else {
@@ -325,6 +386,8 @@ func coalesceTables(dst, src map[string]interface{}) map[string]interface{} {
 		} else if !ok { // <- ok is still in scope from preceding conditional.
 			dst[key] = val
 			continue
+		} else if merged, ok := coalesceLists(dst, src, key); ok {
+			dst[key] = merged
 		}
 	}
 	return dst
@@ -355,6 +418,8 @@ func ToRenderValues(chrt *chart.Chart, chrtVals *chart.Config, options ReleaseOp
 // ToRenderValuesCaps composes the struct from the data coming from the Releases, Charts and Values files
 //
 // This takes both ReleaseOptions and Capabilities to merge into the render values.
+// The coalesced values are validated against the schemas of the chart and its
+// dependencies.
 func ToRenderValuesCaps(chrt *chart.Chart, chrtVals *chart.Config, options ReleaseOptions, caps *Capabilities) (Values, error) {
 
 	top := map[string]interface{}{
@@ -378,6 +443,11 @@ func ToRenderValuesCaps(chrt *chart.Chart, chrtVals *chart.Config, options Relea
 		return top, err
 	}
 
+	stripMergeKeys(vals)
+	if err := ValidateAgainstSchema(chrt, vals); err != nil {
+		return top, err
+	}
+
 	top["Values"] = vals
 	return top, nil
 }
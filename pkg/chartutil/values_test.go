@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"text/template"
 
@@ -454,3 +455,225 @@ chapter:
 	}
 
 }
+
+func TestCoalesceValuesNulls(t *testing.T) {
+	const (
+		parentDefaults = `
+name: parent
+image:
+  repository: nginx
+  tag: stable
+resources:
+  limits:
+    cpu: 100m
+ports: [80, 443]
+placeholder: null
+global:
+  env: prod
+  labels:
+    team: web
+sub:
+  replicas: 2
+`
+		subDefaults = `
+port: 8080
+image:
+  tag: "1.0"
+global:
+  region: eu
+  labels:
+    tier: backend
+exports:
+  data:
+    imported: from-sub
+`
+		importReqs = "dependencies:\n- name: sub\n  import-values:\n  - data\n"
+	)
+	absent := struct{}{}
+
+	tests := []struct {
+		name     string
+		parent   string
+		sub      string
+		reqs     string
+		supplied string
+		expect   map[string]interface{}
+	}{
+		{
+			name:     "null removes a default",
+			supplied: "name: null",
+			expect:   map[string]interface{}{"name": absent},
+		},
+		{
+			name:     "null removes a nested default",
+			supplied: "image:\n  tag: null",
+			expect:   map[string]interface{}{"image.tag": absent, "image.repository": "nginx"},
+		},
+		{
+			name:     "null removes a default table",
+			supplied: "resources: null",
+			expect:   map[string]interface{}{"resources": absent},
+		},
+		{
+			name:     "null removes a nested default table",
+			supplied: "resources:\n  limits: null",
+			expect:   map[string]interface{}{"resources.limits": absent, "resources": map[string]interface{}{}},
+		},
+		{
+			name:     "null removes a default list",
+			supplied: "ports: null",
+			expect:   map[string]interface{}{"ports": absent},
+		},
+		{
+			name:     "null without a default",
+			supplied: "missing: null\nimage:\n  missing: null",
+			expect:   map[string]interface{}{"missing": absent, "image.missing": absent},
+		},
+		{
+			name:   "null default without an override",
+			expect: map[string]interface{}{"placeholder": absent},
+		},
+		{
+			name:     "value replaces a null default",
+			supplied: "placeholder: set",
+			expect:   map[string]interface{}{"placeholder": "set"},
+		},
+		{
+			name:     "null removes a subchart default",
+			supplied: "sub:\n  port: null",
+			expect:   map[string]interface{}{"sub.port": absent, "sub.replicas": float64(2)},
+		},
+		{
+			name:     "null removes a nested subchart default",
+			supplied: "sub:\n  image:\n    tag: null",
+			expect:   map[string]interface{}{"sub.image.tag": absent},
+		},
+		{
+			name:     "null removes a parent default for a subchart",
+			supplied: "sub:\n  replicas: null",
+			expect:   map[string]interface{}{"sub.replicas": absent, "sub.port": float64(8080)},
+		},
+		{
+			name:   "parent default null removes a subchart default",
+			parent: "sub:\n  port: null",
+			expect: map[string]interface{}{"sub.port": absent},
+		},
+		{
+			name:     "value replaces a parent default null for a subchart",
+			parent:   "sub:\n  port: null",
+			supplied: "sub:\n  port: 9090",
+			expect:   map[string]interface{}{"sub.port": float64(9090)},
+		},
+		{
+			name:     "null subchart table leaves the subchart defaults",
+			supplied: "sub: null",
+			expect:   map[string]interface{}{"sub.replicas": absent, "sub.port": float64(8080)},
+		},
+		{
+			name:     "null removes a global everywhere",
+			supplied: "global:\n  env: null",
+			expect:   map[string]interface{}{"global.env": absent, "sub.global.env": absent},
+		},
+		{
+			name:     "parent null global removes a subchart global",
+			supplied: "global:\n  region: null",
+			expect:   map[string]interface{}{"global.region": absent, "sub.global.region": absent},
+		},
+		{
+			name:     "null removes a global table",
+			supplied: "global:\n  labels: null",
+			expect:   map[string]interface{}{"global.labels": absent, "sub.global.labels": absent},
+		},
+		{
+			name:     "null removes a key of a global table",
+			supplied: "global:\n  labels:\n    tier: null",
+			expect:   map[string]interface{}{"sub.global.labels.tier": absent, "sub.global.labels.team": "web"},
+		},
+		{
+			name:     "null removes an imported value",
+			reqs:     importReqs,
+			supplied: "imported: null",
+			expect:   map[string]interface{}{"imported": absent},
+		},
+		{
+			name:   "exported null removes a parent default",
+			parent: "imported: from-parent",
+			sub:    "exports:\n  data:\n    imported: null",
+			reqs:   importReqs,
+			expect: map[string]interface{}{"imported": absent},
+		},
+		{
+			name:   "imported value without nulls",
+			reqs:   importReqs,
+			expect: map[string]interface{}{"imported": "from-sub"},
+		},
+	}
+
+	for _, tt := range tests {
+		// Keys repeated at the end of the defaults replace the earlier ones.
+		sub := &chart.Chart{
+			Metadata: &chart.Metadata{Name: "sub"},
+			Values:   &chart.Config{Raw: subDefaults + tt.sub},
+		}
+		c := &chart.Chart{
+			Metadata:     &chart.Metadata{Name: "parent"},
+			Values:       &chart.Config{Raw: parentDefaults + tt.parent},
+			Dependencies: []*chart.Chart{sub},
+		}
+		if tt.reqs != "" {
+			c.Files = []*any.Any{{TypeUrl: "requirements.yaml", Value: []byte(tt.reqs)}}
+		}
+		if err := ProcessRequirementsImportValues(c); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+
+		v, err := CoalesceValues(c, &chart.Config{Raw: tt.supplied})
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		for path, expect := range tt.expect {
+			got, ok := lookupPath(v, path)
+			if expect == absent {
+				if ok {
+					t.Errorf("%s: expected %s to be removed, got %v", tt.name, path, got)
+				}
+				continue
+			}
+			if !ok || fmt.Sprint(got) != fmt.Sprint(expect) {
+				t.Errorf("%s: expected %s to be %v, got %v", tt.name, path, expect, got)
+			}
+		}
+		if path, ok := findNull(v, ""); ok {
+			t.Errorf("%s: expected no null values, found one at %s", tt.name, path)
+		}
+	}
+}
+
+func lookupPath(v map[string]interface{}, path string) (interface{}, bool) {
+	var cur interface{} = v
+	for _, k := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[k]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func findNull(v map[string]interface{}, prefix string) (string, bool) {
+	for k, val := range v {
+		switch vv := val.(type) {
+		case nil:
+			return prefix + k, true
+		case map[string]interface{}:
+			if p, ok := findNull(vv, prefix+k+"."); ok {
+				return p, true
+			}
+		}
+	}
+	return "", false
+}
@@ -23,6 +23,8 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"text/template/parse"
+	"time"
 
 	"github.com/Masterminds/sprig"
 
@@ -55,6 +57,24 @@ func New() *Engine {
 	}
 }
 
+// AddFuncs registers extra template functions, such as those provided by
+// plugins, with the engine.
+//
+// Like the FuncMap itself, this may only be done prior to the first call to
+// Render. No function is registered if any of them would replace a function
+// the engine already has.
+func (e *Engine) AddFuncs(funcs template.FuncMap) error {
+	for name := range funcs {
+		if _, ok := e.FuncMap[name]; ok {
+			return fmt.Errorf("template function %q is already defined", name)
+		}
+	}
+	for name, fn := range funcs {
+		e.FuncMap[name] = fn
+	}
+	return nil
+}
+
 // FuncMap returns a mapping of all of the functions that Engine has.
 //
 // Because some functions are late-bound (e.g. contain context-sensitive
@@ -84,6 +104,15 @@ func FuncMap() template.FuncMap {
 		"toJson":   chartutil.ToJson,
 		"fromJson": chartutil.FromJson,
 
+		// Functions for working with Kubernetes data.
+		"fromYamlArray": fromYamlArray,
+		"toJsonPretty":  toJSONPretty,
+		"toSecretData":  toSecretData,
+		"semverCompare": semverCompare,
+		"hasKind":       hasKind,
+		"labelSelector": labelSelector,
+		"mustRequired":  mustRequired,
+
 		// This is a placeholder for the "include" function, which is
 		// late-bound to a template. By declaring it here, we preserve the
 		// integrity of the linter.
@@ -126,6 +155,22 @@ func (e *Engine) Render(chrt *chart.Chart, values chartutil.Values) (map[string]
 	return e.render(tmap)
 }
 
+// RenderWithProfile renders a chart exactly like Render, and additionally
+// returns a Profile with the wall time and invocation count of every
+// template file and of every named template invoked through "include" or
+// "template".
+//
+// Profiling adds a small amount of overhead, so it is only done when asked for.
+func (e *Engine) RenderWithProfile(chrt *chart.Chart, values chartutil.Values) (map[string]string, *Profile, error) {
+	prof := newProfile()
+	start := time.Now()
+	tmap := allTemplates(chrt, values)
+	e.CurrentTemplates = tmap
+	out, err := e.renderWithProfile(tmap, prof)
+	prof.Total = time.Since(start)
+	return out, prof, err
+}
+
 // renderable is an object that can be rendered.
 type renderable struct {
 	// tpl is the current template.
@@ -139,7 +184,9 @@ type renderable struct {
 // alterFuncMap takes the Engine's FuncMap and adds context-specific functions.
 //
 // The resulting FuncMap is only valid for the passed-in template.
-func (e *Engine) alterFuncMap(t *template.Template) template.FuncMap {
+//
+// If prof is not nil, calls to "include" are recorded in it.
+func (e *Engine) alterFuncMap(t *template.Template, prof *Profile) template.FuncMap {
 	// Clone the func map because we are adding context-specific functions.
 	var funcMap template.FuncMap = map[string]interface{}{}
 	for k, v := range e.FuncMap {
@@ -148,6 +195,9 @@ func (e *Engine) alterFuncMap(t *template.Template) template.FuncMap {
 
 	// Add the 'include' function here so we can close over t.
 	funcMap["include"] = func(name string, data interface{}) (string, error) {
+		if prof != nil {
+			defer prof.recordDefine(name, time.Now())
+		}
 		buf := bytes.NewBuffer(nil)
 		if err := t.ExecuteTemplate(buf, name, data); err != nil {
 			return "", err
@@ -190,6 +240,13 @@ func (e *Engine) alterFuncMap(t *template.Template) template.FuncMap {
 // render takes a map of templates/values and renders them.
 //利用golang template包解析
 func (e *Engine) render(tpls map[string]renderable) (map[string]string, error) {
+	return e.renderWithProfile(tpls, nil)
+}
+
+// renderWithProfile renders the templates like render. If prof is not nil,
+// the execution of each template and each "include" or "template" call is
+// recorded in it.
+func (e *Engine) renderWithProfile(tpls map[string]renderable, prof *Profile) (map[string]string, error) {
 	// Basically, what we do here is start with an empty parent template and then
 	// build up a list of templates -- one for each file. Once all of the templates
 	// have been parsed, we loop through again and execute every template.
@@ -208,7 +265,7 @@ func (e *Engine) render(tpls map[string]renderable) (map[string]string, error) {
 	}
 
 	//添加特殊的函数到模板引擎中
-	funcMap := e.alterFuncMap(t)
+	funcMap := e.alterFuncMap(t, prof)
 
 	// We want to parse the templates in a predictable order. The order favors
 	// higher-level (in file system) templates over deeply nested templates.
@@ -238,6 +295,14 @@ func (e *Engine) render(tpls map[string]renderable) (map[string]string, error) {
 		}
 	}
 
+	if prof != nil {
+		for _, tpl := range t.Templates() {
+			if tpl.Tree != nil {
+				profileTemplateActions(tpl.Tree.Root)
+			}
+		}
+	}
+
 	rendered := make(map[string]string, len(files))
 	var buf bytes.Buffer
 	for _, file := range files {
@@ -249,9 +314,11 @@ func (e *Engine) render(tpls map[string]renderable) (map[string]string, error) {
 		// At render time, add information about the template that is being rendered.
 		vals := tpls[file].vals
 		vals["Template"] = map[string]interface{}{"Name": file, "BasePath": tpls[file].basePath}
+		start := time.Now()
 		if err := t.ExecuteTemplate(&buf, file, vals); err != nil {
 			return map[string]string{}, fmt.Errorf("render error in %q: %s", file, err)
 		}
+		prof.recordTemplate(file, start)
 
 		// Work around the issue where Go will emit "<no value>" even if Options(missing=zero)
 		// is set. Since missing=error will never get here, we do not need to handle
@@ -263,6 +330,58 @@ func (e *Engine) render(tpls map[string]renderable) (map[string]string, error) {
 	return rendered, nil
 }
 
+// profileTemplateActions replaces the {{template "name" pipeline}} actions
+// in a parse tree with {{include "name" (pipeline)}}, which renders the same
+// output, so that the named templates they invoke are profiled too.
+func profileTemplateActions(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for i, child := range n.Nodes {
+			if tn, ok := child.(*parse.TemplateNode); ok {
+				n.Nodes[i] = includeAction(tn)
+				continue
+			}
+			profileTemplateActions(child)
+		}
+	case *parse.IfNode:
+		profileTemplateActions(n.List)
+		profileTemplateActions(n.ElseList)
+	case *parse.RangeNode:
+		profileTemplateActions(n.List)
+		profileTemplateActions(n.ElseList)
+	case *parse.WithNode:
+		profileTemplateActions(n.List)
+		profileTemplateActions(n.ElseList)
+	}
+}
+
+// includeAction returns the "include" action equivalent to a template action.
+func includeAction(tn *parse.TemplateNode) *parse.ActionNode {
+	var data parse.Node = &parse.NilNode{NodeType: parse.NodeNil, Pos: tn.Pos}
+	if tn.Pipe != nil {
+		data = tn.Pipe
+	}
+	args := []parse.Node{
+		&parse.IdentifierNode{NodeType: parse.NodeIdentifier, Pos: tn.Pos, Ident: "include"},
+		&parse.StringNode{NodeType: parse.NodeString, Pos: tn.Pos, Quoted: fmt.Sprintf("%q", tn.Name), Text: tn.Name},
+		data,
+	}
+	return &parse.ActionNode{
+		NodeType: parse.NodeAction,
+		Pos:      tn.Pos,
+		Line:     tn.Line,
+		Pipe: &parse.PipeNode{
+			NodeType: parse.NodePipe,
+			Pos:      tn.Pos,
+			Line:     tn.Line,
+			Cmds:     []*parse.CommandNode{{NodeType: parse.NodeCommand, Pos: tn.Pos, Args: args}},
+		},
+	}
+}
+
 func sortTemplates(tpls map[string]renderable) []string {
 	keys := make([]string, len(tpls))
 	i := 0
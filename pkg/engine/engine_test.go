@@ -18,8 +18,10 @@ package engine
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
+	"text/template"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/chart"
@@ -80,7 +82,8 @@ func TestFuncMap(t *testing.T) {
 	}
 
 	// Test for Engine-specific template functions.
-	expect := []string{"include", "required", "tpl", "toYaml", "fromYaml", "toToml", "toJson", "fromJson"}
+	expect := []string{"include", "required", "tpl", "toYaml", "fromYaml", "toToml", "toJson", "fromJson",
+		"fromYamlArray", "toJsonPretty", "toSecretData", "semverCompare", "hasKind", "labelSelector", "mustRequired"}
 	for _, f := range expect {
 		if _, ok := fns[f]; !ok {
 			t.Errorf("Expected add-on function %q", f)
@@ -88,6 +91,38 @@ func TestFuncMap(t *testing.T) {
 	}
 }
 
+func TestAddFuncs(t *testing.T) {
+	e := New()
+	err := e.AddFuncs(template.FuncMap{
+		"shout": func(s string) string { return strings.ToUpper(s) + "!" },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tpls := map[string]renderable{
+		"one": {tpl: `{{ shout .Name }}`, vals: chartutil.Values{"Name": "hello"}},
+	}
+	out, err := e.render(tpls)
+	if err != nil {
+		t.Fatalf("Failed template rendering: %s", err)
+	}
+	if out["one"] != "HELLO!" {
+		t.Errorf("Expected 'HELLO!', got %q", out["one"])
+	}
+
+	err = e.AddFuncs(template.FuncMap{
+		"whisper": strings.ToLower,
+		"toYaml":  func(interface{}) string { return "" },
+	})
+	if err == nil {
+		t.Error("Expected an error when replacing a built-in function")
+	}
+	if _, ok := e.FuncMap["whisper"]; ok {
+		t.Error("Expected no function to be registered after a conflict")
+	}
+}
+
 func TestRender(t *testing.T) {
 	c := &chart.Chart{
 		Metadata: &chart.Metadata{
@@ -564,3 +599,93 @@ func TestAlterFuncMap(t *testing.T) {
 	}
 
 }
+
+func TestRenderWithProfile(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/whale", Data: []byte(`{{include "moby.name" .}} {{include "moby.name" .}}`)},
+			{Name: "templates/ship", Data: []byte(`Pequod`)},
+			{Name: "templates/_helpers.tpl", Data: []byte(`{{define "moby.name"}}{{.Release.Name}}{{end}}`)},
+		},
+		Values: &chart.Config{Raw: ``},
+	}
+
+	v := chartutil.Values{
+		"Values": chartutil.Values{},
+		"Chart":  c.Metadata,
+		"Release": chartutil.Values{
+			"Name": "Ishmael",
+		},
+	}
+
+	out, prof, err := New().RenderWithProfile(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := "Ishmael Ishmael"; out["moby/templates/whale"] != expect {
+		t.Errorf("Expected %q, got %q", expect, out["moby/templates/whale"])
+	}
+
+	if len(prof.Templates) != 2 {
+		t.Errorf("Expected 2 profiled templates, got %d", len(prof.Templates))
+	}
+	for _, name := range []string{"moby/templates/whale", "moby/templates/ship"} {
+		if e, ok := prof.Templates[name]; !ok || e.Calls != 1 {
+			t.Errorf("Expected one call recorded for %s, got %v", name, e)
+		}
+	}
+	if _, ok := prof.Templates["moby/templates/_helpers.tpl"]; ok {
+		t.Error("Partials should not be profiled as templates")
+	}
+
+	e, ok := prof.Defines["moby.name"]
+	if !ok {
+		t.Fatal("Expected moby.name to be profiled")
+	}
+	if e.Calls != 2 {
+		t.Errorf("Expected 2 calls to moby.name, got %d", e.Calls)
+	}
+
+	sorted := prof.SortedTemplates()
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i-1].Duration < sorted[i].Duration {
+			t.Errorf("Expected templates sorted slowest first, got %v", sorted)
+		}
+	}
+}
+
+func TestRenderWithProfileTemplateActions(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/whale", Data: []byte(`{{template "moby.name" .}}{{range .Values.boats}} {{template "moby.boat" .}}{{end}}{{if true}} {{template "moby.empty"}}{{end}}`)},
+			{Name: "templates/_helpers.tpl", Data: []byte(`{{define "moby.name"}}{{.Release.Name}}{{end}}{{define "moby.boat"}}{{.}}{{end}}{{define "moby.empty"}}-{{.}}-{{end}}`)},
+		},
+		Values: &chart.Config{Raw: ``},
+	}
+
+	v := chartutil.Values{
+		"Values":  chartutil.Values{"boats": []interface{}{"Pequod", "Rachel"}},
+		"Chart":   c.Metadata,
+		"Release": chartutil.Values{"Name": "Ishmael"},
+	}
+
+	out, prof, err := New().RenderWithProfile(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out["moby/templates/whale"] != plain["moby/templates/whale"] {
+		t.Errorf("Expected the profiled output %q to match %q", out["moby/templates/whale"], plain["moby/templates/whale"])
+	}
+	for name, calls := range map[string]int{"moby.name": 1, "moby.boat": 2, "moby.empty": 1} {
+		if e, ok := prof.Defines[name]; !ok || e.Calls != calls {
+			t.Errorf("Expected %d calls to %s, got %v", calls, name, e)
+		}
+	}
+}
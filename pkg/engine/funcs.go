@@ -0,0 +1,253 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/version"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// fromYamlArray converts a YAML array into a []interface{}.
+//
+// Like chartutil.FromYaml, it tolerates errors: if the document cannot be
+// parsed, the error message is returned as the only element of the array.
+func fromYamlArray(str string) []interface{} {
+	a := []interface{}{}
+	if err := yaml.Unmarshal([]byte(str), &a); err != nil {
+		a = []interface{}{err.Error()}
+	}
+	return a
+}
+
+// toJSONPretty marshals v to indented JSON. Errors are swallowed, as in toJson.
+func toJSONPretty(v interface{}) string {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// validSecretKey matches the keys Kubernetes accepts in a Secret's data.
+var validSecretKey = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// toSecretData base64-encodes every value of a map, for use as the data of a
+// Secret.
+//
+// Unlike piping each value through b64enc, it accepts values of any type:
+// numbers are written without exponents (so 1000000 stays "1000000"), nil
+// becomes the empty string, and lists and maps are encoded as JSON.
+func toSecretData(data map[string]interface{}) (map[string]string, error) {
+	out := make(map[string]string, len(data))
+	for k, v := range data {
+		if !validSecretKey.MatchString(k) {
+			return nil, fmt.Errorf("toSecretData: invalid key %q", k)
+		}
+		s, err := secretValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("toSecretData: key %q: %s", k, err)
+		}
+		out[k] = base64.StdEncoding.EncodeToString(s)
+	}
+	return out, nil
+}
+
+func secretValue(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return []byte{}, nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case bool:
+		return []byte(strconv.FormatBool(v)), nil
+	case float64:
+		return []byte(strconv.FormatFloat(v, 'f', -1, 64)), nil
+	case float32:
+		return []byte(strconv.FormatFloat(float64(v), 'f', -1, 32)), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return []byte(fmt.Sprint(v)), nil
+	}
+	return json.Marshal(v)
+}
+
+// semverCompare reports whether ver satisfies the constraint.
+//
+// It replaces the Sprig function of the same name, and in addition to
+// version strings accepts a Kubernetes version such as
+// .Capabilities.KubeVersion. Provider suffixes such as "-gke.0" are ignored
+// for Kubernetes versions, so that "v1.8.4-gke.0" satisfies ">=1.8".
+func semverCompare(constraint string, ver interface{}) (bool, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+
+	var vs string
+	switch ver := ver.(type) {
+	case string:
+		vs = ver
+	case *version.Info:
+		vs = kubeVersionString(ver)
+	case version.Info:
+		vs = kubeVersionString(&ver)
+	default:
+		return false, fmt.Errorf("semverCompare: cannot compare against %T", ver)
+	}
+
+	v, err := semver.NewVersion(vs)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(v), nil
+}
+
+var leadingDigits = regexp.MustCompile(`^[0-9]+`)
+
+// kubeVersionString returns the plain semantic version of a Kubernetes
+// version, without pre-release or build suffixes.
+func kubeVersionString(info *version.Info) string {
+	if info.GitVersion != "" {
+		if v, err := semver.NewVersion(info.GitVersion); err == nil {
+			return fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+		}
+	}
+	// Some providers report minor versions such as "8+".
+	return leadingDigits.FindString(info.Major) + "." + leadingDigits.FindString(info.Minor) + ".0"
+}
+
+// hasKind reports whether obj, a Kubernetes object or a list of objects, is
+// or contains an object of the given kind.
+//
+// The kind may be qualified with its API version, as in "apps/v1beta1/Deployment".
+func hasKind(kind string, obj interface{}) bool {
+	apiVersion := ""
+	if i := strings.LastIndex(kind, "/"); i >= 0 {
+		apiVersion, kind = kind[:i], kind[i+1:]
+	}
+
+	matches := func(o interface{}) bool {
+		m, ok := asMap(o)
+		if !ok || m["kind"] != kind {
+			return false
+		}
+		return apiVersion == "" || m["apiVersion"] == apiVersion
+	}
+
+	items, ok := obj.([]interface{})
+	if !ok {
+		if m, isMap := asMap(obj); isMap {
+			items, ok = m["items"].([]interface{})
+		}
+		if !ok {
+			items = []interface{}{obj}
+		}
+	}
+	for _, o := range items {
+		if matches(o) {
+			return true
+		}
+	}
+	return false
+}
+
+// labelSelector renders a label selector in the string form accepted by
+// kubectl and the Kubernetes API, e.g. "app=web,tier in (backend,cache)".
+//
+// The selector is either a map of labels or a LabelSelector with
+// matchLabels and/or matchExpressions.
+func labelSelector(sel interface{}) (string, error) {
+	m, ok := asMap(sel)
+	if !ok {
+		return "", fmt.Errorf("labelSelector: expected a map, got %T", sel)
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := m["matchLabels"]; ok {
+		return parseLabelSelector(data)
+	}
+	if _, ok := m["matchExpressions"]; ok {
+		return parseLabelSelector(data)
+	}
+
+	set := labels.Set{}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return "", fmt.Errorf("labelSelector: %s", err)
+	}
+	return labels.SelectorFromSet(set).String(), nil
+}
+
+func parseLabelSelector(data []byte) (string, error) {
+	ls := &metav1.LabelSelector{}
+	if err := json.Unmarshal(data, ls); err != nil {
+		return "", fmt.Errorf("labelSelector: %s", err)
+	}
+	s, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		return "", fmt.Errorf("labelSelector: %s", err)
+	}
+	return s.String(), nil
+}
+
+// mustRequired returns the value at the dotted path in vals, and fails with
+// the path if the value is missing, null or empty.
+//
+//	{{ mustRequired "image.tag" .Values }}
+func mustRequired(path string, vals interface{}) (interface{}, error) {
+	cur := vals
+	walked := []string{}
+	for _, key := range strings.Split(path, ".") {
+		m, ok := asMap(cur)
+		if !ok {
+			return nil, fmt.Errorf("required value %q is missing: %q is not a table", path, strings.Join(walked, "."))
+		}
+		walked = append(walked, key)
+		if cur, ok = m[key]; !ok {
+			return nil, fmt.Errorf("required value %q is missing", path)
+		}
+	}
+	if cur == nil || cur == "" {
+		return nil, fmt.Errorf("required value %q is empty", path)
+	}
+	return cur, nil
+}
+
+func asMap(v interface{}) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v, true
+	case chartutil.Values:
+		return v, true
+	}
+	return nil, false
+}
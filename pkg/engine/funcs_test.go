@@ -0,0 +1,127 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/version"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+func runFuncTest(t *testing.T, tpl string, vals interface{}) (string, error) {
+	tt, err := template.New("test").Funcs(FuncMap()).Parse(tpl)
+	if err != nil {
+		t.Fatalf("Failed to parse %q: %s", tpl, err)
+	}
+	var b bytes.Buffer
+	err = tt.Execute(&b, vals)
+	return b.String(), err
+}
+
+func TestKubeFuncs(t *testing.T) {
+	vals := map[string]interface{}{
+		"Values": chartutil.Values{
+			"image": map[string]interface{}{"repository": "nginx", "tag": ""},
+			"secrets": map[string]interface{}{
+				"password": "hunter2",
+				"port":     float64(1000000),
+				"enabled":  true,
+				"empty":    nil,
+				"list":     []interface{}{"a", "b"},
+			},
+			"labels": map[string]interface{}{"tier": "web", "app": "nginx"},
+			"selector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{"app": "nginx"},
+				"matchExpressions": []interface{}{
+					map[string]interface{}{"key": "tier", "operator": "In", "values": []interface{}{"web", "cache"}},
+				},
+			},
+		},
+		"KubeVersion": &version.Info{Major: "1", Minor: "8+", GitVersion: "v1.8.4-gke.0"},
+	}
+
+	tests := []struct {
+		tpl, expect string
+	}{
+		{`{{ fromYamlArray "- one\n- two" | last }}`, "two"},
+		{`{{ fromYamlArray "one: two" | first | contains "cannot unmarshal" }}`, "true"},
+		{`{{ .Values.labels | toJsonPretty }}`, "{\n  \"app\": \"nginx\",\n  \"tier\": \"web\"\n}"},
+		{`{{ $d := toSecretData .Values.secrets }}{{ $d.password }}`, "aHVudGVyMg=="},
+		{`{{ $d := toSecretData .Values.secrets }}{{ $d.port | b64dec }}`, "1000000"},
+		{`{{ $d := toSecretData .Values.secrets }}{{ $d.enabled | b64dec }}`, "true"},
+		{`{{ $d := toSecretData .Values.secrets }}{{ $d.empty }}`, ""},
+		{`{{ $d := toSecretData .Values.secrets }}{{ $d.list | b64dec }}`, `["a","b"]`},
+		{`{{ semverCompare ">=1.8" .KubeVersion }}`, "true"},
+		{`{{ semverCompare "<1.8" .KubeVersion }}`, "false"},
+		{`{{ semverCompare "^1.2.0" "1.2.3" }}`, "true"},
+		{`{{ hasKind "Service" (fromYaml "kind: Service\napiVersion: v1") }}`, "true"},
+		{`{{ hasKind "v1/Service" (fromYaml "kind: Service\napiVersion: v1") }}`, "true"},
+		{`{{ hasKind "apps/v1beta1/Service" (fromYaml "kind: Service\napiVersion: v1") }}`, "false"},
+		{`{{ hasKind "Deployment" (fromYamlArray "- kind: Service\n- kind: Deployment") }}`, "true"},
+		{`{{ hasKind "Deployment" (fromYaml "kind: List\nitems:\n- kind: Service") }}`, "false"},
+		{`{{ labelSelector .Values.labels }}`, "app=nginx,tier=web"},
+		{`{{ labelSelector .Values.selector }}`, "app=nginx,tier in (cache,web)"},
+		{`{{ mustRequired "image.repository" .Values }}`, "nginx"},
+	}
+
+	for _, tt := range tests {
+		out, err := runFuncTest(t, tt.tpl, vals)
+		if err != nil {
+			t.Errorf("Error running %q: %s", tt.tpl, err)
+			continue
+		}
+		if out != tt.expect {
+			t.Errorf("Expected %q for %q, got %q", tt.expect, tt.tpl, out)
+		}
+	}
+}
+
+func TestKubeFuncErrors(t *testing.T) {
+	vals := map[string]interface{}{
+		"Values": chartutil.Values{
+			"image":   map[string]interface{}{"repository": "nginx", "tag": ""},
+			"secrets": map[string]interface{}{"not/valid": "x"},
+		},
+	}
+
+	tests := []struct {
+		tpl, expect string
+	}{
+		{`{{ mustRequired "image.tag" .Values }}`, `required value "image.tag" is empty`},
+		{`{{ mustRequired "image.pullPolicy" .Values }}`, `required value "image.pullPolicy" is missing`},
+		{`{{ mustRequired "image.repository.name" .Values }}`, `"image.repository" is not a table`},
+		{`{{ toSecretData .Values.secrets }}`, `invalid key "not/valid"`},
+		{`{{ semverCompare ">=1.8" 8 }}`, "cannot compare against int"},
+		{`{{ labelSelector "app=web" }}`, "expected a map"},
+	}
+
+	for _, tt := range tests {
+		_, err := runFuncTest(t, tt.tpl, vals)
+		if err == nil {
+			t.Errorf("Expected an error running %q", tt.tpl)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("Expected error for %q to contain %q, got %q", tt.tpl, tt.expect, err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package overlay implements a Tiller Engine for charts written as plain
+Kubernetes manifests.
+
+Templates are not executed. Instead, each YAML document in the chart's
+templates is customized by the overlays listed under 'overlays' in the
+chart's values:
+
+	overlays:
+	- kind: Deployment
+	  name: web
+	  merge:
+	    spec:
+	      replicas: 3
+	- kind: Service
+	  jsonPatch:
+	  - op: replace
+	    path: /spec/type
+	    value: NodePort
+
+An overlay selects documents by 'apiVersion', 'kind' and 'name' (any of
+which may be omitted) and either applies 'merge' as a strategic merge patch
+(a JSON merge patch for kinds Kubernetes does not know about) or applies the
+'jsonPatch' operations. It is an error for an overlay to match nothing.
+
+A chart selects this engine with 'engine: overlay' in its Chart.yaml.
+*/
+package overlay // import "k8s.io/helm/pkg/engine/overlay"
@@ -0,0 +1,264 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overlay
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// Name is the name a chart uses to select this engine in its Chart.yaml.
+const Name = "overlay"
+
+// sep splits a file into YAML documents, as in releaseutil.SplitManifests.
+var sep = regexp.MustCompile("(?:^|\\s*\n)---\\s*")
+
+// Engine is an implementation of 'cmd/tiller/environment'.Engine that applies
+// overlays from the values to plain YAML templates.
+type Engine struct{}
+
+// New creates a new overlay Engine.
+func New() *Engine {
+	return &Engine{}
+}
+
+// Overlay is a change applied to the documents it matches.
+type Overlay struct {
+	// APIVersion, Kind and Name select the documents to change. Empty
+	// fields match any document.
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Name       string `json:"name,omitempty"`
+	// Merge is applied as a strategic merge patch.
+	Merge map[string]interface{} `json:"merge,omitempty"`
+	// JSONPatch is a list of RFC 6902 JSON Patch operations.
+	JSONPatch []interface{} `json:"jsonPatch,omitempty"`
+}
+
+// String identifies the overlay in error messages.
+func (o *Overlay) String() string {
+	parts := []string{}
+	for _, s := range []string{o.APIVersion, o.Kind, o.Name} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if len(parts) == 0 {
+		return "*"
+	}
+	return strings.Join(parts, "/")
+}
+
+func (o *Overlay) matches(doc *object) bool {
+	return (o.APIVersion == "" || o.APIVersion == doc.APIVersion) &&
+		(o.Kind == "" || o.Kind == doc.Kind) &&
+		(o.Name == "" || o.Name == doc.Metadata.Name)
+}
+
+// apply applies the overlay to a document in JSON form.
+func (o *Overlay) apply(data []byte, doc *object) ([]byte, error) {
+	if o.JSONPatch != nil {
+		ops, err := json.Marshal(o.JSONPatch)
+		if err != nil {
+			return nil, err
+		}
+		patch, err := jsonpatch.DecodePatch(ops)
+		if err != nil {
+			return nil, err
+		}
+		return patch.Apply(data)
+	}
+
+	patch, err := json.Marshal(o.Merge)
+	if err != nil {
+		return nil, err
+	}
+	gvk := schema.FromAPIVersionAndKind(doc.APIVersion, doc.Kind)
+	versionedObject, err := scheme.Scheme.New(gvk)
+	switch {
+	case runtime.IsNotRegisteredError(err):
+		// fall back to generic JSON merge patch
+		return jsonpatch.MergePatch(data, patch)
+	case err != nil:
+		return nil, err
+	default:
+		return strategicpatch.StrategicMergePatch(data, patch, versionedObject)
+	}
+}
+
+// object holds the fields overlays are matched against.
+type object struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+}
+
+// Render applies the overlays in the values to the templates of a chart and
+// its dependencies.
+//
+// As with the Go template engine, each chart only sees its own section of the
+// values, so a parent chart configures the overlays of a dependency under the
+// dependency's name. Files that are not YAML, such as NOTES.txt, are passed
+// through unchanged.
+func (e *Engine) Render(chrt *chart.Chart, values chartutil.Values) (map[string]string, error) {
+	out := map[string]string{}
+	vals, err := values.Table("Values")
+	if err != nil {
+		vals = chartutil.Values{}
+	}
+	if err := renderChart(chrt, vals, "", out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// renderChart renders the templates of c into out, recursing through its
+// dependencies.
+func renderChart(c *chart.Chart, vals chartutil.Values, parentID string, out map[string]string) error {
+	id := c.Metadata.Name
+	if parentID != "" {
+		id = path.Join(parentID, "charts", id)
+	}
+
+	for _, child := range c.Dependencies {
+		childVals, err := vals.Table(child.Metadata.Name)
+		if err != nil {
+			childVals = chartutil.Values{}
+		}
+		if err := renderChart(child, childVals, id, out); err != nil {
+			return err
+		}
+	}
+
+	overlays, err := parseOverlays(vals)
+	if err != nil {
+		return fmt.Errorf("%s: %s", id, err)
+	}
+	matched := make([]bool, len(overlays))
+
+	for _, t := range c.Templates {
+		name := path.Join(id, t.Name)
+		// Like partials in Go templates, files starting with '_' produce no output.
+		if strings.HasPrefix(path.Base(name), "_") {
+			continue
+		}
+		switch path.Ext(name) {
+		case ".yaml", ".yml", ".json":
+		default:
+			out[name] = string(t.Data)
+			continue
+		}
+
+		rendered, err := renderFile(string(t.Data), overlays, matched)
+		if err != nil {
+			return fmt.Errorf("render error in %q: %s", name, err)
+		}
+		out[name] = rendered
+	}
+
+	for i, ok := range matched {
+		if !ok {
+			return fmt.Errorf("%s: overlay %d (%s) matched no resources", id, i, overlays[i])
+		}
+	}
+	return nil
+}
+
+// renderFile applies the overlays to each document of a file. Documents that
+// no overlay matches are left as they are, comments included.
+func renderFile(data string, overlays []*Overlay, matched []bool) (string, error) {
+	docs := []string{}
+	for _, d := range sep.Split(strings.TrimSpace(data), -1) {
+		if strings.TrimSpace(d) == "" {
+			continue
+		}
+
+		js, err := yaml.YAMLToJSON([]byte(d))
+		if err != nil {
+			return "", err
+		}
+		doc := &object{}
+		// Documents holding only comments convert to null.
+		if string(js) != "null" {
+			if err := json.Unmarshal(js, doc); err != nil {
+				return "", err
+			}
+		}
+
+		changed := false
+		for i, o := range overlays {
+			if !o.matches(doc) {
+				continue
+			}
+			matched[i] = true
+			changed = true
+			if js, err = o.apply(js, doc); err != nil {
+				return "", fmt.Errorf("overlay %d (%s): %s", i, o, err)
+			}
+		}
+
+		if changed {
+			y, err := yaml.JSONToYAML(js)
+			if err != nil {
+				return "", err
+			}
+			d = string(y)
+		}
+		docs = append(docs, strings.TrimSpace(d))
+	}
+	if len(docs) == 0 {
+		return "", nil
+	}
+	return strings.Join(docs, "\n---\n") + "\n", nil
+}
+
+// parseOverlays reads the overlays in a chart's values.
+func parseOverlays(vals chartutil.Values) ([]*Overlay, error) {
+	raw, ok := vals["overlays"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	overlays := []*Overlay{}
+	if err := json.Unmarshal(data, &overlays); err != nil {
+		return nil, fmt.Errorf("cannot parse overlays: %s", err)
+	}
+	for i, o := range overlays {
+		if (o.Merge == nil) == (o.JSONPatch == nil) {
+			return nil, fmt.Errorf("overlay %d (%s) must set exactly one of merge and jsonPatch", i, o)
+		}
+	}
+	return overlays, nil
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overlay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const deployment = `# The web frontend.
+apiVersion: apps/v1beta1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.12
+        ports:
+        - containerPort: 80
+      - name: sidecar
+        image: busybox
+`
+
+const service = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: ClusterIP
+---
+# just a comment
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+spec:
+  size: small
+  color: red
+`
+
+func testChart() *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "top", Engine: Name},
+		Templates: []*chart.Template{
+			{Name: "templates/deployment.yaml", Data: []byte(deployment)},
+			{Name: "templates/service.yaml", Data: []byte(service)},
+			{Name: "templates/NOTES.txt", Data: []byte("{{ not a template }}")},
+			{Name: "templates/_ignored.yaml", Data: []byte("kind: Ignored")},
+		},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "sub", Engine: Name},
+				Templates: []*chart.Template{
+					{Name: "templates/config.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: sub\ndata:\n  mode: dev\n")},
+				},
+			},
+		},
+	}
+}
+
+func render(t *testing.T, c *chart.Chart, values string) (map[string]string, error) {
+	vals, err := chartutil.ReadValues([]byte(values))
+	if err != nil {
+		t.Fatalf("Failed to parse values: %s", err)
+	}
+	return New().Render(c, chartutil.Values{"Values": vals})
+}
+
+func docs(t *testing.T, out string) []map[string]interface{} {
+	res := []map[string]interface{}{}
+	for _, d := range sep.Split(strings.TrimSpace(out), -1) {
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(d), &m); err != nil {
+			t.Fatalf("Failed to parse %q: %s", d, err)
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+func TestRender(t *testing.T) {
+	values := `
+overlays:
+- kind: Deployment
+  name: web
+  merge:
+    spec:
+      replicas: 3
+      template:
+        spec:
+          containers:
+          - name: web
+            image: nginx:1.13
+- apiVersion: v1
+  kind: Service
+  jsonPatch:
+  - op: replace
+    path: /spec/type
+    value: NodePort
+- kind: Widget
+  merge:
+    spec:
+      color: null
+      size: large
+sub:
+  overlays:
+  - kind: ConfigMap
+    merge:
+      data:
+        mode: prod
+`
+	out, err := render(t, testChart(), values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := out["top/templates/_ignored.yaml"]; ok {
+		t.Error("Expected partials to be skipped")
+	}
+	if notes := out["top/templates/NOTES.txt"]; notes != "{{ not a template }}" {
+		t.Errorf("Expected NOTES.txt to be passed through, got %q", notes)
+	}
+
+	d := docs(t, out["top/templates/deployment.yaml"])[0]
+	spec := d["spec"].(map[string]interface{})
+	if spec["replicas"] != float64(3) {
+		t.Errorf("Expected 3 replicas, got %v", spec["replicas"])
+	}
+	containers := spec["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})
+	if len(containers) != 2 {
+		t.Fatalf("Expected the containers to be merged by name, got %v", containers)
+	}
+	web := containers[0].(map[string]interface{})
+	if web["image"] != "nginx:1.13" || web["ports"] == nil {
+		t.Errorf("Expected the web container to be patched in place, got %v", web)
+	}
+
+	svc := docs(t, out["top/templates/service.yaml"])
+	if len(svc) != 3 {
+		t.Fatalf("Expected 3 documents in service.yaml, got %d", len(svc))
+	}
+	if typ := svc[0]["spec"].(map[string]interface{})["type"]; typ != "NodePort" {
+		t.Errorf("Expected the service type to be patched, got %v", typ)
+	}
+	widget := svc[2]["spec"].(map[string]interface{})
+	if _, ok := widget["color"]; ok || widget["size"] != "large" {
+		t.Errorf("Expected a JSON merge patch for an unknown kind, got %v", widget)
+	}
+
+	if cm := out["top/charts/sub/templates/config.yaml"]; !strings.Contains(cm, "mode: prod") {
+		t.Errorf("Expected the subchart overlay to be applied, got %q", cm)
+	}
+}
+
+func TestRenderUnchanged(t *testing.T) {
+	out, err := render(t, testChart(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := out["top/templates/deployment.yaml"]; d != deployment {
+		t.Errorf("Expected documents without overlays to be left as they are, got %q", d)
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	tests := []struct {
+		values, expect string
+	}{
+		{
+			"overlays:\n- kind: Ingress\n  merge: {spec: {}}",
+			"top: overlay 0 (Ingress) matched no resources",
+		},
+		{
+			"overlays:\n- kind: Service\n  merge: {}\n  jsonPatch: []",
+			"must set exactly one of merge and jsonPatch",
+		},
+		{
+			"overlays:\n- kind: Service\n  jsonPatch:\n  - op: remove\n    path: /spec/missing",
+			`"top/templates/service.yaml": overlay 0 (Service)`,
+		},
+		{
+			"overlays: 3",
+			"cannot parse overlays",
+		},
+		{
+			"sub:\n  overlays:\n  - name: nothing\n    merge: {}",
+			"top/charts/sub: overlay 0 (nothing) matched no resources",
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := render(t, testChart(), tt.values)
+		if err == nil {
+			t.Errorf("Expected an error for %q", tt.values)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("Expected error for %q to contain %q, got %q", tt.values, tt.expect, err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"sort"
+	"time"
+)
+
+// Profile holds the render statistics collected by Engine.RenderWithProfile.
+//
+// Durations are wall time and inclusive: the time recorded for a template
+// includes the time spent in every named template it includes. Strings
+// rendered through "tpl" are accounted to the template that called "tpl".
+type Profile struct {
+	// Templates has one entry per rendered template file, keyed by the
+	// namespaced file name (e.g. "mychart/templates/deployment.yaml").
+	Templates map[string]*ProfileEntry
+	// Defines has one entry per named template invoked through "include" or
+	// "template", keyed by the name given to "define".
+	Defines map[string]*ProfileEntry
+	// Total is the wall time of the whole render.
+	Total time.Duration
+}
+
+// ProfileEntry describes the cost of a single template or named template.
+type ProfileEntry struct {
+	Name     string
+	Calls    int
+	Duration time.Duration
+}
+
+func newProfile() *Profile {
+	return &Profile{
+		Templates: map[string]*ProfileEntry{},
+		Defines:   map[string]*ProfileEntry{},
+	}
+}
+
+// recordTemplate records one execution of the template file name that began at start.
+//
+// It is safe to call on a nil Profile.
+func (p *Profile) recordTemplate(name string, start time.Time) {
+	if p == nil {
+		return
+	}
+	record(p.Templates, name, start)
+}
+
+// recordDefine records one invocation of the named template name that began at start.
+//
+// It is safe to call on a nil Profile.
+func (p *Profile) recordDefine(name string, start time.Time) {
+	if p == nil {
+		return
+	}
+	record(p.Defines, name, start)
+}
+
+func record(entries map[string]*ProfileEntry, name string, start time.Time) {
+	e, ok := entries[name]
+	if !ok {
+		e = &ProfileEntry{Name: name}
+		entries[name] = e
+	}
+	e.Calls++
+	e.Duration += time.Since(start)
+}
+
+// SortedTemplates returns the template entries, slowest first.
+func (p *Profile) SortedTemplates() []*ProfileEntry {
+	return sortEntries(p.Templates)
+}
+
+// SortedDefines returns the named template entries, slowest first.
+func (p *Profile) SortedDefines() []*ProfileEntry {
+	return sortEntries(p.Defines)
+}
+
+func sortEntries(entries map[string]*ProfileEntry) []*ProfileEntry {
+	sorted := make([]*ProfileEntry, 0, len(entries))
+	for _, e := range entries {
+		sorted = append(sorted, e)
+	}
+	sort.Sort(byDuration(sorted))
+	return sorted
+}
+
+type byDuration []*ProfileEntry
+
+func (d byDuration) Len() int      { return len(d) }
+func (d byDuration) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+func (d byDuration) Less(i, j int) bool {
+	if d[i].Duration == d[j].Duration {
+		return d[i].Name < d[j].Name
+	}
+	return d[i].Duration > d[j].Duration
+}
@@ -22,10 +22,12 @@ import (
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
 )
 
@@ -275,6 +277,24 @@ func (h *Client) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.Get
 	return h.history(ctx, req)
 }
 
+// ReleaseDrift compares the resources of the deployed release with the cluster.
+func (h *Client) ReleaseDrift(rlsName string, opts ...DriftOption) (*rls.GetReleaseDriftResponse, error) {
+	for _, opt := range opts {
+		opt(&h.opts)
+	}
+
+	req := &h.opts.driftReq
+	req.Name = rlsName
+	ctx := NewContext()
+
+	if h.opts.before != nil {
+		if err := h.opts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.drift(ctx, req)
+}
+
 // RunReleaseTest executes a pre-defined test on a release.
 func (h *Client) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error) {
 	for _, opt := range opts {
@@ -333,6 +353,19 @@ func (h *Client) install(ctx context.Context, req *rls.InstallReleaseRequest) (*
 	defer c.Close()
 
 	rlc := rls.NewReleaseServiceClient(c)
+	if h.opts.progress != nil {
+		s, err := rlc.InstallReleaseWithProgress(ctx, req)
+		if err == nil {
+			var rel *release.Release
+			if rel, err = recvProgress(s, h.opts.progress); err == nil {
+				return &rls.InstallReleaseResponse{Release: rel}, nil
+			}
+		}
+		// Older Tillers cannot stream the progress.
+		if grpc.Code(err) != codes.Unimplemented {
+			return nil, err
+		}
+	}
 	return rlc.InstallRelease(ctx, req)
 }
 
@@ -357,6 +390,19 @@ func (h *Client) update(ctx context.Context, req *rls.UpdateReleaseRequest) (*rl
 	defer c.Close()
 
 	rlc := rls.NewReleaseServiceClient(c)
+	if h.opts.progress != nil {
+		s, err := rlc.UpdateReleaseWithProgress(ctx, req)
+		if err == nil {
+			var rel *release.Release
+			if rel, err = recvProgress(s, h.opts.progress); err == nil {
+				return &rls.UpdateReleaseResponse{Release: rel}, nil
+			}
+		}
+		// Older Tillers cannot stream the progress.
+		if grpc.Code(err) != codes.Unimplemented {
+			return nil, err
+		}
+	}
 	return rlc.UpdateRelease(ctx, req)
 }
 
@@ -369,6 +415,19 @@ func (h *Client) rollback(ctx context.Context, req *rls.RollbackReleaseRequest)
 	defer c.Close()
 
 	rlc := rls.NewReleaseServiceClient(c)
+	if h.opts.progress != nil {
+		s, err := rlc.RollbackReleaseWithProgress(ctx, req)
+		if err == nil {
+			var rel *release.Release
+			if rel, err = recvProgress(s, h.opts.progress); err == nil {
+				return &rls.RollbackReleaseResponse{Release: rel}, nil
+			}
+		}
+		// Older Tillers cannot stream the progress.
+		if grpc.Code(err) != codes.Unimplemented {
+			return nil, err
+		}
+	}
 	return rlc.RollbackRelease(ctx, req)
 }
 
@@ -420,6 +479,18 @@ func (h *Client) history(ctx context.Context, req *rls.GetHistoryRequest) (*rls.
 	return rlc.GetHistory(ctx, req)
 }
 
+// Executes tiller.GetReleaseDrift RPC.
+func (h *Client) drift(ctx context.Context, req *rls.GetReleaseDriftRequest) (*rls.GetReleaseDriftResponse, error) {
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	rlc := rls.NewReleaseServiceClient(c)
+	return rlc.GetReleaseDrift(ctx, req)
+}
+
 // Executes tiller.TestRelease RPC.
 func (h *Client) test(ctx context.Context, req *rls.TestReleaseRequest) (<-chan *rls.TestReleaseResponse, <-chan error) {
 	errc := make(chan error, 1)
@@ -457,3 +528,29 @@ func (h *Client) test(ctx context.Context, req *rls.TestReleaseRequest) (<-chan
 
 	return ch, errc
 }
+
+// progressReceiver is the client side of a release progress stream.
+type progressReceiver interface {
+	Recv() (*rls.ReleaseProgress, error)
+}
+
+// recvProgress passes the events of a progress stream to fn, and returns the
+// release the stream ends with.
+func recvProgress(s progressReceiver, fn func(*rls.ReleaseEvent)) (*release.Release, error) {
+	var rel *release.Release
+	for {
+		msg, err := s.Recv()
+		if err == io.EOF {
+			return rel, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if msg.Event != nil {
+			fn(msg.Event)
+		}
+		if msg.Release != nil {
+			rel = msg.Release
+		}
+	}
+}
@@ -30,6 +30,7 @@ import (
 type FakeClient struct {
 	Rels      []*release.Release
 	Responses map[string]release.TestRun_Status
+	Drifts    []*rls.ResourceDrift
 	Err       error
 }
 
@@ -116,6 +117,14 @@ func (c *FakeClient) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls
 	return &rls.GetHistoryResponse{Releases: c.Rels}, c.Err
 }
 
+// ReleaseDrift returns the drifts of the fake release client
+func (c *FakeClient) ReleaseDrift(rlsName string, opts ...DriftOption) (*rls.GetReleaseDriftResponse, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	return &rls.GetReleaseDriftResponse{Name: rlsName, Version: 1, Resources: c.Drifts}, nil
+}
+
 // RunReleaseTest executes a pre-defined tests on a release
 func (c *FakeClient) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error) {
 
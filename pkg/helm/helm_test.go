@@ -18,6 +18,7 @@ package helm // import "k8s.io/helm/pkg/helm"
 
 import (
 	"errors"
+	"io"
 	"path/filepath"
 	"reflect"
 	"testing"
@@ -103,6 +104,7 @@ func TestInstallRelease_VerifyOptions(t *testing.T) {
 	var chartName = "alpine"
 	var chartPath = filepath.Join(chartsDir, chartName)
 	var overrides = []byte("key1=value1,key2=value2")
+	var caps = &chartutil.Capabilities{APIVersions: chartutil.NewVersionSet("v1", "apps/v1beta1")}
 
 	// Expected InstallReleaseRequest message
 	exp := &tpb.InstallReleaseRequest{
@@ -113,6 +115,9 @@ func TestInstallRelease_VerifyOptions(t *testing.T) {
 		DisableHooks: disableHooks,
 		Namespace:    namespace,
 		ReuseName:    reuseName,
+		Capabilities: &tpb.Capabilities{ApiVersions: []string{"apps/v1beta1", "v1"}},
+		Adopt:        true,
+		Validate:     true,
 	}
 
 	// Options used in InstallRelease
@@ -122,6 +127,9 @@ func TestInstallRelease_VerifyOptions(t *testing.T) {
 		ReleaseName(releaseName),
 		InstallReuseName(reuseName),
 		InstallDisableHooks(disableHooks),
+		InstallCapabilities(caps),
+		InstallAdopt(true),
+		InstallValidate(true),
 	}
 
 	// BeforeCall option to intercept Helm client InstallReleaseRequest
@@ -142,6 +150,26 @@ func TestInstallRelease_VerifyOptions(t *testing.T) {
 }
 
 // Verify each DeleteOptions is applied to an UninstallReleaseRequest correctly.
+func TestCapabilitiesToProto(t *testing.T) {
+	kv, err := chartutil.ParseKubeVersion("1.8.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc := capabilitiesToProto(&chartutil.Capabilities{
+		APIVersions: chartutil.NewVersionSet("v1", "batch/v2alpha1"),
+		KubeVersion: kv,
+	})
+	if pc.KubeVersion != "v1.8.3" {
+		t.Errorf("Expected v1.8.3, got %s", pc.KubeVersion)
+	}
+	if len(pc.ApiVersions) != 2 || pc.ApiVersions[0] != "batch/v2alpha1" {
+		t.Errorf("Expected sorted API versions, got %v", pc.ApiVersions)
+	}
+	if capabilitiesToProto(nil) != nil {
+		t.Error("Expected no capabilities for a nil override")
+	}
+}
+
 func TestDeleteRelease_VerifyOptions(t *testing.T) {
 	// Options testdata
 	var releaseName = "test"
@@ -195,6 +223,8 @@ func TestUpdateRelease_VerifyOptions(t *testing.T) {
 		Values:       &cpb.Config{Raw: string(overrides)},
 		DryRun:       dryRun,
 		DisableHooks: disableHooks,
+		Adopt:        true,
+		Validate:     true,
 	}
 
 	// Options used in UpdateRelease
@@ -202,6 +232,8 @@ func TestUpdateRelease_VerifyOptions(t *testing.T) {
 		UpgradeDryRun(dryRun),
 		UpdateValueOverrides(overrides),
 		UpgradeDisableHooks(disableHooks),
+		UpgradeAdopt(true),
+		UpgradeValidate(true),
 	}
 
 	// BeforeCall option to intercept Helm client UpdateReleaseRequest
@@ -319,6 +351,78 @@ func TestReleaseContent_VerifyOptions(t *testing.T) {
 	}
 }
 
+// Verify the release name is applied to a GetReleaseDriftRequest correctly.
+func TestReleaseDrift_VerifyOptions(t *testing.T) {
+	// Options testdata
+	var releaseName = "test"
+
+	// Expected GetReleaseDriftRequest message
+	exp := &tpb.GetReleaseDriftRequest{
+		Name: releaseName,
+	}
+
+	// BeforeCall option to intercept Helm client GetReleaseDriftRequest
+	b4c := BeforeCall(func(_ context.Context, msg proto.Message) error {
+		switch act := msg.(type) {
+		case *tpb.GetReleaseDriftRequest:
+			t.Logf("GetReleaseDriftRequest: %#+v\n", act)
+			assert(t, exp, act)
+		default:
+			t.Fatalf("expected message of type GetReleaseDriftRequest, got %T\n", act)
+		}
+		return errSkip
+	})
+
+	if _, err := NewClient(b4c).ReleaseDrift(releaseName); err != errSkip {
+		t.Fatalf("did not expect error but got (%v)\n``", err)
+	}
+}
+
+type fakeProgressStream struct {
+	msgs []*tpb.ReleaseProgress
+	err  error
+}
+
+func (s *fakeProgressStream) Recv() (*tpb.ReleaseProgress, error) {
+	if len(s.msgs) == 0 {
+		return nil, s.err
+	}
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return msg, nil
+}
+
+// Verify the events of a progress stream are passed on before the release.
+func TestRecvProgress(t *testing.T) {
+	waiting := &tpb.ReleaseEvent{Phase: tpb.ReleaseEvent_RESOURCE_WAITING, Resource: "Deployment/web"}
+	ready := &tpb.ReleaseEvent{Phase: tpb.ReleaseEvent_RESOURCE_READY, Resource: "Deployment/web"}
+	s := &fakeProgressStream{
+		msgs: []*tpb.ReleaseProgress{
+			{Event: waiting},
+			{Event: ready},
+			{Release: &rls.Release{Name: "web"}},
+		},
+		err: io.EOF,
+	}
+
+	var events []*tpb.ReleaseEvent
+	rel, err := recvProgress(s, func(e *tpb.ReleaseEvent) {
+		events = append(events, e)
+	})
+	if err != nil {
+		t.Fatalf("did not expect error but got (%v)\n", err)
+	}
+	assert(t, []*tpb.ReleaseEvent{waiting, ready}, events)
+	if rel.GetName() != "web" {
+		t.Errorf("expected release web, got %v\n", rel)
+	}
+
+	s = &fakeProgressStream{msgs: []*tpb.ReleaseProgress{{Event: waiting}}, err: errors.New("timed out")}
+	if _, err := recvProgress(s, func(*tpb.ReleaseEvent) {}); err == nil || err.Error() != "timed out" {
+		t.Errorf("expected the stream error, got (%v)\n", err)
+	}
+}
+
 func assert(t *testing.T, expect, actual interface{}) {
 	if !reflect.DeepEqual(expect, actual) {
 		t.Fatalf("expected %#+v, actual %#+v\n", expect, actual)
@@ -23,6 +23,7 @@ import (
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/metadata"
 
+	"k8s.io/helm/pkg/chartutil"
 	cpb "k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
@@ -78,6 +79,10 @@ type options struct {
 	reuseValues bool
 	// release test options are applied directly to the test release history request
 	testReq rls.TestReleaseRequest
+	// if set, install, upgrade and rollback stream their progress to it
+	progress func(*rls.ReleaseEvent)
+	// release drift options are applied directly to the get release drift request
+	driftReq rls.GetReleaseDriftRequest
 }
 
 // Host specifies the host address of the Tiller release server, (default = ":44134").
@@ -237,6 +242,38 @@ func UpgradeWait(wait bool) UpdateOption {
 	}
 }
 
+// InstallAdopt specifies whether or not to take over resources of the chart
+// that already exist in the cluster
+func InstallAdopt(adopt bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Adopt = adopt
+	}
+}
+
+// UpgradeAdopt specifies whether or not to take over resources of the chart
+// that already exist in the cluster
+func UpgradeAdopt(adopt bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Adopt = adopt
+	}
+}
+
+// InstallValidate specifies whether or not to validate the resources with a
+// server-side dry run before installing them
+func InstallValidate(validate bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Validate = validate
+	}
+}
+
+// UpgradeValidate specifies whether or not to validate the resources with a
+// server-side dry run before upgrading them
+func UpgradeValidate(validate bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Validate = validate
+	}
+}
+
 // RollbackWait specifies whether or not to wait for all resources to be ready
 func RollbackWait(wait bool) RollbackOption {
 	return func(opts *options) {
@@ -244,6 +281,27 @@ func RollbackWait(wait bool) RollbackOption {
 	}
 }
 
+// InstallProgress specifies a function to receive the progress of the install
+func InstallProgress(fn func(*rls.ReleaseEvent)) InstallOption {
+	return func(opts *options) {
+		opts.progress = fn
+	}
+}
+
+// UpgradeProgress specifies a function to receive the progress of the upgrade
+func UpgradeProgress(fn func(*rls.ReleaseEvent)) UpdateOption {
+	return func(opts *options) {
+		opts.progress = fn
+	}
+}
+
+// RollbackProgress specifies a function to receive the progress of the rollback
+func RollbackProgress(fn func(*rls.ReleaseEvent)) RollbackOption {
+	return func(opts *options) {
+		opts.progress = fn
+	}
+}
+
 // UpdateValueOverrides specifies a list of values to include when upgrading
 func UpdateValueOverrides(raw []byte) UpdateOption {
 	return func(opts *options) {
@@ -279,6 +337,33 @@ func InstallDryRun(dry bool) InstallOption {
 	}
 }
 
+// InstallCapabilities overrides the cluster capabilities a dry-run
+// installation is rendered against.
+func InstallCapabilities(caps *chartutil.Capabilities) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Capabilities = capabilitiesToProto(caps)
+	}
+}
+
+// capabilitiesToProto converts a Capabilities override for an install or
+// upgrade request.
+func capabilitiesToProto(caps *chartutil.Capabilities) *rls.Capabilities {
+	if caps == nil {
+		return nil
+	}
+	pc := &rls.Capabilities{
+		ApiVersions:   caps.APIVersions.Versions(),
+		TillerVersion: caps.TillerVersion,
+	}
+	if kv := caps.KubeVersion; kv != nil {
+		pc.KubeVersion = kv.GitVersion
+		if pc.KubeVersion == "" {
+			pc.KubeVersion = kv.Major + "." + kv.Minor
+		}
+	}
+	return pc
+}
+
 // InstallDisableHooks disables hooks during installation.
 func InstallDisableHooks(disable bool) InstallOption {
 	return func(opts *options) {
@@ -356,6 +441,14 @@ func ReuseValues(reuse bool) UpdateOption {
 	}
 }
 
+// UpgradeCapabilities overrides the cluster capabilities a dry-run
+// upgrade is rendered against.
+func UpgradeCapabilities(caps *chartutil.Capabilities) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Capabilities = capabilitiesToProto(caps)
+	}
+}
+
 // UpgradeRecreate will (if true) recreate pods after upgrade.
 func UpgradeRecreate(recreate bool) UpdateOption {
 	return func(opts *options) {
@@ -401,6 +494,10 @@ type DeleteOption func(*options)
 // VersionOption -- TODO
 type VersionOption func(*options)
 
+// DriftOption allows setting optional attributes when
+// performing a GetReleaseDrift tiller rpc.
+type DriftOption func(*options)
+
 // UpdateOption allows specifying various settings
 // configurable by the helm client user for overriding
 // the defaults used when running the `helm upgrade` command.
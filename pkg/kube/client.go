@@ -26,7 +26,6 @@ import (
 	"strings"
 	"time"
 
-	jsonpatch "github.com/evanphx/json-patch"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,7 +38,6 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/kubernetes/pkg/api"
-	"k8s.io/kubernetes/pkg/api/helper"
 	"k8s.io/kubernetes/pkg/api/v1"
 	apps "k8s.io/kubernetes/pkg/apis/apps/v1beta1"
 	batchinternal "k8s.io/kubernetes/pkg/apis/batch"
@@ -62,6 +60,10 @@ type Client struct {
 	SchemaCacheDir string //schema文件
 
 	Log func(string, ...interface{})
+	// Progress, if set, is told how resources are applied and rolled out.
+	Progress ProgressFunc
+	// Owner, if set, is the release that the resources are applied for.
+	Owner *Owner
 }
 
 // New creates a new Client.
@@ -103,14 +105,13 @@ func (c *Client) Create(namespace string, reader io.Reader, timeout int64, shoul
 	c.Log("creating %d resource(s)", len(infos))
 	for _, v := range infos {
 		fmt.Println("source:", v.Source)
-		fmt.Println("object:", v.Object)
-		fmt.Println("versionObject:", v.VersionedObject)
 		fmt.Println("---------------")
 	}
 	//对所有的k8s资源执行构建
-	if err := perform(infos, createResource); err != nil {
+	if err := perform(infos, c.createOwnedResource); err != nil {
 		return err
 	}
+	c.report(ProgressEvent{Type: ResourcesApplied, Message: fmt.Sprintf("created %d resource(s)", len(infos))})
 	if shouldWait {
 		return c.waitForResources(time.Duration(timeout)*time.Second, infos)
 	}
@@ -236,6 +237,30 @@ func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 	return buf.String(), nil
 }
 
+// Existing returns the kind and name, as in "Deployment/web", of the
+// resources in reader that already exist in the cluster.
+//
+// Namespace will set the namespace.
+func (c *Client) Existing(namespace string, reader io.Reader) ([]string, error) {
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return nil, err
+	}
+	existing := []string{}
+	err = perform(infos, func(info *resource.Info) error {
+		helper := resource.NewHelper(info.Client, info.Mapping)
+		if _, err := helper.Get(info.Namespace, info.Name, info.Export); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("could not get information about %s: %s", resourceName(info), err)
+		}
+		existing = append(existing, resourceName(info))
+		return nil
+	})
+	return existing, err
+}
+
 // Update reads in the current configuration and a target configuration from io.reader
 // and creates resources that don't already exists, updates resources that have been modified
 // in the target configuration and deletes resources from the current configuration that are
@@ -262,8 +287,13 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 			return err
 		}
 
+		if err := c.setOwner(info); err != nil {
+			return err
+		}
+
 		helper := resource.NewHelper(info.Client, info.Mapping)
-		if _, err := helper.Get(info.Namespace, info.Name, info.Export); err != nil {
+		liveObj, err := helper.Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
 			if !errors.IsNotFound(err) {
 				return fmt.Errorf("Could not get information about the resource: %s", err)
 			}
@@ -278,12 +308,16 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 			return nil
 		}
 
+		if err := c.checkOwner(info, liveObj); err != nil {
+			return err
+		}
+
 		originalInfo := original.Get(info)
 		if originalInfo == nil {
 			return fmt.Errorf("no resource with the name %q found", info.Name)
 		}
 
-		if err := updateResource(c, info, originalInfo.Object, force, recreate); err != nil {
+		if err := updateResource(c, info, originalInfo.Object, liveObj, force, recreate); err != nil {
 			c.Log("error updating the resource %q:\n\t %v", info.Name, err)
 			updateErrors = append(updateErrors, err.Error())
 		}
@@ -298,14 +332,33 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 		return fmt.Errorf(strings.Join(updateErrors, " && "))
 	}
 
+	// Resources that another release owns are left alone, and reported once
+	// the update is otherwise done.
+	var foreign []string
 	for _, info := range original.Difference(target) {
 		c.Log("Deleting %q in %s...", info.Name, info.Namespace)
-		if err := deleteResource(c, info); err != nil {
+		err := c.skipIfNotFound(c.deleteOwnedResource(info))
+		switch {
+		case err == nil:
+		case isOwnerConflict(err):
+			c.Log("Not deleting %q: %s", info.Name, err)
+			foreign = append(foreign, err.Error())
+		default:
 			c.Log("Failed to delete %q, err: %s", info.Name, err)
 		}
 	}
+	message := fmt.Sprintf("updated %d resource(s)", len(target))
+	if len(foreign) > 0 {
+		message += fmt.Sprintf(", kept %d owned by other releases", len(foreign))
+	}
+	c.report(ProgressEvent{Type: ResourcesApplied, Message: message})
 	if shouldWait {
-		return c.waitForResources(time.Duration(timeout)*time.Second, target)
+		if err := c.waitForResources(time.Duration(timeout)*time.Second, target); err != nil {
+			return err
+		}
+	}
+	if len(foreign) > 0 {
+		return fmt.Errorf("kept resources removed from the release: %s", strings.Join(foreign, " && "))
 	}
 	return nil
 }
@@ -320,7 +373,7 @@ func (c *Client) Delete(namespace string, reader io.Reader) error {
 	}
 	return perform(infos, func(info *resource.Info) error {
 		c.Log("Starting delete for %q %s", info.Name, info.Mapping.GroupVersionKind.Kind)
-		err := deleteResource(c, info)
+		err := c.deleteOwnedResource(info)
 		return c.skipIfNotFound(err)
 	})
 }
@@ -383,6 +436,41 @@ func createResource(info *resource.Info) error {
 	return info.Refresh(obj, true)
 }
 
+// createOwnedResource creates a resource annotated with the release that owns
+// it. A resource that already exists is reported with its owner, if another
+// release owns it.
+func (c *Client) createOwnedResource(info *resource.Info) error {
+	if err := c.setOwner(info); err != nil {
+		return err
+	}
+	err := createResource(info)
+	if !errors.IsAlreadyExists(err) || c.Owner == nil {
+		return err
+	}
+	live, getErr := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, info.Export)
+	if getErr != nil {
+		return err
+	}
+	if ownerErr := c.checkOwner(info, live); ownerErr != nil {
+		return ownerErr
+	}
+	return err
+}
+
+// deleteOwnedResource deletes a resource unless another release owns it.
+func (c *Client) deleteOwnedResource(info *resource.Info) error {
+	if c.Owner != nil {
+		live, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
+			return err
+		}
+		if err := c.checkOwner(info, live); err != nil {
+			return err
+		}
+	}
+	return deleteResource(c, info)
+}
+
 //在删除release时调用
 func deleteResource(c *Client, info *resource.Info) error {
 	reaper, err := c.Reaper(info.Mapping)
@@ -397,37 +485,48 @@ func deleteResource(c *Client, info *resource.Info) error {
 	return reaper.Stop(info.Namespace, info.Name, 0, nil)
 }
 
-func createPatch(mapping *meta.RESTMapping, target, current runtime.Object) ([]byte, types.PatchType, error) {
-	oldData, err := json.Marshal(current)
+// createPatch creates a three-way patch from the live state of a resource to
+// its target configuration, given the configuration it was last applied with.
+//
+// Changes made to the live object outside of Helm are reverted where they
+// touch fields of the target configuration, and fields are only removed when
+// the target configuration no longer sets them.
+func createPatch(mapping *meta.RESTMapping, target, original, current runtime.Object) ([]byte, types.PatchType, error) {
+	oldData, err := json.Marshal(original)
 	if err != nil {
-		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing current configuration: %s", err)
+		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing original configuration: %s", err)
 	}
 	newData, err := json.Marshal(target)
 	if err != nil {
 		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing target configuration: %s", err)
 	}
-
-	if helper.Semantic.DeepEqual(oldData, newData) {
-		return nil, types.StrategicMergePatchType, nil
+	currentData, err := json.Marshal(current)
+	if err != nil {
+		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing live configuration: %s", err)
 	}
 
 	// Get a versioned object
 	versionedObject, err := api.Scheme.New(mapping.GroupVersionKind)
+	var patch []byte
+	patchType := types.StrategicMergePatchType
 	switch {
 	case runtime.IsNotRegisteredError(err):
 		// fall back to generic JSON merge patch
-		patch, err := jsonpatch.CreateMergePatch(oldData, newData)
-		return patch, types.MergePatchType, err
+		patchType = types.MergePatchType
+		patch, err = createThreeWayJSONMergePatch(oldData, newData, currentData)
 	case err != nil:
-		return nil, types.StrategicMergePatchType, fmt.Errorf("failed to get versionedObject: %s", err)
+		return nil, patchType, fmt.Errorf("failed to get versionedObject: %s", err)
 	default:
-		patch, err := strategicpatch.CreateTwoWayMergePatch(oldData, newData, versionedObject)
-		return patch, types.StrategicMergePatchType, err
+		patch, err = strategicpatch.CreateThreeWayMergePatch(oldData, newData, currentData, versionedObject, true)
+	}
+	if err != nil || string(patch) == "{}" {
+		return nil, patchType, err
 	}
+	return patch, patchType, nil
 }
 
-func updateResource(c *Client, target *resource.Info, currentObj runtime.Object, force bool, recreate bool) error {
-	patch, patchType, err := createPatch(target.Mapping, target.Object, currentObj)
+func updateResource(c *Client, target *resource.Info, originalObj, currentObj runtime.Object, force bool, recreate bool) error {
+	patch, patchType, err := createPatch(target.Mapping, target.Object, originalObj, currentObj)
 	if err != nil {
 		return fmt.Errorf("failed to create patch: %s", err)
 	}
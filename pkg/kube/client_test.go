@@ -23,6 +23,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -230,6 +231,139 @@ func TestUpdate(t *testing.T) {
 
 }
 
+func TestUpdateRevertsLiveChanges(t *testing.T) {
+	list := newPodList("starfish")
+	live := newPodList("starfish")
+	live.Items[0].Spec.Containers[0].Image = "abc/app:debug"
+
+	var actions []string
+
+	f, tf, codec, _ := cmdtesting.NewAPIFactory()
+	tf.UnstructuredClient = &fake.RESTClient{
+		APIRegistry:          api.Registry,
+		NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			actions = append(actions, p+":"+m)
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &live.Items[0])
+			case p == "/namespaces/default/pods/starfish" && m == "PATCH":
+				data, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Fatalf("could not dump request: %s", err)
+				}
+				req.Body.Close()
+				expected := `{"spec":{"$setElementOrder/containers":[{"name":"app:v4"}],"containers":[{"image":"abc/app:v4","name":"app:v4"}]}}`
+				if string(data) != expected {
+					t.Errorf("expected patch\n%s\ngot\n%s", expected, string(data))
+				}
+				return newResponse(200, &list.Items[0])
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	// The release does not change, but the live pod was changed by hand.
+	c := newTestClient(f)
+	if err := c.Update(api.NamespaceDefault, objBody(codec, &list), objBody(codec, &list), false, false, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	expectedActions := []string{
+		"/namespaces/default/pods/starfish:GET",
+		"/namespaces/default/pods/starfish:PATCH",
+	}
+	if !reflect.DeepEqual(expectedActions, actions) {
+		t.Errorf("expected requests %v, got %v", expectedActions, actions)
+	}
+}
+
+func TestUpdateRefusesResourcesOfOtherReleases(t *testing.T) {
+	list := newPodList("starfish")
+	live := newPodList("starfish")
+	live.Items[0].Annotations = map[string]string{
+		ReleaseNameAnnotation:      "smiling-pig",
+		ReleaseNamespaceAnnotation: "default",
+	}
+
+	var actions []string
+
+	f, tf, codec, _ := cmdtesting.NewAPIFactory()
+	tf.UnstructuredClient = &fake.RESTClient{
+		APIRegistry:          api.Registry,
+		NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			actions = append(actions, p+":"+m)
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &live.Items[0])
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	c := newTestClient(f).WithOwner("angry-panda", "default")
+	err := c.Update(api.NamespaceDefault, objBody(codec, &list), objBody(codec, &list), false, false, 0, false)
+	if err == nil || !strings.Contains(err.Error(), `owned by release "smiling-pig"`) {
+		t.Errorf("expected an error about the owner of starfish, got %v", err)
+	}
+	if expectedActions := []string{"/namespaces/default/pods/starfish:GET"}; !reflect.DeepEqual(expectedActions, actions) {
+		t.Errorf("expected requests %v, got %v", expectedActions, actions)
+	}
+}
+
+func TestUpdateKeepsRemovedResourcesOfOtherReleases(t *testing.T) {
+	original := newPodList("starfish", "squid", "octopus")
+	target := newPodList("starfish")
+	live := newPodList("starfish", "squid")
+	live.Items[1].Annotations = map[string]string{
+		ReleaseNameAnnotation:      "smiling-pig",
+		ReleaseNamespaceAnnotation: "default",
+	}
+
+	f, tf, codec, _ := cmdtesting.NewAPIFactory()
+	tf.UnstructuredClient = &fake.RESTClient{
+		APIRegistry:          api.Registry,
+		NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &live.Items[0])
+			case p == "/namespaces/default/pods/starfish" && m == "PATCH":
+				return newResponse(200, &target.Items[0])
+			case p == "/namespaces/default/pods/squid" && m == "GET":
+				return newResponse(200, &live.Items[1])
+			case p == "/namespaces/default/pods/octopus" && m == "GET":
+				return newResponse(404, notFoundBody())
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	reaper := &fakeReaper{}
+	rf := &fakeReaperFactory{Factory: f, reaper: reaper}
+	c := newTestClient(rf).WithOwner("angry-panda", "default")
+	err := c.Update(api.NamespaceDefault, objBody(codec, &original), objBody(codec, &target), false, false, 0, false)
+	if err == nil || !strings.Contains(err.Error(), `Pod/squid in namespace "default" is owned by release "smiling-pig"`) {
+		t.Errorf("expected the pod of the other release to be reported, got %v", err)
+	}
+	// The pod that is already gone is not an error.
+	if err != nil && strings.Contains(err.Error(), "octopus") {
+		t.Errorf("expected the missing pod to be skipped, got %v", err)
+	}
+	if reaper.name != "" {
+		t.Errorf("expected nothing to be deleted, got %q", reaper.name)
+	}
+}
+
 func TestBuild(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -349,6 +483,38 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestExisting(t *testing.T) {
+	list := newPodList("starfish", "otter")
+	f, tf, _, _ := cmdtesting.NewAPIFactory()
+	tf.UnstructuredClient = &fake.RESTClient{
+		APIRegistry:          api.Registry,
+		NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			t.Logf("got request %s %s", p, m)
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(404, notFoundBody())
+			case p == "/namespaces/default/pods/otter" && m == "GET":
+				return newResponse(200, &list.Items[1])
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+	c := newTestClient(f)
+
+	data := strings.NewReader("kind: Pod\napiVersion: v1\nmetadata:\n  name: starfish\n---\nkind: Pod\napiVersion: v1\nmetadata:\n  name: otter")
+	existing, err := c.Existing("default", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := []string{"Pod/otter"}; !reflect.DeepEqual(existing, expect) {
+		t.Errorf("Expected %v, got %v", expect, existing)
+	}
+}
+
 func TestPerform(t *testing.T) {
 	tests := []struct {
 		name        string
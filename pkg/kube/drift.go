@@ -0,0 +1,264 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+// ResourceDrift is a resource whose live state differs from its manifest.
+type ResourceDrift struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// Missing is set when the resource no longer exists.
+	Missing bool
+	// Fields are the fields that differ, sorted by path.
+	Fields []FieldDrift
+}
+
+// FieldDrift is a field whose live value differs from its manifest.
+type FieldDrift struct {
+	// Path is the path of the field, as in "spec.containers[0].image".
+	Path string
+	// Expected is the value in the manifest, as JSON.
+	Expected string
+	// Actual is the live value, as JSON, or empty if the field is not set.
+	Actual string
+}
+
+// ignoredFields are the top-level fields that are not compared, by kind.
+// The API server folds the stringData of Secrets into their data.
+var ignoredFields = map[string][]string{
+	"Secret": {"stringData"},
+}
+
+// quantityTables are the tables whose values are resource quantities, which
+// the API server rewrites to their canonical form, e.g. 0.5 to "500m".
+var quantityTables = map[string]bool{
+	"limits":               true,
+	"requests":             true,
+	"capacity":             true,
+	"hard":                 true,
+	"max":                  true,
+	"min":                  true,
+	"default":              true,
+	"defaultRequest":       true,
+	"maxLimitRequestRatio": true,
+}
+
+// quantityFields are the other fields that are resource quantities.
+var quantityFields = map[string]bool{
+	"sizeLimit": true,
+}
+
+// intOrStringFields are the fields that may be set as a number or as a
+// string, and that the API server may return either way.
+var intOrStringFields = map[string]bool{
+	"port":           true,
+	"targetPort":     true,
+	"servicePort":    true,
+	"maxSurge":       true,
+	"maxUnavailable": true,
+	"minAvailable":   true,
+}
+
+// Drift compares the resources in the reader with their live state, and
+// returns those that differ.
+//
+// Only the fields set in the manifest are compared, so fields populated by
+// the API server or by controllers, such as the status, are not reported.
+func (c *Client) Drift(namespace string, reader io.Reader) ([]ResourceDrift, error) {
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return nil, err
+	}
+	var drifts []ResourceDrift
+	for _, info := range infos {
+		kind := info.Mapping.GroupVersionKind.Kind
+		drift := ResourceDrift{Kind: kind, Namespace: info.Namespace, Name: info.Name}
+
+		live, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, info.Export)
+		if errors.IsNotFound(err) {
+			drift.Missing = true
+			drifts = append(drifts, drift)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not get %s %q: %s", kind, info.Name, err)
+		}
+
+		expected, err := objectFields(info.Object)
+		if err != nil {
+			return nil, err
+		}
+		actual, err := objectFields(live)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range ignoredFields[kind] {
+			delete(expected, f)
+		}
+		if drift.Fields = diffFields("", expected, actual); len(drift.Fields) > 0 {
+			drifts = append(drifts, drift)
+		}
+	}
+	return drifts, nil
+}
+
+func objectFields(obj runtime.Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+// diffFields compares the fields set in expected with the same fields in
+// actual. Fields that are only in actual are ignored.
+func diffFields(path string, expected, actual interface{}) []FieldDrift {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(e))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var drifts []FieldDrift
+		for _, k := range keys {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			av, ok := a[k]
+			if !ok {
+				// An unset field is as good as an empty one.
+				if !isEmpty(e[k]) {
+					drifts = append(drifts, FieldDrift{Path: p, Expected: toJSON(e[k])})
+				}
+				continue
+			}
+			drifts = append(drifts, diffFields(p, e[k], av)...)
+		}
+		return drifts
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(e) {
+			break
+		}
+		var drifts []FieldDrift
+		for i := range e {
+			drifts = append(drifts, diffFields(fmt.Sprintf("%s[%d]", path, i), e[i], a[i])...)
+		}
+		return drifts
+	case nil:
+		// A null in a manifest leaves the field unset.
+		return nil
+	default:
+		if reflect.DeepEqual(expected, actual) || equivalentValues(path, expected, actual) {
+			return nil
+		}
+	}
+	if isEmpty(expected) && isEmpty(actual) {
+		return nil
+	}
+	return []FieldDrift{{Path: path, Expected: toJSON(expected), Actual: toJSON(actual)}}
+}
+
+// equivalentValues reports whether two scalar values of the field at path
+// differ only in how they are written, as resource quantities or as
+// int-or-string values.
+func equivalentValues(path string, expected, actual interface{}) bool {
+	keys := strings.Split(path, ".")
+	key := trimIndex(keys[len(keys)-1])
+	var table string
+	if len(keys) > 1 {
+		table = trimIndex(keys[len(keys)-2])
+	}
+	switch {
+	case quantityTables[table] || quantityFields[key]:
+		e, ok := toQuantity(expected)
+		if !ok {
+			return false
+		}
+		a, ok := toQuantity(actual)
+		return ok && e.Cmp(a) == 0
+	case intOrStringFields[key]:
+		return fmt.Sprint(expected) == fmt.Sprint(actual)
+	}
+	return false
+}
+
+// trimIndex removes the list index from a path element, as in "ports[0]".
+func trimIndex(key string) string {
+	if i := strings.Index(key, "["); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
+// toQuantity parses a resource quantity written as a string or a number.
+func toQuantity(v interface{}) (apiresource.Quantity, bool) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return apiresource.Quantity{}, false
+	}
+	q, err := apiresource.ParseQuantity(s)
+	return q, err == nil
+}
+
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func toJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
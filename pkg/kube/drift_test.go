@@ -0,0 +1,95 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiffFields(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual string
+		drifts           []FieldDrift
+	}{
+		{
+			name:     "ignores server populated fields",
+			expected: `{"metadata":{"name":"web","creationTimestamp":null},"spec":{"replicas":2,"template":{}}}`,
+			actual:   `{"metadata":{"name":"web","uid":"1234","creationTimestamp":"2017-10-01T00:00:00Z"},"spec":{"replicas":2,"paused":false},"status":{"replicas":2}}`,
+		},
+		{
+			name:     "reports changed values",
+			expected: `{"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"web","image":"web:1"}]}}}}`,
+			actual:   `{"spec":{"replicas":0,"template":{"spec":{"containers":[{"name":"web","image":"web:2","imagePullPolicy":"Always"}]}}}}`,
+			drifts: []FieldDrift{
+				{Path: "spec.replicas", Expected: "2", Actual: "0"},
+				{Path: "spec.template.spec.containers[0].image", Expected: `"web:1"`, Actual: `"web:2"`},
+			},
+		},
+		{
+			name:     "reports removed fields",
+			expected: `{"metadata":{"labels":{"app":"web","tier":"front"}}}`,
+			actual:   `{"metadata":{"labels":{"app":"web"}}}`,
+			drifts: []FieldDrift{
+				{Path: "metadata.labels.tier", Expected: `"front"`},
+			},
+		},
+		{
+			name:     "ignores quantities and ports rewritten by the API server",
+			expected: `{"spec":{"containers":[{"resources":{"limits":{"cpu":0.5,"memory":"1024Mi"},"requests":{"cpu":"100m"}},"ports":[{"containerPort":80}]}],"volumes":[{"emptyDir":{"sizeLimit":"1024Mi"}}],"strategy":{"rollingUpdate":{"maxSurge":1}}}}`,
+			actual:   `{"spec":{"containers":[{"resources":{"limits":{"cpu":"500m","memory":"1Gi"},"requests":{"cpu":"100m"}},"ports":[{"containerPort":80}]}],"volumes":[{"emptyDir":{"sizeLimit":"1Gi"}}],"strategy":{"rollingUpdate":{"maxSurge":"1"}}}}`,
+		},
+		{
+			name:     "reports changed quantities",
+			expected: `{"spec":{"containers":[{"resources":{"limits":{"cpu":0.5}}}]}}`,
+			actual:   `{"spec":{"containers":[{"resources":{"limits":{"cpu":"1"}}}]}}`,
+			drifts: []FieldDrift{
+				{Path: "spec.containers[0].resources.limits.cpu", Expected: "0.5", Actual: `"1"`},
+			},
+		},
+		{
+			name:     "reports lists of another length as a whole",
+			expected: `{"spec":{"ports":[{"port":80}]}}`,
+			actual:   `{"spec":{"ports":[{"port":80},{"port":443}]}}`,
+			drifts: []FieldDrift{
+				{Path: "spec.ports", Expected: `[{"port":80}]`, Actual: `[{"port":80},{"port":443}]`},
+			},
+		},
+		{
+			name:     "reports values of another type",
+			expected: `{"data":{"config":{"debug":true}}}`,
+			actual:   `{"data":{"config":"debug"}}`,
+			drifts: []FieldDrift{
+				{Path: "data.config", Expected: `{"debug":true}`, Actual: `"debug"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		var expected, actual map[string]interface{}
+		if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(tt.actual), &actual); err != nil {
+			t.Fatal(err)
+		}
+		if drifts := diffFields("", expected, actual); !reflect.DeepEqual(drifts, tt.drifts) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.drifts, drifts)
+		}
+	}
+}
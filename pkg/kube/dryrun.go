@@ -0,0 +1,182 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	goerrors "errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/rest"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+// ErrServerDryRunUnsupported indicates that the cluster is too old to run
+// requests as dry runs.
+var ErrServerDryRunUnsupported = goerrors.New("validating resources with a server-side dry run requires Kubernetes 1.13 or later")
+
+// ResourceError is the error the API server returned for a resource.
+type ResourceError struct {
+	// Resource is the kind and name of the resource, as in "Deployment/web".
+	Resource string
+	Err      error
+}
+
+// ValidationError holds the errors of the resources that the API server
+// rejected, in the order of the manifest.
+type ValidationError []ResourceError
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, r := range e {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", r.Resource, r.Err))
+	}
+	return fmt.Sprintf("the API server rejected %d resource(s):\n%s", len(e), strings.Join(msgs, "\n"))
+}
+
+// ValidateOnServer submits the resources of a target configuration to the
+// API server as dry runs, and returns a ValidationError with the error of
+// every resource that it rejected.
+//
+// Resources that do not exist yet are created, and the others are patched
+// from the original configuration like Update does, so that admission
+// webhooks, quotas and immutable fields are checked without persisting any
+// change. Resources that exist but are not part of the original
+// configuration are rejected as already existing, so resources that are
+// adopted must be part of it. originalReader may be nil, for resources about
+// to be installed.
+//
+// Namespace will set the namespace.
+func (c *Client) ValidateOnServer(namespace string, originalReader, targetReader io.Reader) error {
+	client, err := c.ClientSet()
+	if err != nil {
+		return err
+	}
+	info, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return fmt.Errorf("could not get the Kubernetes version: %s", err)
+	}
+	if !supportsServerDryRun(info) {
+		return ErrServerDryRunUnsupported
+	}
+
+	var original Result
+	if originalReader != nil {
+		if original, err = c.BuildUnstructured(namespace, originalReader); err != nil {
+			return fmt.Errorf("failed decoding reader into objects: %s", err)
+		}
+	}
+	target, err := c.BuildUnstructured(namespace, targetReader)
+	if err != nil {
+		return fmt.Errorf("failed decoding reader into objects: %s", err)
+	}
+
+	c.Log("validating %d resource(s) with a server-side dry run", len(target))
+	return c.dryRunAll(namespace, original, target)
+}
+
+// dryRunAll creates or patches the target resources as dry runs, and returns
+// the errors of all the resources together.
+func (c *Client) dryRunAll(namespace string, original, target Result) error {
+	var verr ValidationError
+	for _, info := range target {
+		if err := c.dryRun(namespace, info, original.Get(info)); err != nil {
+			verr = append(verr, ResourceError{Resource: resourceName(info), Err: err})
+		}
+	}
+	if len(verr) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// dryRun creates or patches a resource as a dry run.
+func (c *Client) dryRun(namespace string, info, original *resource.Info) error {
+	if err := c.setOwner(info); err != nil {
+		return err
+	}
+
+	helper := resource.NewHelper(info.Client, info.Mapping)
+	live, err := helper.Get(info.Namespace, info.Name, info.Export)
+	if errors.IsNotFound(err) {
+		err = dryRunRequest(info.Client.Post(), helper, info.Namespace).Body(info.Object).Do().Error()
+		// The namespace of the release is only created with its resources.
+		if isNamespaceNotFound(err, namespace) {
+			return nil
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if err := c.checkOwner(info, live); err != nil {
+		return err
+	}
+	if original == nil {
+		return errors.NewAlreadyExists(schema.GroupResource{
+			Group:    info.Mapping.GroupVersionKind.Group,
+			Resource: info.Mapping.Resource,
+		}, info.Name)
+	}
+
+	patch, patchType, err := createPatch(info.Mapping, info.Object, original.Object, live)
+	if err != nil {
+		return fmt.Errorf("failed to create patch: %s", err)
+	}
+	if patch == nil {
+		return nil
+	}
+	return dryRunRequest(info.Client.Patch(patchType), helper, info.Namespace).Name(info.Name).Body(patch).Do().Error()
+}
+
+func dryRunRequest(r *rest.Request, helper *resource.Helper, namespace string) *rest.Request {
+	return r.NamespaceIfScoped(namespace, helper.NamespaceScoped).
+		Resource(helper.Resource).
+		Param("dryRun", "All")
+}
+
+func isNamespaceNotFound(err error, namespace string) bool {
+	status, ok := err.(errors.APIStatus)
+	if !ok || !errors.IsNotFound(err) {
+		return false
+	}
+	details := status.Status().Details
+	return details != nil && details.Kind == "namespaces" && details.Name == namespace
+}
+
+var versionNumber = regexp.MustCompile(`^\d+`)
+
+// supportsServerDryRun tells whether a cluster runs requests with the dryRun
+// parameter as dry runs. Older clusters ignore the parameter, and would apply
+// the changes.
+func supportsServerDryRun(info *version.Info) bool {
+	major, err := strconv.Atoi(versionNumber.FindString(info.Major))
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(versionNumber.FindString(info.Minor))
+	if err != nil {
+		return false
+	}
+	return major > 1 || (major == 1 && minor >= 13)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest/fake"
+	"k8s.io/kubernetes/pkg/api"
+	cmdtesting "k8s.io/kubernetes/pkg/kubectl/cmd/testing"
+)
+
+func TestDryRunAll(t *testing.T) {
+	list := newPodList("starfish", "otter", "dolphin")
+	live := newPodList("starfish", "otter")
+	live.Items[0].Spec.Containers[0].Image = "abc/app:debug"
+
+	var actions []string
+
+	f, tf, codec, _ := cmdtesting.NewAPIFactory()
+	tf.UnstructuredClient = &fake.RESTClient{
+		APIRegistry:          api.Registry,
+		NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			if m != "GET" {
+				if req.URL.Query().Get("dryRun") != "All" {
+					t.Errorf("expected %s %s to be a dry run, got query %q", m, p, req.URL.RawQuery)
+				}
+			}
+			actions = append(actions, p+":"+m)
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &live.Items[0])
+			case p == "/namespaces/default/pods/starfish" && m == "PATCH":
+				data, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Fatalf("could not dump request: %s", err)
+				}
+				req.Body.Close()
+				expected := `{"spec":{"$setElementOrder/containers":[{"name":"app:v4"}],"containers":[{"image":"abc/app:v4","name":"app:v4"}]}}`
+				if string(data) != expected {
+					t.Errorf("expected patch\n%s\ngot\n%s", expected, string(data))
+				}
+				return newResponse(200, &list.Items[0])
+			case p == "/namespaces/default/pods/otter" && m == "GET":
+				return newResponse(200, &live.Items[1])
+			case p == "/namespaces/default/pods/dolphin" && m == "GET":
+				return newResponse(404, notFoundBody())
+			case p == "/namespaces/default/pods" && m == "POST":
+				return newResponse(403, &metav1.Status{
+					Code:    http.StatusForbidden,
+					Status:  metav1.StatusFailure,
+					Reason:  metav1.StatusReasonForbidden,
+					Message: `pods "dolphin" is forbidden: exceeded quota: pods`,
+				})
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	c := newTestClient(f)
+	original, err := c.BuildUnstructured(api.NamespaceDefault, objBody(codec, &list))
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := c.BuildUnstructured(api.NamespaceDefault, objBody(codec, &list))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.dryRunAll(api.NamespaceDefault, original, target)
+	verr, ok := err.(ValidationError)
+	if !ok {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if len(verr) != 1 || verr[0].Resource != "Pod/dolphin" || !strings.Contains(verr[0].Err.Error(), "exceeded quota") {
+		t.Errorf("expected dolphin to be rejected for its quota, got %v", verr)
+	}
+
+	expectedActions := []string{
+		"/namespaces/default/pods/starfish:GET",
+		"/namespaces/default/pods/starfish:PATCH",
+		"/namespaces/default/pods/otter:GET",
+		"/namespaces/default/pods/dolphin:GET",
+		"/namespaces/default/pods:POST",
+	}
+	if !reflect.DeepEqual(expectedActions, actions) {
+		t.Errorf("expected requests %v, got %v", expectedActions, actions)
+	}
+}
+
+func TestDryRunAllExisting(t *testing.T) {
+	list := newPodList("starfish", "otter")
+
+	var actions []string
+
+	f, tf, codec, _ := cmdtesting.NewAPIFactory()
+	tf.UnstructuredClient = &fake.RESTClient{
+		APIRegistry:          api.Registry,
+		NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			actions = append(actions, p+":"+m)
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &list.Items[0])
+			case p == "/namespaces/default/pods/otter" && m == "GET":
+				return newResponse(200, &list.Items[1])
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	// Only otter is part of the original configuration, e.g. as adopted.
+	adopted := newPodList("otter")
+	c := newTestClient(f)
+	original, err := c.BuildUnstructured(api.NamespaceDefault, objBody(codec, &adopted))
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := c.BuildUnstructured(api.NamespaceDefault, objBody(codec, &list))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.dryRunAll(api.NamespaceDefault, original, target)
+	verr, ok := err.(ValidationError)
+	if !ok {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if len(verr) != 1 || verr[0].Resource != "Pod/starfish" || !apierrors.IsAlreadyExists(verr[0].Err) {
+		t.Errorf("expected starfish to already exist, got %v", verr)
+	}
+
+	expectedActions := []string{
+		"/namespaces/default/pods/starfish:GET",
+		"/namespaces/default/pods/otter:GET",
+	}
+	if !reflect.DeepEqual(expectedActions, actions) {
+		t.Errorf("expected requests %v, got %v", expectedActions, actions)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := ValidationError{
+		{Resource: "Deployment/web", Err: errors.New("spec.selector: field is immutable")},
+		{Resource: "Pod/dolphin", Err: errors.New("exceeded quota: pods")},
+	}
+	expected := "the API server rejected 2 resource(s):\nDeployment/web: spec.selector: field is immutable\nPod/dolphin: exceeded quota: pods"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestIsNamespaceNotFound(t *testing.T) {
+	tests := []struct {
+		err    error
+		expect bool
+	}{
+		{apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "spaced"), true},
+		{apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "other"), false},
+		{apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "spaced"), false},
+		{errors.New(`namespaces "spaced" not found`), false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := isNamespaceNotFound(tt.err, "spaced"); got != tt.expect {
+			t.Errorf("%v: expected %t, got %t", tt.err, tt.expect, got)
+		}
+	}
+}
+
+func TestSupportsServerDryRun(t *testing.T) {
+	tests := []struct {
+		major, minor string
+		expect       bool
+	}{
+		{"1", "7", false},
+		{"1", "12+", false},
+		{"1", "13", true},
+		{"1", "18+", true},
+		{"2", "0", true},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := supportsServerDryRun(&version.Info{Major: tt.major, Minor: tt.minor}); got != tt.expect {
+			t.Errorf("%s.%s: expected %t, got %t", tt.major, tt.minor, tt.expect, got)
+		}
+	}
+}
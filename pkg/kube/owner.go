@@ -0,0 +1,102 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+const (
+	// ReleaseNameAnnotation names the release that owns a resource.
+	ReleaseNameAnnotation = "meta.helm.sh/release-name"
+	// ReleaseNamespaceAnnotation is the namespace of the release that owns a
+	// resource.
+	ReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+)
+
+// Owner identifies the release that owns resources.
+type Owner struct {
+	Name      string
+	Namespace string
+}
+
+// WithOwner returns a copy of the client that applies resources on behalf
+// of a release.
+//
+// The client annotates the resources it creates and updates with the release,
+// and refuses to update or delete resources that another release owns.
+func (c *Client) WithOwner(name, namespace string) *Client {
+	cp := *c
+	cp.Owner = &Owner{Name: name, Namespace: namespace}
+	return &cp
+}
+
+// setOwner annotates a resource with the release that owns it.
+func (c *Client) setOwner(info *resource.Info) error {
+	if c.Owner == nil {
+		return nil
+	}
+	accessor, err := meta.Accessor(info.Object)
+	if err != nil {
+		return err
+	}
+	annotations := accessor.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ReleaseNameAnnotation] = c.Owner.Name
+	annotations[ReleaseNamespaceAnnotation] = c.Owner.Namespace
+	accessor.SetAnnotations(annotations)
+	return nil
+}
+
+// checkOwner returns an error if the live state of a resource shows that it
+// is owned by another release. Resources without an owner, such as those
+// created before releases were annotated, may be modified.
+func (c *Client) checkOwner(info *resource.Info, live runtime.Object) error {
+	if c.Owner == nil {
+		return nil
+	}
+	accessor, err := meta.Accessor(live)
+	if err != nil {
+		return err
+	}
+	annotations := accessor.GetAnnotations()
+	name, namespace := annotations[ReleaseNameAnnotation], annotations[ReleaseNamespaceAnnotation]
+	if name == "" || (name == c.Owner.Name && (namespace == "" || namespace == c.Owner.Namespace)) {
+		return nil
+	}
+	return &ownerConflictError{fmt.Sprintf("%s in namespace %q is owned by release %q in namespace %q, not by release %q", resourceName(info), info.Namespace, name, namespace, c.Owner.Name)}
+}
+
+// ownerConflictError is returned for a resource that another release owns.
+type ownerConflictError struct {
+	msg string
+}
+
+func (e *ownerConflictError) Error() string {
+	return e.msg
+}
+
+func isOwnerConflict(err error) bool {
+	_, ok := err.(*ownerConflictError)
+	return ok
+}
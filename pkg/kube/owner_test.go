@@ -0,0 +1,87 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+func newOwnedConfigMap(annotations map[string]interface{}) *unstructured.Unstructured {
+	metadata := map[string]interface{}{"name": "config", "namespace": "default"}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   metadata,
+	}}
+}
+
+func TestSetOwner(t *testing.T) {
+	c := (&Client{}).WithOwner("angry-panda", "default")
+	obj := newOwnedConfigMap(map[string]interface{}{"team": "web"})
+	if err := c.setOwner(&resource.Info{Name: "config", Namespace: "default", Object: obj}); err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string]string{
+		"team":                     "web",
+		ReleaseNameAnnotation:      "angry-panda",
+		ReleaseNamespaceAnnotation: "default",
+	}
+	if got := obj.GetAnnotations(); !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected annotations %v, got %v", expect, got)
+	}
+
+	obj = newOwnedConfigMap(nil)
+	if err := (&Client{}).setOwner(&resource.Info{Name: "config", Namespace: "default", Object: obj}); err != nil {
+		t.Fatal(err)
+	}
+	if got := obj.GetAnnotations(); len(got) != 0 {
+		t.Errorf("expected a client without owner not to annotate resources, got %v", got)
+	}
+}
+
+func TestCheckOwner(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]interface{}
+		err         bool
+	}{
+		{"without owner", nil, false},
+		{"owned by the release", map[string]interface{}{ReleaseNameAnnotation: "angry-panda", ReleaseNamespaceAnnotation: "default"}, false},
+		{"owned by the release before its namespace was recorded", map[string]interface{}{ReleaseNameAnnotation: "angry-panda"}, false},
+		{"owned by another release", map[string]interface{}{ReleaseNameAnnotation: "smiling-pig", ReleaseNamespaceAnnotation: "default"}, true},
+		{"owned by a release of another namespace", map[string]interface{}{ReleaseNameAnnotation: "angry-panda", ReleaseNamespaceAnnotation: "staging"}, true},
+	}
+
+	c := (&Client{}).WithOwner("angry-panda", "default")
+	for _, tt := range tests {
+		live := newOwnedConfigMap(tt.annotations)
+		info := &resource.Info{Name: "config", Namespace: "default", Object: newOwnedConfigMap(nil)}
+		if err := c.checkOwner(info, live); (err != nil) != tt.err {
+			t.Errorf("%s: expected error %t, got %v", tt.name, tt.err, err)
+		}
+		if err := (&Client{}).checkOwner(info, live); err != nil {
+			t.Errorf("%s: expected a client without owner to modify any resource, got %v", tt.name, err)
+		}
+	}
+}
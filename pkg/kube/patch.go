@@ -0,0 +1,82 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// createThreeWayJSONMergePatch creates a JSON merge patch (RFC 7386) that
+// brings the current state of an object to the modified configuration.
+//
+// Fields that differ between current and modified are set to their modified
+// value, so changes made to the object outside of Helm are reverted. Fields
+// are only removed when they were in the original configuration and are no
+// longer in the modified one, so fields that Helm never set, such as those
+// filled in by the API server or by controllers, are left alone.
+//
+// This is used for resources without a strategic merge patch schema, such
+// as custom resources. Lists are replaced as a whole, as with any JSON merge
+// patch.
+func createThreeWayJSONMergePatch(original, modified, current []byte) ([]byte, error) {
+	var o, m, c map[string]interface{}
+	for _, v := range []struct {
+		data []byte
+		into *map[string]interface{}
+	}{{original, &o}, {modified, &m}, {current, &c}} {
+		if len(v.data) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(v.data, v.into); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(threeWayMerge(o, m, c))
+}
+
+func threeWayMerge(original, modified, current map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for k, mv := range modified {
+		cv, ok := current[k]
+		if !ok {
+			patch[k] = mv
+			continue
+		}
+		mm, mok := mv.(map[string]interface{})
+		cm, cok := cv.(map[string]interface{})
+		if mok && cok {
+			om, _ := original[k].(map[string]interface{})
+			if sub := threeWayMerge(om, mm, cm); len(sub) > 0 {
+				patch[k] = sub
+			}
+			continue
+		}
+		if !reflect.DeepEqual(mv, cv) {
+			patch[k] = mv
+		}
+	}
+	for k := range original {
+		if _, ok := modified[k]; ok {
+			continue
+		}
+		if _, ok := current[k]; ok {
+			patch[k] = nil
+		}
+	}
+	return patch
+}
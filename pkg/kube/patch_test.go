@@ -0,0 +1,95 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"testing"
+)
+
+func TestCreateThreeWayJSONMergePatch(t *testing.T) {
+	tests := []struct {
+		name                        string
+		original, modified, current string
+		expect                      string
+	}{
+		{
+			name:     "no changes",
+			original: `{"spec":{"replicas":2}}`,
+			modified: `{"spec":{"replicas":2}}`,
+			current:  `{"spec":{"replicas":2},"status":{"ready":true}}`,
+			expect:   `{}`,
+		},
+		{
+			name:     "reverts a change made outside of helm",
+			original: `{"spec":{"replicas":2}}`,
+			modified: `{"spec":{"replicas":2}}`,
+			current:  `{"spec":{"replicas":0}}`,
+			expect:   `{"spec":{"replicas":2}}`,
+		},
+		{
+			name:     "applies a new value",
+			original: `{"spec":{"replicas":2,"size":"small"}}`,
+			modified: `{"spec":{"replicas":3,"size":"small"}}`,
+			current:  `{"spec":{"replicas":2,"size":"small"}}`,
+			expect:   `{"spec":{"replicas":3}}`,
+		},
+		{
+			name:     "removes a field removed from the chart",
+			original: `{"spec":{"replicas":2,"size":"small"}}`,
+			modified: `{"spec":{"replicas":2}}`,
+			current:  `{"spec":{"replicas":2,"size":"small"}}`,
+			expect:   `{"spec":{"size":null}}`,
+		},
+		{
+			name:     "keeps fields the chart never set",
+			original: `{"spec":{"replicas":2}}`,
+			modified: `{"spec":{"replicas":2}}`,
+			current:  `{"spec":{"replicas":2,"paused":false},"metadata":{"uid":"1234"}}`,
+			expect:   `{}`,
+		},
+		{
+			name:     "adds a field deleted outside of helm",
+			original: `{"spec":{"replicas":2,"size":"small"}}`,
+			modified: `{"spec":{"replicas":2,"size":"small"}}`,
+			current:  `{"spec":{"replicas":2}}`,
+			expect:   `{"spec":{"size":"small"}}`,
+		},
+		{
+			name:     "replaces lists",
+			original: `{"spec":{"ports":[80]}}`,
+			modified: `{"spec":{"ports":[80,443]}}`,
+			current:  `{"spec":{"ports":[80]}}`,
+			expect:   `{"spec":{"ports":[80,443]}}`,
+		},
+		{
+			name:     "without an original",
+			modified: `{"spec":{"replicas":2}}`,
+			current:  `{"spec":{"replicas":1,"size":"small"}}`,
+			expect:   `{"spec":{"replicas":2}}`,
+		},
+	}
+	for _, tt := range tests {
+		patch, err := createThreeWayJSONMergePatch([]byte(tt.original), []byte(tt.modified), []byte(tt.current))
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if string(patch) != tt.expect {
+			t.Errorf("%s: expected patch %s, got %s", tt.name, tt.expect, patch)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+// ProgressEventType is the kind of a ProgressEvent.
+type ProgressEventType int
+
+const (
+	// ResourcesApplied is reported once the resources of a manifest are
+	// created or updated.
+	ResourcesApplied ProgressEventType = iota
+	// ResourceWaiting is reported when a resource is not ready yet, and
+	// again whenever the reason changes.
+	ResourceWaiting
+	// ResourceReady is reported when a resource becomes ready.
+	ResourceReady
+)
+
+// ProgressEvent tells how far along applying a manifest is.
+type ProgressEvent struct {
+	Type ProgressEventType
+	// Resource is the kind and name of the resource, as in "Deployment/web".
+	// It is empty for ResourcesApplied.
+	Resource string
+	Message  string
+}
+
+// ProgressFunc receives progress events.
+type ProgressFunc func(ProgressEvent)
+
+// WithProgress returns a copy of the client that reports its progress to fn.
+func (c *Client) WithProgress(fn ProgressFunc) *Client {
+	cp := *c
+	cp.Progress = fn
+	return &cp
+}
+
+func (c *Client) report(e ProgressEvent) {
+	if c.Progress != nil {
+		c.Progress(e)
+	}
+}
+
+func resourceName(info *resource.Info) string {
+	kind := info.Object.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" && info.Mapping != nil {
+		kind = info.Mapping.GroupVersionKind.Kind
+	}
+	return kind + "/" + info.Name
+}
+
+// waitProgress reports the state of the resources being waited for, only
+// when it changes between polls.
+type waitProgress struct {
+	report func(ProgressEvent)
+	seen   map[string]string
+}
+
+func newWaitProgress(c *Client) *waitProgress {
+	return &waitProgress{report: c.report, seen: map[string]string{}}
+}
+
+func (p *waitProgress) update(info *resource.Info, ready bool, reason string) {
+	p.set(resourceName(info), ready, reason)
+}
+
+func (p *waitProgress) set(name string, ready bool, reason string) {
+	state := "waiting: " + reason
+	if ready {
+		state = "ready"
+	}
+	if last, ok := p.seen[name]; ok && last == state {
+		return
+	}
+	p.seen[name] = state
+	if ready {
+		p.report(ProgressEvent{Type: ResourceReady, Resource: name})
+		return
+	}
+	p.report(ProgressEvent{Type: ResourceWaiting, Resource: name, Message: reason})
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWaitProgressReportsChanges(t *testing.T) {
+	var events []ProgressEvent
+	c := (&Client{}).WithProgress(func(e ProgressEvent) {
+		events = append(events, e)
+	})
+	p := newWaitProgress(c)
+
+	p.set("Deployment/web", false, "0 of 2 new pods ready")
+	p.set("Deployment/web", false, "0 of 2 new pods ready")
+	p.set("Deployment/web", false, "1 of 2 new pods ready")
+	p.set("Service/web", true, "")
+	p.set("Deployment/web", true, "")
+	p.set("Deployment/web", true, "")
+
+	expect := []ProgressEvent{
+		{Type: ResourceWaiting, Resource: "Deployment/web", Message: "0 of 2 new pods ready"},
+		{Type: ResourceWaiting, Resource: "Deployment/web", Message: "1 of 2 new pods ready"},
+		{Type: ResourceReady, Resource: "Service/web"},
+		{Type: ResourceReady, Resource: "Deployment/web"},
+	}
+	if !reflect.DeepEqual(events, expect) {
+		t.Errorf("expected events %v, got %v", expect, events)
+	}
+}
+
+func TestReportWithoutProgress(t *testing.T) {
+	// A client without a progress func must not panic.
+	(&Client{}).report(ProgressEvent{Type: ResourcesApplied})
+}
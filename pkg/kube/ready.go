@@ -0,0 +1,164 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ReadyConditionAnno is the annotation that names the status conditions a
+// resource must have to be ready when waiting, e.g. "Available" or
+// "Synced=True,Degraded=False".
+const ReadyConditionAnno = "helm.sh/ready-condition"
+
+// ReadyCondition is a status condition that a resource must have to be ready.
+type ReadyCondition struct {
+	Type   string
+	Status string
+}
+
+func (c ReadyCondition) String() string {
+	return c.Type + "=" + c.Status
+}
+
+// defaultReadyCondition is the condition that resources without the
+// ReadyConditionAnno annotation must meet, if they report it at all.
+var defaultReadyCondition = ReadyCondition{Type: "Ready", Status: "True"}
+
+// ParseReadyConditions parses the value of the ReadyConditionAnno annotation:
+// a comma-separated list of condition types, each optionally followed by '='
+// and the status the condition must have, which defaults to "True".
+func ParseReadyConditions(value string) ([]ReadyCondition, error) {
+	var conds []ReadyCondition
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		cond := ReadyCondition{Type: part, Status: "True"}
+		if i := strings.Index(part, "="); i >= 0 {
+			cond.Type, cond.Status = strings.TrimSpace(part[:i]), strings.TrimSpace(part[i+1:])
+		}
+		if cond.Type == "" || cond.Status == "" {
+			return nil, fmt.Errorf("invalid %s %q: expected TYPE or TYPE=STATUS", ReadyConditionAnno, part)
+		}
+		conds = append(conds, cond)
+	}
+	if len(conds) == 0 {
+		return nil, fmt.Errorf("%s names no conditions", ReadyConditionAnno)
+	}
+	return conds, nil
+}
+
+// IsReady tells whether an object, in its unstructured form as read from the
+// API server, is ready, and if not, why.
+//
+// An object is not ready while its status was observed for an older
+// generation of it. The conditions that its ReadyConditionAnno annotation
+// names must then all have their expected status, for the current generation
+// when the condition tells which generation it observed. Without the
+// annotation, an object is not ready until its controller reports a status
+// with either a Ready condition, which must be True, or the generation it
+// observed.
+func IsReady(obj map[string]interface{}) (bool, string, error) {
+	meta, _ := obj["metadata"].(map[string]interface{})
+	status, _ := obj["status"].(map[string]interface{})
+	generation, hasGeneration := toInt64(meta["generation"])
+
+	observed, hasObserved := toInt64(status["observedGeneration"])
+	if hasObserved && hasGeneration && observed < generation {
+		return false, fmt.Sprintf("status observed for generation %d, not %d", observed, generation), nil
+	}
+
+	conds := []ReadyCondition{defaultReadyCondition}
+	required := false
+	if annotations, ok := meta["annotations"].(map[string]interface{}); ok {
+		if value, ok := annotations[ReadyConditionAnno].(string); ok {
+			var err error
+			if conds, err = ParseReadyConditions(value); err != nil {
+				return false, "", err
+			}
+			required = true
+		}
+	}
+
+	reported := statusConditions(status)
+	for _, want := range conds {
+		got, ok := reported[want.Type]
+		if !ok {
+			if required {
+				return false, fmt.Sprintf("condition %s is not reported", want.Type), nil
+			}
+			// A resource that has not been reconciled yet has no status.
+			if status == nil {
+				return false, "status is not reported", nil
+			}
+			if !hasObserved {
+				return false, fmt.Sprintf("neither condition %s nor the observed generation is reported", want.Type), nil
+			}
+			continue
+		}
+		if observed, ok := toInt64(got["observedGeneration"]); ok && hasGeneration && observed < generation {
+			return false, fmt.Sprintf("condition %s observed for generation %d, not %d", want.Type, observed, generation), nil
+		}
+		if s, _ := got["status"].(string); s != want.Status {
+			reason := fmt.Sprintf("condition %s is %s, expected %s", want.Type, s, want.Status)
+			if msg, _ := got["message"].(string); msg != "" {
+				reason += ": " + msg
+			}
+			return false, reason, nil
+		}
+	}
+	return true, "", nil
+}
+
+// statusConditions returns the conditions of a status by type.
+func statusConditions(status map[string]interface{}) map[string]map[string]interface{} {
+	conds := map[string]map[string]interface{}{}
+	list, _ := status["conditions"].([]interface{})
+	for _, item := range list {
+		cond, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, ok := cond["type"].(string); ok {
+			conds[t] = cond
+		}
+	}
+	return conds
+}
+
+// toInt64 reads an integer of an unstructured object, whichever way it was
+// decoded.
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseReadyConditions(t *testing.T) {
+	conds, err := ParseReadyConditions("Synced, Degraded=False")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []ReadyCondition{{Type: "Synced", Status: "True"}, {Type: "Degraded", Status: "False"}}
+	if !reflect.DeepEqual(conds, expect) {
+		t.Errorf("expected %v, got %v", expect, conds)
+	}
+
+	for _, value := range []string{"", " , ", "=True", "Ready="} {
+		if _, err := ParseReadyConditions(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
+func TestIsReady(t *testing.T) {
+	tests := []struct {
+		name   string
+		obj    string
+		ready  bool
+		reason string
+		err    bool
+	}{
+		{
+			name:   "no status",
+			obj:    `{"metadata": {"generation": 1}}`,
+			reason: "status is not reported",
+		},
+		{
+			name:  "status of the current generation without conditions",
+			obj:   `{"metadata": {"generation": 1}, "status": {"observedGeneration": 1}}`,
+			ready: true,
+		},
+		{
+			name:  "ready",
+			obj:   `{"metadata": {"generation": 2}, "status": {"observedGeneration": 2, "conditions": [{"type": "Ready", "status": "True"}]}}`,
+			ready: true,
+		},
+		{
+			name:   "not ready",
+			obj:    `{"metadata": {"generation": 2}, "status": {"conditions": [{"type": "Ready", "status": "False", "message": "waiting for the database"}]}}`,
+			reason: "condition Ready is False, expected True: waiting for the database",
+		},
+		{
+			name:   "status of an older generation",
+			obj:    `{"metadata": {"generation": 3}, "status": {"observedGeneration": 2, "conditions": [{"type": "Ready", "status": "True"}]}}`,
+			reason: "status observed for generation 2, not 3",
+		},
+		{
+			name:   "condition of an older generation",
+			obj:    `{"metadata": {"generation": 3}, "status": {"conditions": [{"type": "Ready", "status": "True", "observedGeneration": 2}]}}`,
+			reason: "condition Ready observed for generation 2, not 3",
+		},
+		{
+			name:  "other conditions are ignored by default",
+			obj:   `{"metadata": {"generation": 1}, "status": {"observedGeneration": 1, "conditions": [{"type": "Degraded", "status": "True"}]}}`,
+			ready: true,
+		},
+		{
+			name:   "other conditions without an observed generation",
+			obj:    `{"metadata": {"generation": 1}, "status": {"conditions": [{"type": "Degraded", "status": "True"}]}}`,
+			reason: "neither condition Ready nor the observed generation is reported",
+		},
+		{
+			name:  "annotated conditions met",
+			obj:   `{"metadata": {"generation": 1, "annotations": {"helm.sh/ready-condition": "Synced,Degraded=False"}}, "status": {"conditions": [{"type": "Synced", "status": "True"}, {"type": "Degraded", "status": "False"}, {"type": "Ready", "status": "False"}]}}`,
+			ready: true,
+		},
+		{
+			name:   "annotated condition not reported",
+			obj:    `{"metadata": {"generation": 1, "annotations": {"helm.sh/ready-condition": "Synced"}}, "status": {}}`,
+			reason: "condition Synced is not reported",
+		},
+		{
+			name:   "annotated condition not met",
+			obj:    `{"metadata": {"generation": 1, "annotations": {"helm.sh/ready-condition": "Degraded=False"}}, "status": {"conditions": [{"type": "Degraded", "status": "True"}]}}`,
+			reason: "condition Degraded is True, expected False",
+		},
+		{
+			name: "invalid annotation",
+			obj:  `{"metadata": {"annotations": {"helm.sh/ready-condition": "=True"}}}`,
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(tt.obj), &obj); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		ready, reason, err := IsReady(obj)
+		if (err != nil) != tt.err {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.err, err)
+			continue
+		}
+		if ready != tt.ready || reason != tt.reason {
+			t.Errorf("%s: expected %v %q, got %v %q", tt.name, tt.ready, tt.reason, ready, reason)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"io"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+)
+
+// ResourceStatus is the state of a resource in the cluster.
+type ResourceStatus struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	// Ready is set when the resource is ready, as waiting for it expects.
+	Ready bool
+	// Message tells why the resource is not ready, or why it could not be
+	// read.
+	Message string
+	// Age is the time since the resource was created.
+	Age time.Duration
+	// Missing is set when the resource does not exist.
+	Missing bool
+}
+
+// Status returns the state of the resources in the reader, in their order.
+//
+// Resources that cannot be read are reported with the reason, rather than
+// failing the whole status.
+func (c *Client) Status(namespace string, reader io.Reader) ([]ResourceStatus, error) {
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var client clientset.Interface
+	statuses := make([]ResourceStatus, 0, len(infos))
+	for _, info := range infos {
+		gvk := info.Mapping.GroupVersionKind
+		status := ResourceStatus{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  info.Namespace,
+			Name:       info.Name,
+		}
+
+		live, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
+			c.Log("WARNING: Failed Get for resource %q: %s", info.Name, err)
+			if status.Missing = errors.IsNotFound(err); status.Missing {
+				status.Message = "not found"
+			} else {
+				status.Message = err.Error()
+			}
+			statuses = append(statuses, status)
+			continue
+		}
+		if accessor, err := meta.Accessor(live); err == nil {
+			if created := accessor.GetCreationTimestamp(); !created.IsZero() {
+				status.Age = now.Sub(created.Time)
+			}
+		}
+
+		if client == nil {
+			cs, err := c.ClientSet()
+			if err != nil {
+				return nil, err
+			}
+			client = versionedClientsetForDeployment(cs)
+		}
+		status.Ready, status.Message, err = c.resourceReady(client, info)
+		if err != nil {
+			status.Message = err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
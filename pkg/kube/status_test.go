@@ -0,0 +1,73 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest/fake"
+	"k8s.io/kubernetes/pkg/api"
+	cmdtesting "k8s.io/kubernetes/pkg/kubectl/cmd/testing"
+)
+
+func TestStatusOfMissingResources(t *testing.T) {
+	f, tf, _, _ := cmdtesting.NewAPIFactory()
+	tf.UnstructuredClient = &fake.RESTClient{
+		APIRegistry:          api.Registry,
+		NegotiatedSerializer: dynamic.ContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			t.Logf("got request %s %s", p, m)
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(404, notFoundBody())
+			case p == "/namespaces/default/services/otter" && m == "GET":
+				return newResponse(403, &metav1.Status{
+					Code:    http.StatusForbidden,
+					Status:  metav1.StatusFailure,
+					Reason:  metav1.StatusReasonForbidden,
+					Message: `services "otter" is forbidden: User "ci" cannot get services in the namespace "default"`,
+				})
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+	c := newTestClient(f)
+
+	data := strings.NewReader("kind: Pod\napiVersion: v1\nmetadata:\n  name: starfish\n---\nkind: Service\napiVersion: v1\nmetadata:\n  name: otter")
+	statuses, err := c.Status("default", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("Expected 2 statuses, got %d", len(statuses))
+	}
+	expect := ResourceStatus{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "starfish", Message: "not found", Missing: true}
+	if !reflect.DeepEqual(statuses[0], expect) {
+		t.Errorf("Expected %+v, got %+v", expect, statuses[0])
+	}
+	if s := statuses[1]; s.Kind != "Service" || s.Name != "otter" || s.Ready || s.Missing || !strings.Contains(s.Message, "forbidden") {
+		t.Errorf("Expected otter to be unreadable, got %+v", s)
+	}
+}
@@ -17,23 +17,32 @@ limitations under the License.
 package kube // import "k8s.io/helm/pkg/kube"
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/kubernetes/pkg/api/v1"
 	"k8s.io/kubernetes/pkg/api/v1/helper"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	apps "k8s.io/kubernetes/pkg/apis/apps/v1beta1"
+	batch "k8s.io/kubernetes/pkg/apis/batch/v1"
 	extensions "k8s.io/kubernetes/pkg/apis/extensions/v1beta1"
 	"k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+	appsclient "k8s.io/kubernetes/pkg/client/clientset_generated/clientset/typed/apps/v1beta1"
+	batchclient "k8s.io/kubernetes/pkg/client/clientset_generated/clientset/typed/batch/v1"
 	core "k8s.io/kubernetes/pkg/client/clientset_generated/clientset/typed/core/v1"
 	extensionsclient "k8s.io/kubernetes/pkg/client/clientset_generated/clientset/typed/extensions/v1beta1"
 	internalclientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
 	deploymentutil "k8s.io/kubernetes/pkg/controller/deployment/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
 )
 
 // deployment holds associated replicaSets for a deployment
@@ -42,8 +51,13 @@ type deployment struct {
 	deployment  *extensions.Deployment
 }
 
-// waitForResources polls to get the current status of all pods, PVCs, and Services
-// until all are ready or a timeout is reached
+// waitForResources polls to get the current status of all pods, PVCs, Services,
+// workloads and Jobs until all are ready or a timeout is reached.
+//
+// Deployments, StatefulSets and DaemonSets are ready once their new version
+// is rolled out, and Jobs once they are complete. A failed Job ends the wait
+// with an error. Custom resources, and resources with the ReadyConditionAnno
+// annotation, are ready once their status conditions say so (see IsReady).
 func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 	c.Log("beginning wait for %d resources with timeout of %v", len(created), timeout)
 
@@ -52,126 +66,324 @@ func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 		return err
 	}
 	client := versionedClientsetForDeployment(cs)
+	progress := newWaitProgress(c)
 	return wait.Poll(2*time.Second, timeout, func() (bool, error) {
-		pods := []v1.Pod{}
-		services := []v1.Service{}
-		pvc := []v1.PersistentVolumeClaim{}
-		deployments := []deployment{}
+		isReady := true
 		for _, v := range created {
-			obj, err := c.AsVersionedObject(v.Object)
-			if err != nil && !runtime.IsNotRegisteredError(err) {
+			ready, reason, err := c.resourceReady(client, v)
+			if err != nil {
 				return false, err
 			}
-			switch value := obj.(type) {
-			case (*v1.ReplicationController):
-				list, err := getPods(client, value.Namespace, value.Spec.Selector)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case (*v1.Pod):
-				pod, err := client.Core().Pods(value.Namespace).Get(value.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, *pod)
-			case (*extensions.Deployment):
-				currentDeployment, err := client.Extensions().Deployments(value.Namespace).Get(value.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				// Find RS associated with deployment
-				newReplicaSet, err := deploymentutil.GetNewReplicaSet(currentDeployment, client)
-				if err != nil || newReplicaSet == nil {
-					return false, err
-				}
-				newDeployment := deployment{
-					newReplicaSet,
-					currentDeployment,
-				}
-				deployments = append(deployments, newDeployment)
-			case (*extensions.DaemonSet):
-				list, err := getPods(client, value.Namespace, value.Spec.Selector.MatchLabels)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case (*apps.StatefulSet):
-				list, err := getPods(client, value.Namespace, value.Spec.Selector.MatchLabels)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case (*extensions.ReplicaSet):
-				list, err := getPods(client, value.Namespace, value.Spec.Selector.MatchLabels)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case (*v1.PersistentVolumeClaim):
-				claim, err := client.Core().PersistentVolumeClaims(value.Namespace).Get(value.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				pvc = append(pvc, *claim)
-			case (*v1.Service):
-				svc, err := client.Core().Services(value.Namespace).Get(value.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				services = append(services, *svc)
-			}
+			progress.update(v, ready, reason)
+			isReady = isReady && ready
 		}
-		isReady := podsReady(pods) && servicesReady(services) && volumesReady(pvc) && deploymentsReady(deployments)
 		c.Log("resources ready: %v", isReady)
 		return isReady, nil
 	})
 }
 
-func podsReady(pods []v1.Pod) bool {
+// resourceReady tells whether the current version of a resource is ready,
+// and if not, why.
+func (c *Client) resourceReady(client clientset.Interface, info *resource.Info) (bool, string, error) {
+	obj, err := c.asWaitableObject(info.Object)
+	if err != nil && !runtime.IsNotRegisteredError(err) {
+		return false, "", err
+	}
+	if obj == nil || hasReadyCondition(info.Object) {
+		return c.customResourceReady(info)
+	}
+	switch value := obj.(type) {
+	case (*v1.ReplicationController):
+		list, err := getPods(client, value.Namespace, value.Spec.Selector)
+		if err != nil {
+			return false, "", err
+		}
+		ready, reason := podsReady(list)
+		return ready, reason, nil
+	case (*v1.Pod):
+		pod, err := client.Core().Pods(value.Namespace).Get(value.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		ready, reason := podsReady([]v1.Pod{*pod})
+		return ready, reason, nil
+	case (*extensions.Deployment):
+		return deploymentReadyByName(client, value.Namespace, value.Name)
+	case (*apps.Deployment):
+		// Deployments of every version are served as extensions/v1beta1
+		// too.
+		return deploymentReadyByName(client, value.Namespace, value.Name)
+	case (*extensions.DaemonSet):
+		ds, err := client.Extensions().DaemonSets(value.Namespace).Get(value.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		ready, reason := daemonSetReady(ds)
+		return ready, reason, nil
+	case (*apps.StatefulSet):
+		sts, err := client.Apps().StatefulSets(value.Namespace).Get(value.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		ready, reason := statefulSetReady(sts)
+		return ready, reason, nil
+	case (*batch.Job):
+		job, err := client.Batch().Jobs(value.Namespace).Get(value.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		return jobComplete(job)
+	case (*extensions.ReplicaSet):
+		list, err := getPods(client, value.Namespace, value.Spec.Selector.MatchLabels)
+		if err != nil {
+			return false, "", err
+		}
+		ready, reason := podsReady(list)
+		return ready, reason, nil
+	case (*v1.PersistentVolumeClaim):
+		claim, err := client.Core().PersistentVolumeClaims(value.Namespace).Get(value.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		ready, reason := volumeReady(claim)
+		return ready, reason, nil
+	case (*v1.Service):
+		svc, err := client.Core().Services(value.Namespace).Get(value.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		ready, reason := serviceReady(svc)
+		return ready, reason, nil
+	}
+	return true, "", nil
+}
+
+// asWaitableObject converts a runtime.object to a versioned object, like
+// AsVersionedObject.
+//
+// The apps/v1 and apps/v1beta2 workloads are not registered with this client,
+// so they are read as their apps/v1beta1 and extensions/v1beta1 equivalents,
+// which have the same fields as far as readiness goes.
+func (c *Client) asWaitableObject(obj runtime.Object) (runtime.Object, error) {
+	versioned, err := c.AsVersionedObject(obj)
+	if !runtime.IsNotRegisteredError(err) {
+		return versioned, err
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, err
+	}
+	gvk := u.GroupVersionKind()
+	if gvk.Group != "apps" || (gvk.Version != "v1" && gvk.Version != "v1beta2") {
+		return nil, err
+	}
+	var out runtime.Object
+	switch gvk.Kind {
+	case "Deployment":
+		out = &extensions.Deployment{}
+	case "DaemonSet":
+		out = &extensions.DaemonSet{}
+	case "ReplicaSet":
+		out = &extensions.ReplicaSet{}
+	case "StatefulSet":
+		out = &apps.StatefulSet{}
+	default:
+		return nil, err
+	}
+	data, err := u.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("cannot read %s %s: %s", gvk.Kind, u.GetName(), err)
+	}
+	return out, nil
+}
+
+// hasReadyCondition tells whether an object names its own ready conditions.
+func hasReadyCondition(obj runtime.Object) bool {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	_, ok := accessor.GetAnnotations()[ReadyConditionAnno]
+	return ok
+}
+
+// customResourceReady checks the status conditions of the current version
+// of a resource.
+func (c *Client) customResourceReady(info *resource.Info) (bool, string, error) {
+	obj, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, info.Export)
+	if err != nil {
+		return false, "", err
+	}
+	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, obj)
+	if err != nil {
+		return false, "", err
+	}
+	var u map[string]interface{}
+	if err := json.Unmarshal(data, &u); err != nil {
+		return false, "", err
+	}
+	ready, reason, err := IsReady(u)
+	if err != nil {
+		return false, "", fmt.Errorf("%s: %s", resourceName(info), err)
+	}
+	return ready, reason, nil
+}
+
+// deploymentReadyByName gets a deployment along with its new replica set,
+// and tells whether it is ready.
+func deploymentReadyByName(client clientset.Interface, namespace, name string) (bool, string, error) {
+	currentDeployment, err := client.Extensions().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return false, "", err
+	}
+	// Find RS associated with deployment
+	newReplicaSet, err := deploymentutil.GetNewReplicaSet(currentDeployment, client)
+	if err != nil {
+		return false, "", err
+	}
+	if newReplicaSet == nil {
+		return false, "waiting for the new replica set to be created", nil
+	}
+	ready, reason := deploymentReady(deployment{newReplicaSet, currentDeployment})
+	return ready, reason, nil
+}
+
+func podsReady(pods []v1.Pod) (bool, string) {
 	for _, pod := range pods {
 		if !podutil.IsPodReady(&pod) {
-			return false
+			return false, fmt.Sprintf("pod %s is not ready", pod.Name)
 		}
 	}
-	return true
+	return true, ""
 }
 
-func servicesReady(svc []v1.Service) bool {
-	for _, s := range svc {
-		// ExternalName Services are external to cluster so helm shouldn't be checking to see if they're 'ready' (i.e. have an IP Set)
-		if s.Spec.Type == v1.ServiceTypeExternalName {
-			continue
-		}
+func serviceReady(s *v1.Service) (bool, string) {
+	// ExternalName Services are external to cluster so helm shouldn't be checking to see if they're 'ready' (i.e. have an IP Set)
+	if s.Spec.Type == v1.ServiceTypeExternalName {
+		return true, ""
+	}
 
-		// Make sure the service is not explicitly set to "None" before checking the IP
-		if s.Spec.ClusterIP != v1.ClusterIPNone && !helper.IsServiceIPSet(&s) {
-			return false
-		}
-		// This checks if the service has a LoadBalancer and that balancer has an Ingress defined
-		if s.Spec.Type == v1.ServiceTypeLoadBalancer && s.Status.LoadBalancer.Ingress == nil {
-			return false
-		}
+	// Make sure the service is not explicitly set to "None" before checking the IP
+	if s.Spec.ClusterIP != v1.ClusterIPNone && !helper.IsServiceIPSet(s) {
+		return false, "waiting for a cluster IP"
+	}
+	// This checks if the service has a LoadBalancer and that balancer has an Ingress defined
+	if s.Spec.Type == v1.ServiceTypeLoadBalancer && s.Status.LoadBalancer.Ingress == nil {
+		return false, "waiting for the load balancer"
+	}
+	return true, ""
+}
+
+func volumeReady(v *v1.PersistentVolumeClaim) (bool, string) {
+	if v.Status.Phase != v1.ClaimBound {
+		return false, fmt.Sprintf("claim is %s, not %s", v.Status.Phase, v1.ClaimBound)
+	}
+	return true, ""
+}
+
+func deploymentReady(v deployment) (bool, string) {
+	// The new replica set is only known to be the one of the latest spec
+	// once the controller has seen it.
+	if v.deployment.Status.ObservedGeneration < v.deployment.Generation {
+		return false, "waiting for the rollout to be observed"
+	}
+	expected := *v.deployment.Spec.Replicas - deploymentutil.MaxUnavailable(*v.deployment)
+	if !(v.replicaSets.Status.ReadyReplicas >= expected) {
+		return false, fmt.Sprintf("%d of %d new pods ready", v.replicaSets.Status.ReadyReplicas, expected)
+	}
+	return true, ""
+}
+
+func statefulSetReady(sts *apps.StatefulSet) (bool, string) {
+	if sts.Status.ObservedGeneration == nil || *sts.Status.ObservedGeneration < sts.Generation {
+		return false, "waiting for the rollout to be observed"
+	}
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	if sts.Status.ReadyReplicas < replicas {
+		return false, fmt.Sprintf("%d of %d pods ready", sts.Status.ReadyReplicas, replicas)
+	}
+	// With the OnDelete strategy, pods are only updated as they are deleted,
+	// so there is no rollout to wait for.
+	if sts.Spec.UpdateStrategy.Type != apps.RollingUpdateStatefulSetStrategyType {
+		return true, ""
+	}
+	// A partitioned update only updates the pods with an ordinal of at least
+	// the partition, and leaves the current revision as it is.
+	var partition int32
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		partition = *ru.Partition
 	}
-	return true
+	if partition > replicas {
+		partition = replicas
+	}
+	if sts.Status.UpdatedReplicas < replicas-partition {
+		return false, fmt.Sprintf("%d of %d pods updated", sts.Status.UpdatedReplicas, replicas-partition)
+	}
+	if partition == 0 && sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+		return false, fmt.Sprintf("waiting for revision %s to become current", sts.Status.UpdateRevision)
+	}
+	return true, ""
 }
 
-func volumesReady(vols []v1.PersistentVolumeClaim) bool {
-	for _, v := range vols {
-		if v.Status.Phase != v1.ClaimBound {
-			return false
+func daemonSetReady(ds *extensions.DaemonSet) (bool, string) {
+	if ds.Status.ObservedGeneration < ds.Generation {
+		return false, "waiting for the rollout to be observed"
+	}
+	desired := ds.Status.DesiredNumberScheduled
+	// With the OnDelete strategy, pods are only updated as they are deleted,
+	// so all the current pods only need to be ready.
+	if ds.Spec.UpdateStrategy.Type != extensions.RollingUpdateDaemonSetStrategyType {
+		if ds.Status.NumberReady < desired {
+			return false, fmt.Sprintf("%d of %d pods ready", ds.Status.NumberReady, desired)
 		}
+		return true, ""
+	}
+	if ds.Status.UpdatedNumberScheduled < desired {
+		return false, fmt.Sprintf("%d of %d pods updated", ds.Status.UpdatedNumberScheduled, desired)
 	}
-	return true
+	maxUnavailable := intstr.FromInt(1)
+	if ru := ds.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.MaxUnavailable != nil {
+		maxUnavailable = *ru.MaxUnavailable
+	}
+	unavailable, err := intstr.GetValueFromIntOrPercent(&maxUnavailable, int(desired), true)
+	if err != nil {
+		return false, fmt.Sprintf("invalid maxUnavailable: %s", err)
+	}
+	if int(ds.Status.NumberUnavailable) > unavailable {
+		return false, fmt.Sprintf("%d pods unavailable, at most %d allowed", ds.Status.NumberUnavailable, unavailable)
+	}
+	return true, ""
 }
 
-func deploymentsReady(deployments []deployment) bool {
-	for _, v := range deployments {
-		if !(v.replicaSets.Status.ReadyReplicas >= *v.deployment.Spec.Replicas-deploymentutil.MaxUnavailable(*v.deployment)) {
-			return false
+// jobComplete tells whether a job is complete, and returns an error if it
+// failed.
+func jobComplete(job *batch.Job) (bool, string, error) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != v1.ConditionTrue {
+			continue
 		}
+		switch c.Type {
+		case batch.JobComplete:
+			return true, "", nil
+		case batch.JobFailed:
+			return false, "", fmt.Errorf("job %s failed: %s", job.Name, c.Reason)
+		}
+	}
+	return false, fmt.Sprintf("%d of %d completions", job.Status.Succeeded, completions(job)), nil
+}
+
+// completions returns the number of successful pods a job needs.
+func completions(job *batch.Job) int32 {
+	if job.Spec.Completions != nil {
+		return *job.Spec.Completions
 	}
-	return true
+	return 1
 }
 
 func getPods(client clientset.Interface, namespace string, selector map[string]string) ([]v1.Pod, error) {
@@ -189,5 +401,7 @@ func versionedClientsetForDeployment(internalClient internalclientset.Interface)
 	return &clientset.Clientset{
 		CoreV1Client:            core.New(internalClient.Core().RESTClient()),
 		ExtensionsV1beta1Client: extensionsclient.New(internalClient.Extensions().RESTClient()),
+		AppsV1beta1Client:       appsclient.New(internalClient.Apps().RESTClient()),
+		BatchV1Client:           batchclient.New(internalClient.Batch().RESTClient()),
 	}
 }
@@ -0,0 +1,253 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"encoding/json"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/kubernetes/pkg/api/v1"
+	apps "k8s.io/kubernetes/pkg/apis/apps/v1beta1"
+	batch "k8s.io/kubernetes/pkg/apis/batch/v1"
+	extensions "k8s.io/kubernetes/pkg/apis/extensions/v1beta1"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+func int64Ptr(i int64) *int64 { return &i }
+
+func newStatefulSet(replicas, partition int32, status apps.StatefulSetStatus) apps.StatefulSet {
+	sts := apps.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Generation: 2},
+		Spec: apps.StatefulSetSpec{
+			Replicas: int32Ptr(replicas),
+			UpdateStrategy: apps.StatefulSetUpdateStrategy{
+				Type: apps.RollingUpdateStatefulSetStrategyType,
+			},
+		},
+		Status: status,
+	}
+	if partition > 0 {
+		sts.Spec.UpdateStrategy.RollingUpdate = &apps.RollingUpdateStatefulSetStrategy{Partition: int32Ptr(partition)}
+	}
+	return sts
+}
+
+func TestStatefulSetReady(t *testing.T) {
+	tests := []struct {
+		name   string
+		sts    apps.StatefulSet
+		expect bool
+	}{
+		{
+			name: "rolled out",
+			sts: newStatefulSet(3, 0, apps.StatefulSetStatus{
+				ObservedGeneration: int64Ptr(2), ReadyReplicas: 3, UpdatedReplicas: 3,
+				CurrentRevision: "db-2", UpdateRevision: "db-2",
+			}),
+			expect: true,
+		},
+		{
+			name: "update not observed yet",
+			sts: newStatefulSet(3, 0, apps.StatefulSetStatus{
+				ObservedGeneration: int64Ptr(1), ReadyReplicas: 3, UpdatedReplicas: 3,
+				CurrentRevision: "db-1", UpdateRevision: "db-1",
+			}),
+		},
+		{
+			name: "old pods still ready",
+			sts: newStatefulSet(3, 0, apps.StatefulSetStatus{
+				ObservedGeneration: int64Ptr(2), ReadyReplicas: 3, UpdatedReplicas: 1,
+				CurrentRevision: "db-1", UpdateRevision: "db-2",
+			}),
+		},
+		{
+			name: "all pods updated but revision not switched",
+			sts: newStatefulSet(3, 0, apps.StatefulSetStatus{
+				ObservedGeneration: int64Ptr(2), ReadyReplicas: 3, UpdatedReplicas: 3,
+				CurrentRevision: "db-1", UpdateRevision: "db-2",
+			}),
+		},
+		{
+			name: "partitioned update rolled out",
+			sts: newStatefulSet(3, 2, apps.StatefulSetStatus{
+				ObservedGeneration: int64Ptr(2), ReadyReplicas: 3, UpdatedReplicas: 1,
+				CurrentRevision: "db-1", UpdateRevision: "db-2",
+			}),
+			expect: true,
+		},
+		{
+			name: "partitioned update in progress",
+			sts: newStatefulSet(3, 2, apps.StatefulSetStatus{
+				ObservedGeneration: int64Ptr(2), ReadyReplicas: 2, UpdatedReplicas: 0,
+				CurrentRevision: "db-1", UpdateRevision: "db-2",
+			}),
+		},
+		{
+			name: "on delete only needs ready pods",
+			sts: func() apps.StatefulSet {
+				sts := newStatefulSet(3, 0, apps.StatefulSetStatus{
+					ObservedGeneration: int64Ptr(2), ReadyReplicas: 3,
+					CurrentRevision: "db-1", UpdateRevision: "db-2",
+				})
+				sts.Spec.UpdateStrategy.Type = apps.OnDeleteStatefulSetStrategyType
+				return sts
+			}(),
+			expect: true,
+		},
+	}
+	for _, tt := range tests {
+		if got, _ := statefulSetReady(&tt.sts); got != tt.expect {
+			t.Errorf("%s: expected ready %v, got %v", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func newDaemonSet(maxUnavailable intstr.IntOrString, status extensions.DaemonSetStatus) extensions.DaemonSet {
+	return extensions.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Generation: 2},
+		Spec: extensions.DaemonSetSpec{
+			UpdateStrategy: extensions.DaemonSetUpdateStrategy{
+				Type:          extensions.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &extensions.RollingUpdateDaemonSet{MaxUnavailable: &maxUnavailable},
+			},
+		},
+		Status: status,
+	}
+}
+
+func TestDaemonSetReady(t *testing.T) {
+	tests := []struct {
+		name   string
+		ds     extensions.DaemonSet
+		expect bool
+	}{
+		{
+			name: "rolled out",
+			ds: newDaemonSet(intstr.FromInt(1), extensions.DaemonSetStatus{
+				ObservedGeneration: 2, DesiredNumberScheduled: 4, UpdatedNumberScheduled: 4, NumberReady: 4,
+			}),
+			expect: true,
+		},
+		{
+			name: "within max unavailable",
+			ds: newDaemonSet(intstr.FromString("50%"), extensions.DaemonSetStatus{
+				ObservedGeneration: 2, DesiredNumberScheduled: 4, UpdatedNumberScheduled: 4, NumberReady: 2, NumberUnavailable: 2,
+			}),
+			expect: true,
+		},
+		{
+			name: "too many unavailable",
+			ds: newDaemonSet(intstr.FromInt(1), extensions.DaemonSetStatus{
+				ObservedGeneration: 2, DesiredNumberScheduled: 4, UpdatedNumberScheduled: 4, NumberReady: 2, NumberUnavailable: 2,
+			}),
+		},
+		{
+			name: "old pods not replaced",
+			ds: newDaemonSet(intstr.FromInt(1), extensions.DaemonSetStatus{
+				ObservedGeneration: 2, DesiredNumberScheduled: 4, UpdatedNumberScheduled: 1, NumberReady: 4,
+			}),
+		},
+		{
+			name: "update not observed yet",
+			ds: newDaemonSet(intstr.FromInt(1), extensions.DaemonSetStatus{
+				ObservedGeneration: 1, DesiredNumberScheduled: 4, UpdatedNumberScheduled: 4, NumberReady: 4,
+			}),
+		},
+		{
+			name: "on delete only needs ready pods",
+			ds: func() extensions.DaemonSet {
+				ds := newDaemonSet(intstr.FromInt(1), extensions.DaemonSetStatus{
+					ObservedGeneration: 2, DesiredNumberScheduled: 4, NumberReady: 4,
+				})
+				ds.Spec.UpdateStrategy = extensions.DaemonSetUpdateStrategy{Type: extensions.OnDeleteDaemonSetStrategyType}
+				return ds
+			}(),
+			expect: true,
+		},
+	}
+	for _, tt := range tests {
+		if got, _ := daemonSetReady(&tt.ds); got != tt.expect {
+			t.Errorf("%s: expected ready %v, got %v", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestDeploymentReadyWaitsForObservedGeneration(t *testing.T) {
+	d := deployment{
+		replicaSets: &extensions.ReplicaSet{Status: extensions.ReplicaSetStatus{ReadyReplicas: 2}},
+		deployment: &extensions.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Generation: 3},
+			Spec: extensions.DeploymentSpec{
+				Replicas: int32Ptr(2),
+				Strategy: extensions.DeploymentStrategy{Type: extensions.RecreateDeploymentStrategyType},
+			},
+			Status: extensions.DeploymentStatus{ObservedGeneration: 2},
+		},
+	}
+	if ready, reason := deploymentReady(d); ready || reason != "waiting for the rollout to be observed" {
+		t.Errorf("expected a deployment whose update is not observed to not be ready, got %v, %q", ready, reason)
+	}
+	d.deployment.Status.ObservedGeneration = 3
+	if ready, _ := deploymentReady(d); !ready {
+		t.Error("expected a rolled out deployment to be ready")
+	}
+}
+
+func TestJobComplete(t *testing.T) {
+	job := func(conditions ...batch.JobCondition) *batch.Job {
+		return &batch.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate"},
+			Status:     batch.JobStatus{Conditions: conditions},
+		}
+	}
+	complete := batch.JobCondition{Type: batch.JobComplete, Status: v1.ConditionTrue}
+	failed := batch.JobCondition{Type: batch.JobFailed, Status: v1.ConditionTrue, Reason: "BackoffLimitExceeded"}
+
+	if done, _, err := jobComplete(job(complete)); !done || err != nil {
+		t.Errorf("expected a complete job to be done, got %v, %v", done, err)
+	}
+	if done, reason, err := jobComplete(job()); done || reason != "0 of 1 completions" || err != nil {
+		t.Errorf("expected a running job not to be done, got %v, %q, %v", done, reason, err)
+	}
+	if _, _, err := jobComplete(job(failed)); err == nil || err.Error() != "job migrate failed: BackoffLimitExceeded" {
+		t.Errorf("expected a failed job to be reported, got %v", err)
+	}
+}
+
+func TestCustomResourceWaitsForStatus(t *testing.T) {
+	// A custom resource as it is created, before its controller reconciles it.
+	const created = `{
+		"apiVersion": "stable.example.com/v1",
+		"kind": "CronTab",
+		"metadata": {"name": "nightly", "generation": 1},
+		"spec": {"cronSpec": "0 0 * * *"}
+	}`
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(created), &obj); err != nil {
+		t.Fatal(err)
+	}
+	if ready, reason, err := IsReady(obj); ready || reason != "status is not reported" || err != nil {
+		t.Errorf("expected a custom resource without a status not to be ready, got %v, %q, %v", ready, reason, err)
+	}
+
+	obj["status"] = map[string]interface{}{"observedGeneration": float64(1)}
+	if ready, _, err := IsReady(obj); !ready || err != nil {
+		t.Errorf("expected a reconciled custom resource to be ready, got %v, %v", ready, err)
+	}
+}
@@ -19,18 +19,28 @@ package lint // import "k8s.io/helm/pkg/lint"
 import (
 	"path/filepath"
 
+	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/lint/rules"
 	"k8s.io/helm/pkg/lint/support"
 )
 
 // All runs all of the available linters on the given base directory.
 func All(basedir string) support.Linter {
+	linter, _ := AllWithEngine(basedir, engine.New(), false)
+	return linter
+}
+
+// AllWithEngine runs all of the available linters on the given base directory
+// like All, rendering the templates with the given engine. If profile is true,
+// the profile of rendering the chart's templates is also returned.
+func AllWithEngine(basedir string, e *engine.Engine, profile bool) (support.Linter, *engine.Profile) {
 	// Using abs path to get directory context
 	chartDir, _ := filepath.Abs(basedir)
 
 	linter := support.Linter{ChartDir: chartDir}
 	rules.Chartfile(&linter)
 	rules.Values(&linter)
-	rules.Templates(&linter)
-	return linter
+	rules.Environments(&linter)
+	prof := rules.TemplatesWithEngine(&linter, e, profile)
+	return linter, prof
 }
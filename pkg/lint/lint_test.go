@@ -28,6 +28,8 @@ const badChartDir = "rules/testdata/badchartfile"
 const badValuesFileDir = "rules/testdata/badvaluesfile"
 const badYamlFileDir = "rules/testdata/albatross"
 const goodChartDir = "rules/testdata/goodone"
+const badSchemaDir = "rules/testdata/badschema"
+const badEnvironmentsDir = "rules/testdata/badenvironments"
 
 func TestBadChart(t *testing.T) {
 	m := All(badChartDir).Messages
@@ -85,9 +87,31 @@ func TestBadValues(t *testing.T) {
 	}
 }
 
+func TestBadSchema(t *testing.T) {
+	m := All(badSchemaDir).Messages
+	if len(m) != 1 {
+		t.Fatalf("All didn't fail with expected errors, got %#v", m)
+	}
+	if !strings.Contains(m[0].Err.Error(), "- replicas: Invalid type. Expected: integer, given: string") {
+		t.Errorf("All didn't have the error for the schema violation: %s", m[0].Err)
+	}
+}
+
 func TestGoodChart(t *testing.T) {
 	m := All(goodChartDir).Messages
 	if len(m) != 0 {
 		t.Errorf("All failed but shouldn't have: %#v", m)
 	}
 }
+
+func TestBadEnvironments(t *testing.T) {
+	m := All(badEnvironmentsDir).Messages
+	if len(m) != 3 {
+		t.Fatalf("All didn't fail with expected errors, got %#v", m)
+	}
+	for _, msg := range m {
+		if !strings.HasPrefix(msg.Path, "environments/") {
+			t.Errorf("All reported an error outside of the environments: %s", msg)
+		}
+	}
+}
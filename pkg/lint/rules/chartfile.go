@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/Masterminds/semver"
@@ -131,6 +132,7 @@ func validateChartEngine(cf *chart.Metadata) error {
 
 		keys = append(keys, str)
 	}
+	sort.Strings(keys)
 
 	return fmt.Errorf("engine '%v' not valid. Valid options are %v", cf.Engine, keys)
 }
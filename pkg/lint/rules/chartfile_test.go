@@ -121,7 +121,7 @@ func TestValidateChartVersion(t *testing.T) {
 }
 
 func TestValidateChartEngine(t *testing.T) {
-	var successTest = []string{"", "gotpl"}
+	var successTest = []string{"", "gotpl", "overlay"}
 
 	for _, engine := range successTest {
 		badChart.Engine = engine
@@ -133,7 +133,7 @@ func TestValidateChartEngine(t *testing.T) {
 
 	badChart.Engine = "foobar"
 	err := validateChartEngine(badChart)
-	if err == nil || !strings.Contains(err.Error(), "not valid. Valid options are [gotpl") {
+	if err == nil || !strings.Contains(err.Error(), "not valid. Valid options are [gotpl overlay]") {
 		t.Errorf("validateChartEngine(%s) to return an error, got no error", badChart.Engine)
 	}
 }
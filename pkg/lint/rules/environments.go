@@ -0,0 +1,57 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+	"path"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// Environments lints the environments of a chart: each one must inherit from
+// an existing environment without cycles, have a valid values file and yield
+// values valid against the chart's schemas.
+func Environments(linter *support.Linter) {
+	c, err := chartutil.Load(linter.ChartDir)
+	if err != nil {
+		// Charts that fail to load are reported by the other rules.
+		return
+	}
+	for _, name := range chartutil.Environments(c) {
+		file := path.Join(chartutil.EnvironmentsDir, name)
+		linter.RunLinterRule(support.ErrorSev, file, validateEnvironment(c, name))
+	}
+}
+
+func validateEnvironment(c *chart.Chart, name string) error {
+	// Applying an environment replaces the chart's default values, so they
+	// are restored for the next environment.
+	defaults := c.Values
+	defer func() { c.Values = defaults }()
+
+	if err := chartutil.ApplyEnvironment(c, name); err != nil {
+		return err
+	}
+	vals, err := chartutil.CoalesceValues(c, c.Values)
+	if err != nil {
+		return fmt.Errorf("unable to coalesce values\n\t%s", err)
+	}
+	return chartutil.ValidateAgainstSchema(c, vals)
+}
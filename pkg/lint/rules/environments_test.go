@@ -0,0 +1,55 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/lint/support"
+)
+
+func TestEnvironments(t *testing.T) {
+	linter := support.Linter{ChartDir: "./testdata/badenvironments"}
+	Environments(&linter)
+	res := linter.Messages
+
+	expect := map[string]string{
+		"environments/dev":  "cannot load environments/dev/values.yaml",
+		"environments/prod": "- replicas: Invalid type. Expected: integer, given: string",
+		"environments/qa":   `environment "uat" not found`,
+	}
+	if len(res) != len(expect) {
+		t.Fatalf("Expected %d errors, got %d, %v", len(expect), len(res), res)
+	}
+	for _, msg := range res {
+		if msg.Severity != support.ErrorSev {
+			t.Errorf("Expected an error, got %s", msg)
+		}
+		if !strings.Contains(msg.Err.Error(), expect[msg.Path]) {
+			t.Errorf("Unexpected error for %s: %s", msg.Path, msg.Err)
+		}
+	}
+}
+
+func TestEnvironmentsNone(t *testing.T) {
+	linter := support.Linter{ChartDir: goodChartDir}
+	Environments(&linter)
+	if len(linter.Messages) != 0 {
+		t.Errorf("Expected no errors, got %v", linter.Messages)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/engine"
+	"k8s.io/helm/pkg/engine/overlay"
 	"k8s.io/helm/pkg/lint/support"
 	"k8s.io/helm/pkg/timeconv"
 	tversion "k8s.io/helm/pkg/version"
@@ -34,6 +35,14 @@ import (
 
 // Templates lints the templates in the Linter.
 func Templates(linter *support.Linter) {
+	TemplatesWithEngine(linter, engine.New(), false)
+}
+
+// TemplatesWithEngine lints the templates in the Linter, rendering them with
+// the given engine, e.g. one with extra template functions registered. If
+// profile is true, the render profile of the chart is returned. Charts that
+// select the overlay engine are rendered with it instead, without a profile.
+func TemplatesWithEngine(linter *support.Linter, e *engine.Engine, profile bool) *engine.Profile {
 	path := "templates/"
 	templatesPath := filepath.Join(linter.ChartDir, path)
 
@@ -41,7 +50,7 @@ func Templates(linter *support.Linter) {
 
 	// Templates directory is optional for now
 	if !templatesDirExist {
-		return
+		return nil
 	}
 
 	// Load chart and parse templates, based on tiller/release_server
@@ -50,7 +59,7 @@ func Templates(linter *support.Linter) {
 	chartLoaded := linter.RunLinterRule(support.ErrorSev, path, err)
 
 	if !chartLoaded {
-		return
+		return nil
 	}
 
 	options := chartutil.ReleaseOptions{Name: "testRelease", Time: timeconv.Now(), Namespace: "testNamespace"}
@@ -70,14 +79,24 @@ func Templates(linter *support.Linter) {
 		// FIXME: This seems to generate a duplicate, but I can't find where the first
 		// error is coming from.
 		//linter.RunLinterRule(support.ErrorSev, err)
-		return
+		return nil
+	}
+	var (
+		renderedContentMap map[string]string
+		prof               *engine.Profile
+	)
+	if chart.Metadata.Engine == overlay.Name {
+		renderedContentMap, err = overlay.New().Render(chart, valuesToRender)
+	} else if profile {
+		renderedContentMap, prof, err = e.RenderWithProfile(chart, valuesToRender)
+	} else {
+		renderedContentMap, err = e.Render(chart, valuesToRender)
 	}
-	renderedContentMap, err := engine.New().Render(chart, valuesToRender)
 
 	renderOk := linter.RunLinterRule(support.ErrorSev, path, err)
 
 	if !renderOk {
-		return
+		return nil
 	}
 
 	/* Iterate over all the templates to check:
@@ -117,6 +136,7 @@ func Templates(linter *support.Linter) {
 			continue
 		}
 	}
+	return prof
 }
 
 // Validation functions
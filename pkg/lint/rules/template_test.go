@@ -76,3 +76,17 @@ func TestTemplateIntegrationHappyPath(t *testing.T) {
 		t.Fatalf("Expected no error, got %d, %v", len(res), res)
 	}
 }
+
+func TestTemplateOverlayEngine(t *testing.T) {
+	linter := support.Linter{ChartDir: "./testdata/overlay"}
+	Templates(&linter)
+	res := linter.Messages
+
+	if len(res) != 1 {
+		t.Fatalf("Expected one error, got %d, %v", len(res), res)
+	}
+
+	if !strings.Contains(res[0].Err.Error(), "overlay 0 (Deployment) matched no resources") {
+		t.Errorf("Unexpected error: %s", res[0])
+	}
+}
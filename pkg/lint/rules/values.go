@@ -31,11 +31,11 @@ func Values(linter *support.Linter) {
 	vf := filepath.Join(linter.ChartDir, file)
 	fileExists := linter.RunLinterRule(support.InfoSev, file, validateValuesFileExistence(linter, vf))
 
-	if !fileExists {
+	if fileExists && !linter.RunLinterRule(support.ErrorSev, file, validateValuesFile(linter, vf)) {
 		return
 	}
 
-	linter.RunLinterRule(support.ErrorSev, file, validateValuesFile(linter, vf))
+	linter.RunLinterRule(support.ErrorSev, file, validateValuesSchema(linter.ChartDir))
 }
 
 func validateValuesFileExistence(linter *support.Linter, valuesPath string) error {
@@ -53,3 +53,18 @@ func validateValuesFile(linter *support.Linter, valuesPath string) error {
 	}
 	return nil
 }
+
+// validateValuesSchema checks the chart's default values against the schemas
+// of the chart and its dependencies. Charts that fail to load are reported by
+// the other rules.
+func validateValuesSchema(chartDir string) error {
+	c, err := chartutil.Load(chartDir)
+	if err != nil {
+		return nil
+	}
+	vals, err := chartutil.CoalesceValues(c, c.Values)
+	if err != nil {
+		return nil
+	}
+	return chartutil.ValidateAgainstSchema(c, vals)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin // import "k8s.io/helm/pkg/plugin"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// validFuncName matches the names text/template accepts for functions.
+var validFuncName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// TemplateFuncs returns the template functions declared by the given plugins.
+//
+// Each function runs its plugin command out-of-process: the arguments are
+// passed as a JSON array on stdin and the JSON value written to stdout is
+// returned as the result. It is an error for two plugins to declare a
+// function with the same name.
+func TemplateFuncs(plugins []*Plugin) (template.FuncMap, error) {
+	funcs := template.FuncMap{}
+	owners := map[string]string{}
+	for _, p := range plugins {
+		for _, fn := range p.Metadata.TemplateFunctions {
+			if !validFuncName.MatchString(fn.Name) {
+				return nil, fmt.Errorf("plugin %q: invalid template function name %q", p.Metadata.Name, fn.Name)
+			}
+			if owner, ok := owners[fn.Name]; ok {
+				return nil, fmt.Errorf("template function %q is declared by both plugin %q and plugin %q", fn.Name, owner, p.Metadata.Name)
+			}
+			owners[fn.Name] = p.Metadata.Name
+			funcs[fn.Name] = p.templateFunc(fn)
+		}
+	}
+	return funcs, nil
+}
+
+// templateFunc returns the template function that invokes fn's command.
+func (p *Plugin) templateFunc(fn TemplateFunction) func(...interface{}) (interface{}, error) {
+	return func(args ...interface{}) (interface{}, error) {
+		if args == nil {
+			args = []interface{}{}
+		}
+		in, err := json.Marshal(args)
+		if err != nil {
+			return nil, fmt.Errorf("template function %q: cannot encode arguments: %s", fn.Name, err)
+		}
+
+		parts := strings.Split(os.Expand(fn.Command, p.expandEnv), " ")
+		prog := exec.Command(parts[0], parts[1:]...)
+		prog.Env = append(os.Environ(),
+			"HELM_PLUGIN_NAME="+p.Metadata.Name,
+			"HELM_PLUGIN_DIR="+p.Dir,
+		)
+		prog.Stdin = bytes.NewReader(in)
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		prog.Stdout = stdout
+		prog.Stderr = stderr
+		if err := prog.Run(); err != nil {
+			msg := strings.TrimSpace(stderr.String())
+			if msg == "" {
+				msg = err.Error()
+			}
+			return nil, fmt.Errorf("template function %q (plugin %q) failed: %s", fn.Name, p.Metadata.Name, msg)
+		}
+
+		var out interface{}
+		if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+			return nil, fmt.Errorf("template function %q (plugin %q) returned invalid JSON: %s", fn.Name, p.Metadata.Name, err)
+		}
+		return out, nil
+	}
+}
+
+// expandEnv expands the plugin variables in a command, falling back to the
+// process environment for everything else.
+func (p *Plugin) expandEnv(key string) string {
+	switch key {
+	case "HELM_PLUGIN_NAME":
+		return p.Metadata.Name
+	case "HELM_PLUGIN_DIR":
+		return p.Dir
+	}
+	return os.Getenv(key)
+}
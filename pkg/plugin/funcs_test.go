@@ -0,0 +1,81 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin // import "k8s.io/helm/pkg/plugin"
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"text/template"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	plugs, err := LoadAll("testdata/funcdir")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	funcs, err := TemplateFuncs(plugs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(funcs); l != 2 {
+		t.Fatalf("Expected 2 template functions, got %d", l)
+	}
+
+	echo := funcs["echoArgs"].(func(...interface{}) (interface{}, error))
+	out, err := echo("a", 1, map[string]interface{}{"b": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []interface{}{"a", float64(1), map[string]interface{}{"b": true}}
+	if !reflect.DeepEqual(expect, out) {
+		t.Errorf("Expected %v, got %v", expect, out)
+	}
+
+	fail := funcs["fail"].(func(...interface{}) (interface{}, error))
+	if _, err := fail(); err == nil {
+		t.Error("Expected an error from a failing command")
+	}
+
+	tpl := template.Must(template.New("test").Funcs(funcs).Parse(`{{ index (echoArgs "x" "y") 1 }}`))
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "y" {
+		t.Errorf("Expected y, got %q", buf.String())
+	}
+}
+
+func TestTemplateFuncsConflict(t *testing.T) {
+	plugs, err := LoadAll("testdata/funcdir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TemplateFuncs(append(plugs, plugs...)); err == nil {
+		t.Error("Expected an error for a function declared twice")
+	}
+
+	bad := &Plugin{Metadata: &Metadata{
+		Name:              "bad",
+		TemplateFunctions: []TemplateFunction{{Name: "not-valid", Command: "cat"}},
+	}}
+	if _, err := TemplateFuncs([]*Plugin{bad}); err == nil {
+		t.Error("Expected an error for an invalid function name")
+	}
+}
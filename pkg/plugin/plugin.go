@@ -38,6 +38,19 @@ type Downloaders struct {
 	Command string `json:"command"`
 }
 
+// TemplateFunction describes a template function provided by a plugin.
+type TemplateFunction struct {
+	// Name is the name of the function, as used in templates.
+	Name string `json:"name"`
+	// Command is the executable that implements the function. It receives
+	// the function's arguments as a JSON array on stdin and must write its
+	// result, encoded as JSON, to stdout.
+	//
+	// As with Metadata.Command, environment variables are expanded;
+	// $HELM_PLUGIN_DIR points to the plugin's directory.
+	Command string `json:"command"`
+}
+
 // Metadata describes a plugin.
 //
 // This is the plugin equivalent of a chart.Metadata.
@@ -82,6 +95,10 @@ type Metadata struct {
 	// Downloaders field is used if the plugin supply downloader mechanism
 	// for special protocols.
 	Downloaders []Downloaders `json:"downloaders"`
+
+	// TemplateFunctions are extra functions the plugin makes available to
+	// chart templates.
+	TemplateFunctions []TemplateFunction `json:"templateFunctions"`
 }
 
 // Plugin represents a plugin.
@@ -17,15 +17,18 @@ type Metadata_Engine int32
 const (
 	Metadata_UNKNOWN Metadata_Engine = 0
 	Metadata_GOTPL   Metadata_Engine = 1
+	Metadata_OVERLAY Metadata_Engine = 2
 )
 
 var Metadata_Engine_name = map[int32]string{
 	0: "UNKNOWN",
 	1: "GOTPL",
+	2: "OVERLAY",
 }
 var Metadata_Engine_value = map[string]int32{
 	"UNKNOWN": 0,
 	"GOTPL":   1,
+	"OVERLAY": 2,
 }
 
 func (x Metadata_Engine) String() string {
@@ -96,6 +99,9 @@ type Metadata struct {
 	// TillerVersion is a SemVer constraints on what version of Tiller is required.
 	// See SemVer ranges here: https://github.com/Masterminds/semver#basic-comparisons
 	TillerVersion string `protobuf:"bytes,15,opt,name=tillerVersion" json:"tillerVersion,omitempty"`
+	// Sensitive lists the paths of values, such as passwords, that are masked
+	// when releases are printed. A '*' matches any key or list entry.
+	Sensitive []string `protobuf:"bytes,16,rep,name=sensitive" json:"sensitive,omitempty"`
 }
 
 func (m *Metadata) Reset()                    { *m = Metadata{} }
@@ -208,6 +214,13 @@ func (m *Metadata) GetTillerVersion() string {
 	return ""
 }
 
+func (m *Metadata) GetSensitive() []string {
+	if m != nil {
+		return m.Sensitive
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Maintainer)(nil), "hapi.chart.Maintainer")
 	proto.RegisterType((*Metadata)(nil), "hapi.chart.Metadata")
@@ -217,28 +230,29 @@ func init() {
 func init() { proto.RegisterFile("hapi/chart/metadata.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 354 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x92, 0x5f, 0x4b, 0xe3, 0x40,
-	0x14, 0xc5, 0x37, 0x9b, 0xe6, 0xdf, 0xcd, 0x76, 0xb7, 0x0c, 0x4b, 0x99, 0x5d, 0x44, 0x42, 0xf1,
-	0x21, 0x4f, 0x29, 0x28, 0x88, 0xcf, 0x82, 0xf8, 0xa0, 0x6d, 0x25, 0xf8, 0x07, 0x7c, 0x1b, 0x93,
-	0x4b, 0x3b, 0xd8, 0xcc, 0x84, 0xc9, 0xa8, 0xf8, 0x7d, 0xfd, 0x20, 0x32, 0x93, 0xa4, 0x8d, 0xe0,
-	0xdb, 0x3d, 0xe7, 0xe4, 0xfe, 0xc2, 0xb9, 0x0c, 0xfc, 0xdb, 0xb0, 0x9a, 0xcf, 0x8b, 0x0d, 0x53,
-	0x7a, 0x5e, 0xa1, 0x66, 0x25, 0xd3, 0x2c, 0xab, 0x95, 0xd4, 0x92, 0x80, 0x89, 0x32, 0x1b, 0xcd,
-	0x4e, 0x01, 0x16, 0x8c, 0x0b, 0xcd, 0xb8, 0x40, 0x45, 0x08, 0x8c, 0x04, 0xab, 0x90, 0x3a, 0x89,
-	0x93, 0x46, 0xb9, 0x9d, 0xc9, 0x5f, 0xf0, 0xb0, 0x62, 0x7c, 0x4b, 0x7f, 0x5a, 0xb3, 0x15, 0xb3,
-	0x0f, 0x17, 0xc2, 0x45, 0x87, 0xfd, 0x76, 0x8d, 0xc0, 0x68, 0x23, 0x2b, 0xec, 0xb6, 0xec, 0x4c,
-	0x28, 0x04, 0x8d, 0x7c, 0x51, 0x05, 0x36, 0xd4, 0x4d, 0xdc, 0x34, 0xca, 0x7b, 0x69, 0x92, 0x57,
-	0x54, 0x0d, 0x97, 0x82, 0x8e, 0xec, 0x42, 0x2f, 0x49, 0x02, 0x71, 0x89, 0x4d, 0xa1, 0x78, 0xad,
-	0x4d, 0xea, 0xd9, 0x74, 0x68, 0x91, 0xff, 0x10, 0x3e, 0xe3, 0xfb, 0x9b, 0x54, 0x65, 0x43, 0x7d,
-	0x8b, 0xdd, 0x69, 0x72, 0x06, 0x71, 0xb5, 0xab, 0xd7, 0xd0, 0x20, 0x71, 0xd3, 0xf8, 0x78, 0x9a,
-	0xed, 0x0f, 0x90, 0xed, 0xdb, 0xe7, 0xc3, 0x4f, 0xc9, 0x14, 0x7c, 0x14, 0x6b, 0x2e, 0x90, 0x86,
-	0xf6, 0x97, 0x9d, 0x32, 0xbd, 0x78, 0x21, 0x05, 0x8d, 0xda, 0x5e, 0x66, 0x26, 0x87, 0x00, 0xac,
-	0xe6, 0xf7, 0x5d, 0x01, 0xb0, 0xc9, 0xc0, 0x21, 0x07, 0x10, 0x15, 0x52, 0x94, 0xdc, 0x36, 0x88,
-	0x6d, 0xbc, 0x37, 0x0c, 0x51, 0xb3, 0x75, 0x43, 0x7f, 0xb5, 0x44, 0x33, 0xb7, 0xc4, 0xba, 0x27,
-	0x8e, 0x7b, 0x62, 0xef, 0x98, 0xbc, 0xc4, 0x5a, 0x61, 0xc1, 0x34, 0x96, 0xf4, 0x77, 0xe2, 0xa4,
-	0x61, 0x3e, 0x70, 0xc8, 0x11, 0x8c, 0x35, 0xdf, 0x6e, 0x51, 0xf5, 0x88, 0x3f, 0x16, 0xf1, 0xd5,
-	0x9c, 0x25, 0xe0, 0x5f, 0xb4, 0xad, 0x62, 0x08, 0xee, 0x96, 0x57, 0xcb, 0xd5, 0xc3, 0x72, 0xf2,
-	0x83, 0x44, 0xe0, 0x5d, 0xae, 0x6e, 0x6f, 0xae, 0x27, 0xce, 0x79, 0xf0, 0xe8, 0xd9, 0x33, 0x3d,
-	0xf9, 0xf6, 0xe9, 0x9c, 0x7c, 0x06, 0x00, 0x00, 0xff, 0xff, 0xea, 0xb5, 0x4c, 0xbe, 0x57, 0x02,
-	0x00, 0x00,
+	// 374 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x92, 0x4f, 0xab, 0xd3, 0x40,
+	0x14, 0xc5, 0xcd, 0x6b, 0xfe, 0xde, 0xf8, 0x34, 0x0c, 0xf2, 0x18, 0x45, 0x24, 0x14, 0x17, 0xd9,
+	0x98, 0x82, 0x82, 0xb8, 0x55, 0x28, 0x2e, 0xec, 0x1f, 0x09, 0x5a, 0xd1, 0xdd, 0x98, 0x5c, 0xda,
+	0xc1, 0x66, 0x26, 0xcc, 0x8c, 0x15, 0x3f, 0x85, 0x5f, 0x59, 0x66, 0x92, 0x34, 0x11, 0xdc, 0xdd,
+	0x73, 0x4e, 0xee, 0x2f, 0x39, 0x97, 0xc0, 0xe3, 0x13, 0xeb, 0xf8, 0xaa, 0x3e, 0x31, 0x65, 0x56,
+	0x2d, 0x1a, 0xd6, 0x30, 0xc3, 0xca, 0x4e, 0x49, 0x23, 0x09, 0xd8, 0xa8, 0x74, 0xd1, 0xf2, 0x35,
+	0xc0, 0x96, 0x71, 0x61, 0x18, 0x17, 0xa8, 0x08, 0x01, 0x5f, 0xb0, 0x16, 0xa9, 0x97, 0x7b, 0x45,
+	0x52, 0xb9, 0x99, 0x3c, 0x82, 0x00, 0x5b, 0xc6, 0xcf, 0xf4, 0xc6, 0x99, 0xbd, 0x58, 0xfe, 0xf1,
+	0x21, 0xde, 0x0e, 0xd8, 0xff, 0xae, 0x11, 0xf0, 0x4f, 0xb2, 0xc5, 0x61, 0xcb, 0xcd, 0x84, 0x42,
+	0xa4, 0xe5, 0x4f, 0x55, 0xa3, 0xa6, 0x8b, 0x7c, 0x51, 0x24, 0xd5, 0x28, 0x6d, 0x72, 0x41, 0xa5,
+	0xb9, 0x14, 0xd4, 0x77, 0x0b, 0xa3, 0x24, 0x39, 0xa4, 0x0d, 0xea, 0x5a, 0xf1, 0xce, 0xd8, 0x34,
+	0x70, 0xe9, 0xdc, 0x22, 0x4f, 0x20, 0xfe, 0x81, 0xbf, 0x7f, 0x49, 0xd5, 0x68, 0x1a, 0x3a, 0xec,
+	0x55, 0x93, 0x37, 0x90, 0xb6, 0xd7, 0x7a, 0x9a, 0x46, 0xf9, 0xa2, 0x48, 0x5f, 0xde, 0x95, 0xd3,
+	0x01, 0xca, 0xa9, 0x7d, 0x35, 0x7f, 0x94, 0xdc, 0x41, 0x88, 0xe2, 0xc8, 0x05, 0xd2, 0xd8, 0xbd,
+	0x72, 0x50, 0xb6, 0x17, 0xaf, 0xa5, 0xa0, 0x49, 0xdf, 0xcb, 0xce, 0xe4, 0x19, 0x00, 0xeb, 0xf8,
+	0x61, 0x28, 0x00, 0x2e, 0x99, 0x39, 0xe4, 0x29, 0x24, 0xb5, 0x14, 0x0d, 0x77, 0x0d, 0x52, 0x17,
+	0x4f, 0x86, 0x25, 0x1a, 0x76, 0xd4, 0xf4, 0x7e, 0x4f, 0xb4, 0x73, 0x4f, 0xec, 0x46, 0xe2, 0xed,
+	0x48, 0x1c, 0x1d, 0x9b, 0x37, 0xd8, 0x29, 0xac, 0x99, 0xc1, 0x86, 0x3e, 0xc8, 0xbd, 0x22, 0xae,
+	0x66, 0x0e, 0x79, 0x0e, 0xb7, 0x86, 0x9f, 0xcf, 0xa8, 0x46, 0xc4, 0x43, 0x87, 0xf8, 0xd7, 0xb4,
+	0xdf, 0xa5, 0x51, 0x68, 0x6e, 0xf8, 0x05, 0x69, 0xe6, 0x4e, 0x37, 0x19, 0xcb, 0x17, 0x10, 0xae,
+	0xfb, 0xce, 0x29, 0x44, 0x9f, 0x77, 0x1f, 0x76, 0xfb, 0x2f, 0xbb, 0xec, 0x1e, 0x49, 0x20, 0x78,
+	0xbf, 0xff, 0xf4, 0x71, 0x93, 0x79, 0xd6, 0xdf, 0x1f, 0xd6, 0xd5, 0xe6, 0xed, 0xd7, 0xec, 0xe6,
+	0x5d, 0xf4, 0x2d, 0x70, 0x17, 0xfd, 0x1e, 0xba, 0xbf, 0xec, 0xd5, 0xdf, 0x01, 0x00, 0x04, 0x97,
+	0x68, 0xbf, 0x82, 0x02, 0x00, 0x00,
 }
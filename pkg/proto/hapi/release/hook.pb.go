@@ -17,6 +17,7 @@ It has these top-level messages:
 	Info
 	Release
 	Status
+	ResourceStatus
 	TestRun
 	TestSuite
 */
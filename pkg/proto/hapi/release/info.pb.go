@@ -22,6 +22,9 @@ type Info struct {
 	Deleted *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=deleted" json:"deleted,omitempty"`
 	// Description is human-friendly "log entry" about this release.
 	Description string `protobuf:"bytes,5,opt,name=Description" json:"Description,omitempty"`
+	// Adopted lists the resources, as in "Deployment/web", that existed in
+	// the cluster before this release and were taken over by it.
+	Adopted []string `protobuf:"bytes,6,rep,name=adopted" json:"adopted,omitempty"`
 }
 
 func (m *Info) Reset()                    { *m = Info{} }
@@ -64,6 +67,13 @@ func (m *Info) GetDescription() string {
 	return ""
 }
 
+func (m *Info) GetAdopted() []string {
+	if m != nil {
+		return m.Adopted
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Info)(nil), "hapi.release.Info")
 }
@@ -71,20 +81,21 @@ func init() {
 func init() { proto.RegisterFile("hapi/release/info.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 235 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x84, 0x8f, 0x31, 0x4f, 0xc3, 0x30,
-	0x10, 0x85, 0x95, 0x52, 0x5a, 0xd5, 0x6d, 0x19, 0x2c, 0x24, 0x42, 0x16, 0x22, 0xa6, 0x0e, 0xc8,
-	0x91, 0x80, 0x1d, 0x81, 0xba, 0xb0, 0x06, 0x26, 0x16, 0xe4, 0xe2, 0x73, 0xb1, 0xe4, 0xe6, 0x2c,
-	0xfb, 0x3a, 0xf0, 0x2f, 0xf8, 0xc9, 0xa8, 0xb6, 0x83, 0xd2, 0xa9, 0xab, 0xbf, 0xf7, 0x3e, 0xbf,
-	0x63, 0x57, 0xdf, 0xd2, 0x99, 0xc6, 0x83, 0x05, 0x19, 0xa0, 0x31, 0x9d, 0x46, 0xe1, 0x3c, 0x12,
-	0xf2, 0xc5, 0x01, 0x88, 0x0c, 0xaa, 0x9b, 0x2d, 0xe2, 0xd6, 0x42, 0x13, 0xd9, 0x66, 0xaf, 0x1b,
-	0x32, 0x3b, 0x08, 0x24, 0x77, 0x2e, 0xc5, 0xab, 0xeb, 0x23, 0x4f, 0x20, 0x49, 0xfb, 0x90, 0xd0,
-	0xed, 0xef, 0x88, 0x8d, 0x5f, 0x3b, 0x8d, 0xfc, 0x8e, 0x4d, 0x12, 0x28, 0x8b, 0xba, 0x58, 0xcd,
-	0xef, 0x2f, 0xc5, 0xf0, 0x0f, 0xf1, 0x16, 0x59, 0x9b, 0x33, 0xfc, 0x99, 0x5d, 0x68, 0xe3, 0x03,
-	0x7d, 0x2a, 0x70, 0x16, 0x7f, 0x40, 0x95, 0xa3, 0xd8, 0xaa, 0x44, 0xda, 0x22, 0xfa, 0x2d, 0xe2,
-	0xbd, 0xdf, 0xd2, 0x2e, 0x63, 0x63, 0x9d, 0x0b, 0xfc, 0x89, 0x2d, 0xad, 0x1c, 0x1a, 0xce, 0x4e,
-	0x1a, 0x16, 0x87, 0xc2, 0xbf, 0xe0, 0x91, 0x4d, 0x15, 0x58, 0x20, 0x50, 0xe5, 0xf8, 0x64, 0xb5,
-	0x8f, 0xf2, 0x9a, 0xcd, 0xd7, 0x10, 0xbe, 0xbc, 0x71, 0x64, 0xb0, 0x2b, 0xcf, 0xeb, 0x62, 0x35,
-	0x6b, 0x87, 0x4f, 0x2f, 0xb3, 0x8f, 0x69, 0xbe, 0x7a, 0x33, 0x89, 0xa6, 0x87, 0xbf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x1a, 0x52, 0x8f, 0x9c, 0x89, 0x01, 0x00, 0x00,
+	// 245 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x84, 0x90, 0x31, 0x4f, 0xc3, 0x30,
+	0x10, 0x85, 0x95, 0xb6, 0x24, 0x8a, 0xdb, 0x32, 0x58, 0x48, 0x98, 0x2c, 0x44, 0x4c, 0x19, 0x90,
+	0x23, 0x01, 0x3b, 0x02, 0x75, 0x61, 0x0d, 0x4c, 0x2c, 0xc8, 0xc5, 0x97, 0x62, 0xc9, 0xcd, 0x59,
+	0xf1, 0x75, 0xe0, 0x2f, 0xf1, 0x2b, 0x51, 0x9d, 0x44, 0x72, 0xa7, 0x8e, 0xc9, 0xf7, 0xde, 0x77,
+	0x4f, 0x66, 0xd7, 0x3f, 0xca, 0x99, 0xba, 0x07, 0x0b, 0xca, 0x43, 0x6d, 0xba, 0x16, 0xa5, 0xeb,
+	0x91, 0x90, 0xaf, 0x8e, 0x40, 0x8e, 0xa0, 0xb8, 0xdd, 0x21, 0xee, 0x2c, 0xd4, 0x81, 0x6d, 0x0f,
+	0x6d, 0x4d, 0x66, 0x0f, 0x9e, 0xd4, 0xde, 0x0d, 0xf1, 0xe2, 0xe6, 0xc4, 0xe3, 0x49, 0xd1, 0xc1,
+	0x0f, 0xe8, 0xee, 0x6f, 0xc6, 0x16, 0x6f, 0x5d, 0x8b, 0xfc, 0x9e, 0xa5, 0x03, 0x10, 0x49, 0x99,
+	0x54, 0xcb, 0x87, 0x2b, 0x19, 0xdf, 0x90, 0xef, 0x81, 0x35, 0x63, 0x86, 0xbf, 0xb0, 0xcb, 0xd6,
+	0xf4, 0x9e, 0xbe, 0x34, 0x38, 0x8b, 0xbf, 0xa0, 0xc5, 0x2c, 0xb4, 0x0a, 0x39, 0x6c, 0x91, 0xd3,
+	0x16, 0xf9, 0x31, 0x6d, 0x69, 0xd6, 0xa1, 0xb1, 0x19, 0x0b, 0xfc, 0x99, 0xad, 0xad, 0x8a, 0x0d,
+	0xf3, 0xb3, 0x86, 0x95, 0x55, 0x91, 0xe0, 0x89, 0x65, 0x1a, 0x2c, 0x10, 0x68, 0xb1, 0x38, 0x5b,
+	0x9d, 0xa2, 0xbc, 0x64, 0xcb, 0x0d, 0xf8, 0xef, 0xde, 0x38, 0x32, 0xd8, 0x89, 0x8b, 0x32, 0xa9,
+	0xf2, 0x26, 0xfe, 0xc5, 0x05, 0xcb, 0x94, 0x46, 0x77, 0xf4, 0xa6, 0xe5, 0xbc, 0xca, 0x9b, 0xe9,
+	0xf3, 0x35, 0xff, 0xcc, 0xc6, 0xf7, 0xd8, 0xa6, 0xe1, 0xc6, 0xe3, 0xff, 0x00, 0x2f, 0x24, 0xa1,
+	0x36, 0xa3, 0x01, 0x00, 0x00,
 }
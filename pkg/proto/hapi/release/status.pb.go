@@ -55,12 +55,14 @@ func (Status_Code) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []
 // Status defines the status of a release.
 type Status struct {
 	Code Status_Code `protobuf:"varint,1,opt,name=code,enum=hapi.release.Status_Code" json:"code,omitempty"`
-	// Cluster resources as kubectl would print them.
+	// Cluster resources as a table, rendered from resource_statuses.
 	Resources string `protobuf:"bytes,3,opt,name=resources" json:"resources,omitempty"`
 	// Contains the rendered templates/NOTES.txt if available
 	Notes string `protobuf:"bytes,4,opt,name=notes" json:"notes,omitempty"`
 	// LastTestSuiteRun provides results on the last test run on a release
 	LastTestSuiteRun *TestSuite `protobuf:"bytes,5,opt,name=last_test_suite_run,json=lastTestSuiteRun" json:"last_test_suite_run,omitempty"`
+	// ResourceStatuses describes the state of each resource of the release in the cluster.
+	ResourceStatuses []*ResourceStatus `protobuf:"bytes,6,rep,name=resource_statuses,json=resourceStatuses" json:"resource_statuses,omitempty"`
 }
 
 func (m *Status) Reset()                    { *m = Status{} }
@@ -96,32 +98,127 @@ func (m *Status) GetLastTestSuiteRun() *TestSuite {
 	return nil
 }
 
+func (m *Status) GetResourceStatuses() []*ResourceStatus {
+	if m != nil {
+		return m.ResourceStatuses
+	}
+	return nil
+}
+
+// ResourceStatus describes the state of a resource of a release in the cluster.
+type ResourceStatus struct {
+	// Kind is the kind of the resource, as in "Deployment".
+	Kind      string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	// Ready is set when the resource is ready, as helm install --wait expects it.
+	Ready bool `protobuf:"varint,4,opt,name=ready" json:"ready,omitempty"`
+	// Message tells the state of the resource, as in "2/3 replicas ready".
+	Message string `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	// Age is the number of seconds since the resource was created.
+	Age int64 `protobuf:"varint,6,opt,name=age" json:"age,omitempty"`
+	// APIVersion is the API version of the resource, as in "apps/v1beta1".
+	ApiVersion string `protobuf:"bytes,7,opt,name=api_version,json=apiVersion" json:"api_version,omitempty"`
+	// Missing is set when the resource does not exist in the cluster.
+	Missing bool `protobuf:"varint,8,opt,name=missing" json:"missing,omitempty"`
+}
+
+func (m *ResourceStatus) Reset()                    { *m = ResourceStatus{} }
+func (m *ResourceStatus) String() string            { return proto.CompactTextString(m) }
+func (*ResourceStatus) ProtoMessage()               {}
+func (*ResourceStatus) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{1} }
+
+func (m *ResourceStatus) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ResourceStatus) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ResourceStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ResourceStatus) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *ResourceStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ResourceStatus) GetAge() int64 {
+	if m != nil {
+		return m.Age
+	}
+	return 0
+}
+
+func (m *ResourceStatus) GetApiVersion() string {
+	if m != nil {
+		return m.ApiVersion
+	}
+	return ""
+}
+
+func (m *ResourceStatus) GetMissing() bool {
+	if m != nil {
+		return m.Missing
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Status)(nil), "hapi.release.Status")
+	proto.RegisterType((*ResourceStatus)(nil), "hapi.release.ResourceStatus")
 	proto.RegisterEnum("hapi.release.Status_Code", Status_Code_name, Status_Code_value)
 }
 
 func init() { proto.RegisterFile("hapi/release/status.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 291 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x54, 0x90, 0xdf, 0x6a, 0xc2, 0x30,
-	0x14, 0xc6, 0x57, 0xad, 0x3a, 0x8f, 0x22, 0x21, 0x1b, 0xac, 0xca, 0x06, 0xc5, 0xab, 0xde, 0xac,
-	0x05, 0xf7, 0x04, 0xdb, 0x12, 0x87, 0xac, 0x54, 0x69, 0x2b, 0xfb, 0x73, 0x53, 0xaa, 0x9e, 0x39,
-	0xa1, 0x34, 0xd2, 0x24, 0x17, 0x7b, 0x88, 0xbd, 0xf3, 0x68, 0x2b, 0x74, 0x5e, 0x7e, 0xf9, 0xfd,
-	0x4e, 0xce, 0xc7, 0x81, 0xf1, 0x77, 0x7a, 0x3c, 0x78, 0x05, 0x66, 0x98, 0x4a, 0xf4, 0xa4, 0x4a,
-	0x95, 0x96, 0xee, 0xb1, 0x10, 0x4a, 0xd0, 0x61, 0x89, 0xdc, 0x13, 0x9a, 0xdc, 0x9d, 0x89, 0x0a,
-	0xa5, 0x4a, 0xa4, 0x3e, 0x28, 0xac, 0xe5, 0xc9, 0x78, 0x2f, 0xc4, 0x3e, 0x43, 0xaf, 0x4a, 0x1b,
-	0xfd, 0xe5, 0xa5, 0xf9, 0x4f, 0x8d, 0xa6, 0xbf, 0x2d, 0xe8, 0x46, 0xd5, 0xc7, 0xf4, 0x1e, 0xcc,
-	0xad, 0xd8, 0xa1, 0x65, 0xd8, 0x86, 0x33, 0x9a, 0x8d, 0xdd, 0xff, 0x1b, 0xdc, 0xda, 0x71, 0x9f,
-	0xc5, 0x0e, 0xc3, 0x4a, 0xa3, 0xb7, 0xd0, 0x2f, 0x50, 0x0a, 0x5d, 0x6c, 0x51, 0x5a, 0x6d, 0xdb,
-	0x70, 0xfa, 0x61, 0xf3, 0x40, 0xaf, 0xa1, 0x93, 0x0b, 0x85, 0xd2, 0x32, 0x2b, 0x52, 0x07, 0x3a,
-	0x87, 0xab, 0x2c, 0x95, 0x2a, 0x69, 0x1a, 0x26, 0x85, 0xce, 0xad, 0x8e, 0x6d, 0x38, 0x83, 0xd9,
-	0xcd, 0xf9, 0xc6, 0x18, 0xa5, 0x8a, 0x4a, 0x25, 0x24, 0xe5, 0x4c, 0x13, 0x75, 0x3e, 0x7d, 0x07,
-	0xb3, 0x6c, 0x42, 0x07, 0xd0, 0x5b, 0x07, 0xaf, 0xc1, 0xf2, 0x2d, 0x20, 0x17, 0x74, 0x08, 0x97,
-	0x8c, 0xaf, 0xfc, 0xe5, 0x07, 0x67, 0xc4, 0x28, 0x11, 0xe3, 0x3e, 0x8f, 0x39, 0x23, 0x2d, 0x3a,
-	0x02, 0x88, 0xd6, 0x2b, 0x1e, 0x46, 0x9c, 0x71, 0x46, 0xda, 0x14, 0xa0, 0x3b, 0x7f, 0x5c, 0xf8,
-	0x9c, 0x11, 0xb3, 0x1e, 0xf3, 0x79, 0xbc, 0x08, 0x5e, 0x48, 0xe7, 0xa9, 0xff, 0xd9, 0x3b, 0x15,
-	0xd8, 0x74, 0xab, 0x0b, 0x3d, 0xfc, 0x05, 0x00, 0x00, 0xff, 0xff, 0xd4, 0x11, 0x21, 0x30, 0x86,
-	0x01, 0x00, 0x00,
+	// 425 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x54, 0x92, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x71, 0xec, 0x38, 0xf1, 0xa4, 0x8a, 0xcc, 0x82, 0x84, 0x53, 0x15, 0x61, 0xe5, 0xe4,
+	0x0b, 0x8e, 0x14, 0x9e, 0x00, 0xf0, 0x16, 0x45, 0x44, 0x69, 0xb5, 0x49, 0xf9, 0x77, 0xb1, 0xb6,
+	0xc9, 0x10, 0x2c, 0x52, 0xdb, 0xf2, 0xac, 0x91, 0xfa, 0x9c, 0x5c, 0x78, 0x1c, 0xb4, 0xbb, 0x09,
+	0xc1, 0xb7, 0xf9, 0x66, 0xbe, 0x99, 0x6f, 0xfd, 0x93, 0x61, 0xf2, 0x43, 0xd6, 0xc5, 0xac, 0xc1,
+	0x03, 0x4a, 0xc2, 0x19, 0x29, 0xa9, 0x5a, 0x4a, 0xeb, 0xa6, 0x52, 0x15, 0xbb, 0xd0, 0xa3, 0xf4,
+	0x38, 0xba, 0x7c, 0xd9, 0x31, 0x2a, 0x24, 0x95, 0x53, 0x5b, 0x28, 0xb4, 0xe6, 0xcb, 0xc9, 0xbe,
+	0xaa, 0xf6, 0x07, 0x9c, 0x19, 0x75, 0xdf, 0x7e, 0x9f, 0xc9, 0xf2, 0xd1, 0x8e, 0xa6, 0x7f, 0x7a,
+	0xe0, 0xaf, 0xcd, 0x61, 0xf6, 0x1a, 0xbc, 0x6d, 0xb5, 0xc3, 0xc8, 0x89, 0x9d, 0x64, 0x3c, 0x9f,
+	0xa4, 0xff, 0x27, 0xa4, 0xd6, 0x93, 0xbe, 0xaf, 0x76, 0x28, 0x8c, 0x8d, 0x5d, 0x41, 0xd0, 0x20,
+	0x55, 0x6d, 0xb3, 0x45, 0x8a, 0xdc, 0xd8, 0x49, 0x02, 0x71, 0x6e, 0xb0, 0xe7, 0xd0, 0x2f, 0x2b,
+	0x85, 0x14, 0x79, 0x66, 0x62, 0x05, 0xbb, 0x86, 0x67, 0x07, 0x49, 0x2a, 0x3f, 0xbf, 0x30, 0x6f,
+	0xda, 0x32, 0xea, 0xc7, 0x4e, 0x32, 0x9a, 0xbf, 0xe8, 0x26, 0x6e, 0x90, 0xd4, 0x5a, 0x5b, 0x44,
+	0xa8, 0x77, 0xce, 0xb2, 0x2d, 0xd9, 0x02, 0x9e, 0x9e, 0xa2, 0x72, 0x8b, 0x05, 0x29, 0xf2, 0x63,
+	0x37, 0x19, 0xcd, 0xaf, 0xba, 0x57, 0xc4, 0xd1, 0x66, 0xdf, 0x2f, 0xc2, 0xa6, 0xa3, 0x91, 0xa6,
+	0x5f, 0xc0, 0xd3, 0x1f, 0xc5, 0x46, 0x30, 0xb8, 0x5b, 0x7d, 0x5c, 0xdd, 0x7c, 0x5e, 0x85, 0x4f,
+	0xd8, 0x05, 0x0c, 0x33, 0x7e, 0xbb, 0xbc, 0xf9, 0xca, 0xb3, 0xd0, 0xd1, 0xa3, 0x8c, 0x2f, 0xf9,
+	0x86, 0x67, 0x61, 0x8f, 0x8d, 0x01, 0xd6, 0x77, 0xb7, 0x5c, 0xac, 0x79, 0xc6, 0xb3, 0xd0, 0x65,
+	0x00, 0xfe, 0xf5, 0xdb, 0xc5, 0x92, 0x67, 0xa1, 0x67, 0xd7, 0x96, 0x7c, 0xb3, 0x58, 0x7d, 0x08,
+	0xfb, 0xd3, 0xdf, 0x0e, 0x8c, 0xbb, 0xf1, 0x8c, 0x81, 0xf7, 0xb3, 0x28, 0x77, 0x06, 0x71, 0x20,
+	0x4c, 0xad, 0x39, 0x96, 0xf2, 0x01, 0xa9, 0x96, 0x5b, 0x8c, 0x7a, 0x96, 0xe3, 0xbf, 0x86, 0xde,
+	0xd0, 0xe2, 0x08, 0xd8, 0xd4, 0x9a, 0x6d, 0x83, 0x72, 0xf7, 0x68, 0xd8, 0x0e, 0x85, 0x15, 0x2c,
+	0x82, 0xc1, 0x03, 0x12, 0xc9, 0x3d, 0x1a, 0x9e, 0x81, 0x38, 0x49, 0x16, 0x82, 0xab, 0xbb, 0x7e,
+	0xec, 0x24, 0xae, 0xd0, 0x25, 0x7b, 0x05, 0x23, 0x59, 0x17, 0xf9, 0x2f, 0x6c, 0xa8, 0xa8, 0xca,
+	0x68, 0x60, 0xfc, 0x20, 0xeb, 0xe2, 0x93, 0xed, 0x98, 0x63, 0x05, 0x51, 0x51, 0xee, 0xa3, 0xa1,
+	0x09, 0x39, 0xc9, 0x77, 0xc1, 0xb7, 0xc1, 0x91, 0xed, 0xbd, 0x6f, 0x7e, 0xa1, 0x37, 0x7f, 0x07,
+	0x00, 0x0d, 0x7b, 0xde, 0x78, 0xa7, 0x02, 0x00, 0x00,
 }
@@ -29,6 +29,13 @@ It has these top-level messages:
 	GetHistoryResponse
 	TestReleaseRequest
 	TestReleaseResponse
+	ReleaseEvent
+	ReleaseProgress
+	GetReleaseDriftRequest
+	FieldDrift
+	ResourceDrift
+	GetReleaseDriftResponse
+	Capabilities
 */
 package services
 
@@ -106,6 +113,44 @@ func (x ListSort_SortOrder) String() string {
 }
 func (ListSort_SortOrder) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1, 1} }
 
+type ReleaseEvent_Phase int32
+
+const (
+	ReleaseEvent_UNKNOWN ReleaseEvent_Phase = 0
+	// The hooks of an event, such as pre-install, started.
+	ReleaseEvent_HOOKS_STARTED ReleaseEvent_Phase = 1
+	// The hooks of an event finished.
+	ReleaseEvent_HOOKS_FINISHED ReleaseEvent_Phase = 2
+	// The resources of the release were created or updated.
+	ReleaseEvent_RESOURCES_APPLIED ReleaseEvent_Phase = 3
+	// A resource is not ready yet. The message tells why.
+	ReleaseEvent_RESOURCE_WAITING ReleaseEvent_Phase = 4
+	// A resource became ready.
+	ReleaseEvent_RESOURCE_READY ReleaseEvent_Phase = 5
+)
+
+var ReleaseEvent_Phase_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "HOOKS_STARTED",
+	2: "HOOKS_FINISHED",
+	3: "RESOURCES_APPLIED",
+	4: "RESOURCE_WAITING",
+	5: "RESOURCE_READY",
+}
+var ReleaseEvent_Phase_value = map[string]int32{
+	"UNKNOWN":           0,
+	"HOOKS_STARTED":     1,
+	"HOOKS_FINISHED":    2,
+	"RESOURCES_APPLIED": 3,
+	"RESOURCE_WAITING":  4,
+	"RESOURCE_READY":    5,
+}
+
+func (x ReleaseEvent_Phase) String() string {
+	return proto.EnumName(ReleaseEvent_Phase_name, int32(x))
+}
+func (ReleaseEvent_Phase) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{21, 0} }
+
 // ListReleasesRequest requests a list of releases.
 //
 // Releases can be retrieved in chunks by setting limit and offset.
@@ -376,6 +421,15 @@ type UpdateReleaseRequest struct {
 	ReuseValues bool `protobuf:"varint,10,opt,name=reuse_values,json=reuseValues" json:"reuse_values,omitempty"`
 	// Force resource update through delete/recreate if needed.
 	Force bool `protobuf:"varint,11,opt,name=force" json:"force,omitempty"`
+	// Capabilities overrides the cluster capabilities the chart is rendered
+	// against. It is only honored on a dry run.
+	Capabilities *Capabilities `protobuf:"bytes,12,opt,name=capabilities" json:"capabilities,omitempty"`
+	// Adopt takes over resources of the chart that already exist in the
+	// cluster, instead of failing to create them.
+	Adopt bool `protobuf:"varint,13,opt,name=adopt" json:"adopt,omitempty"`
+	// Validate submits the resources to the API server as a dry run before
+	// upgrading, and fails the upgrade if the API server rejects any.
+	Validate bool `protobuf:"varint,14,opt,name=validate" json:"validate,omitempty"`
 }
 
 func (m *UpdateReleaseRequest) Reset()                    { *m = UpdateReleaseRequest{} }
@@ -460,6 +514,27 @@ func (m *UpdateReleaseRequest) GetForce() bool {
 	return false
 }
 
+func (m *UpdateReleaseRequest) GetCapabilities() *Capabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *UpdateReleaseRequest) GetAdopt() bool {
+	if m != nil {
+		return m.Adopt
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetValidate() bool {
+	if m != nil {
+		return m.Validate
+	}
+	return false
+}
+
 // UpdateReleaseResponse is the response to an update request.
 type UpdateReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
@@ -602,6 +677,15 @@ type InstallReleaseRequest struct {
 	// wait, if true, will wait until all Pods, PVCs, and Services are in a ready state
 	// before marking the release as successful. It will wait for as long as timeout
 	Wait bool `protobuf:"varint,9,opt,name=wait" json:"wait,omitempty"`
+	// Capabilities overrides the cluster capabilities the chart is rendered
+	// against. It is only honored on a dry run.
+	Capabilities *Capabilities `protobuf:"bytes,10,opt,name=capabilities" json:"capabilities,omitempty"`
+	// Adopt takes over resources of the chart that already exist in the
+	// cluster, instead of failing to create them.
+	Adopt bool `protobuf:"varint,11,opt,name=adopt" json:"adopt,omitempty"`
+	// Validate submits the resources to the API server as a dry run before
+	// installing, and fails the install if the API server rejects any.
+	Validate bool `protobuf:"varint,12,opt,name=validate" json:"validate,omitempty"`
 }
 
 func (m *InstallReleaseRequest) Reset()                    { *m = InstallReleaseRequest{} }
@@ -672,6 +756,27 @@ func (m *InstallReleaseRequest) GetWait() bool {
 	return false
 }
 
+func (m *InstallReleaseRequest) GetCapabilities() *Capabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *InstallReleaseRequest) GetAdopt() bool {
+	if m != nil {
+		return m.Adopt
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetValidate() bool {
+	if m != nil {
+		return m.Validate
+	}
+	return false
+}
+
 // InstallReleaseResponse is the response from a release installation.
 type InstallReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
@@ -891,6 +996,260 @@ func (m *TestReleaseResponse) GetStatus() hapi_release1.TestRun_Status {
 	return hapi_release1.TestRun_UNKNOWN
 }
 
+// ReleaseEvent reports a step in the progress of an install, upgrade or
+// rollback.
+type ReleaseEvent struct {
+	Phase ReleaseEvent_Phase `protobuf:"varint,1,opt,name=phase,enum=hapi.services.tiller.ReleaseEvent_Phase" json:"phase,omitempty"`
+	// Hook is the event of the hooks, e.g. "pre-install", for the hook phases.
+	Hook string `protobuf:"bytes,2,opt,name=hook" json:"hook,omitempty"`
+	// Resource is the kind and name of the resource, e.g. "Deployment/web",
+	// for the resource phases.
+	Resource string `protobuf:"bytes,3,opt,name=resource" json:"resource,omitempty"`
+	// Message describes the step.
+	Message string `protobuf:"bytes,4,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *ReleaseEvent) Reset()                    { *m = ReleaseEvent{} }
+func (m *ReleaseEvent) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseEvent) ProtoMessage()               {}
+func (*ReleaseEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ReleaseEvent) GetPhase() ReleaseEvent_Phase {
+	if m != nil {
+		return m.Phase
+	}
+	return ReleaseEvent_UNKNOWN
+}
+
+func (m *ReleaseEvent) GetHook() string {
+	if m != nil {
+		return m.Hook
+	}
+	return ""
+}
+
+func (m *ReleaseEvent) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *ReleaseEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// ReleaseProgress is a message streamed while a release is installed,
+// upgraded or rolled back. It holds either an event, or, in the last message,
+// the resulting release.
+type ReleaseProgress struct {
+	Event   *ReleaseEvent          `protobuf:"bytes,1,opt,name=event" json:"event,omitempty"`
+	Release *hapi_release5.Release `protobuf:"bytes,2,opt,name=release" json:"release,omitempty"`
+}
+
+func (m *ReleaseProgress) Reset()                    { *m = ReleaseProgress{} }
+func (m *ReleaseProgress) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseProgress) ProtoMessage()               {}
+func (*ReleaseProgress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ReleaseProgress) GetEvent() *ReleaseEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *ReleaseProgress) GetRelease() *hapi_release5.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+// GetReleaseDriftRequest is a request to compare a release with the cluster.
+type GetReleaseDriftRequest struct {
+	// Name is the name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *GetReleaseDriftRequest) Reset()                    { *m = GetReleaseDriftRequest{} }
+func (m *GetReleaseDriftRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetReleaseDriftRequest) ProtoMessage()               {}
+func (*GetReleaseDriftRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *GetReleaseDriftRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// FieldDrift is a field whose live value differs from the release.
+type FieldDrift struct {
+	// Path is the path of the field, e.g. "spec.template.spec.containers[0].image".
+	Path string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	// Expected is the value in the release, as JSON.
+	Expected string `protobuf:"bytes,2,opt,name=expected" json:"expected,omitempty"`
+	// Actual is the live value, as JSON, or empty if the field is not set.
+	Actual string `protobuf:"bytes,3,opt,name=actual" json:"actual,omitempty"`
+}
+
+func (m *FieldDrift) Reset()                    { *m = FieldDrift{} }
+func (m *FieldDrift) String() string            { return proto.CompactTextString(m) }
+func (*FieldDrift) ProtoMessage()               {}
+func (*FieldDrift) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *FieldDrift) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FieldDrift) GetExpected() string {
+	if m != nil {
+		return m.Expected
+	}
+	return ""
+}
+
+func (m *FieldDrift) GetActual() string {
+	if m != nil {
+		return m.Actual
+	}
+	return ""
+}
+
+// ResourceDrift is a resource whose live state differs from the release.
+type ResourceDrift struct {
+	Kind      string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	// Missing is set when the resource no longer exists.
+	Missing bool `protobuf:"varint,4,opt,name=missing" json:"missing,omitempty"`
+	// Fields are the fields that differ, for a resource that exists.
+	Fields []*FieldDrift `protobuf:"bytes,5,rep,name=fields" json:"fields,omitempty"`
+}
+
+func (m *ResourceDrift) Reset()                    { *m = ResourceDrift{} }
+func (m *ResourceDrift) String() string            { return proto.CompactTextString(m) }
+func (*ResourceDrift) ProtoMessage()               {}
+func (*ResourceDrift) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ResourceDrift) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ResourceDrift) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ResourceDrift) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ResourceDrift) GetMissing() bool {
+	if m != nil {
+		return m.Missing
+	}
+	return false
+}
+
+func (m *ResourceDrift) GetFields() []*FieldDrift {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+// GetReleaseDriftResponse lists the resources of a release that drifted.
+type GetReleaseDriftResponse struct {
+	// Name is the name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Version is the deployed version of the release.
+	Version int32 `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
+	// Resources are the resources that drifted. It is empty when the
+	// cluster matches the release.
+	Resources []*ResourceDrift `protobuf:"bytes,3,rep,name=resources" json:"resources,omitempty"`
+}
+
+func (m *GetReleaseDriftResponse) Reset()                    { *m = GetReleaseDriftResponse{} }
+func (m *GetReleaseDriftResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetReleaseDriftResponse) ProtoMessage()               {}
+func (*GetReleaseDriftResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *GetReleaseDriftResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetReleaseDriftResponse) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GetReleaseDriftResponse) GetResources() []*ResourceDrift {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+// Capabilities describes the cluster a chart is rendered against.
+//
+// Fields that are left empty fall back to the values Tiller discovers
+// from the cluster.
+type Capabilities struct {
+	// APIVersions is the set of supported API versions, e.g. "apps/v1beta1".
+	ApiVersions []string `protobuf:"bytes,1,rep,name=api_versions,json=apiVersions" json:"api_versions,omitempty"`
+	// KubeVersion is the Kubernetes version, e.g. "v1.8.0".
+	KubeVersion string `protobuf:"bytes,2,opt,name=kube_version,json=kubeVersion" json:"kube_version,omitempty"`
+	// TillerVersion is the Tiller version.
+	TillerVersion *hapi_version.Version `protobuf:"bytes,3,opt,name=tiller_version,json=tillerVersion" json:"tiller_version,omitempty"`
+}
+
+func (m *Capabilities) Reset()                    { *m = Capabilities{} }
+func (m *Capabilities) String() string            { return proto.CompactTextString(m) }
+func (*Capabilities) ProtoMessage()               {}
+func (*Capabilities) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *Capabilities) GetApiVersions() []string {
+	if m != nil {
+		return m.ApiVersions
+	}
+	return nil
+}
+
+func (m *Capabilities) GetKubeVersion() string {
+	if m != nil {
+		return m.KubeVersion
+	}
+	return ""
+}
+
+func (m *Capabilities) GetTillerVersion() *hapi_version.Version {
+	if m != nil {
+		return m.TillerVersion
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ListReleasesRequest)(nil), "hapi.services.tiller.ListReleasesRequest")
 	proto.RegisterType((*ListSort)(nil), "hapi.services.tiller.ListSort")
@@ -913,8 +1272,16 @@ func init() {
 	proto.RegisterType((*GetHistoryResponse)(nil), "hapi.services.tiller.GetHistoryResponse")
 	proto.RegisterType((*TestReleaseRequest)(nil), "hapi.services.tiller.TestReleaseRequest")
 	proto.RegisterType((*TestReleaseResponse)(nil), "hapi.services.tiller.TestReleaseResponse")
+	proto.RegisterType((*ReleaseEvent)(nil), "hapi.services.tiller.ReleaseEvent")
+	proto.RegisterType((*ReleaseProgress)(nil), "hapi.services.tiller.ReleaseProgress")
+	proto.RegisterType((*GetReleaseDriftRequest)(nil), "hapi.services.tiller.GetReleaseDriftRequest")
+	proto.RegisterType((*FieldDrift)(nil), "hapi.services.tiller.FieldDrift")
+	proto.RegisterType((*ResourceDrift)(nil), "hapi.services.tiller.ResourceDrift")
+	proto.RegisterType((*GetReleaseDriftResponse)(nil), "hapi.services.tiller.GetReleaseDriftResponse")
+	proto.RegisterType((*Capabilities)(nil), "hapi.services.tiller.Capabilities")
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortBy", ListSort_SortBy_name, ListSort_SortBy_value)
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortOrder", ListSort_SortOrder_name, ListSort_SortOrder_value)
+	proto.RegisterEnum("hapi.services.tiller.ReleaseEvent_Phase", ReleaseEvent_Phase_name, ReleaseEvent_Phase_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -951,6 +1318,18 @@ type ReleaseServiceClient interface {
 	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(ctx context.Context, in *TestReleaseRequest, opts ...grpc.CallOption) (ReleaseService_RunReleaseTestClient, error)
+	// InstallReleaseWithProgress installs a release like InstallRelease, and
+	// streams its progress. The last message holds the release.
+	InstallReleaseWithProgress(ctx context.Context, in *InstallReleaseRequest, opts ...grpc.CallOption) (ReleaseService_InstallReleaseWithProgressClient, error)
+	// UpdateReleaseWithProgress updates a release like UpdateRelease, and
+	// streams its progress. The last message holds the release.
+	UpdateReleaseWithProgress(ctx context.Context, in *UpdateReleaseRequest, opts ...grpc.CallOption) (ReleaseService_UpdateReleaseWithProgressClient, error)
+	// RollbackReleaseWithProgress rolls back a release like RollbackRelease,
+	// and streams its progress. The last message holds the release.
+	RollbackReleaseWithProgress(ctx context.Context, in *RollbackReleaseRequest, opts ...grpc.CallOption) (ReleaseService_RollbackReleaseWithProgressClient, error)
+	// GetReleaseDrift compares the resources of the deployed release with
+	// their live state in the cluster.
+	GetReleaseDrift(ctx context.Context, in *GetReleaseDriftRequest, opts ...grpc.CallOption) (*GetReleaseDriftResponse, error)
 }
 
 type releaseServiceClient struct {
@@ -1098,6 +1477,111 @@ func (x *releaseServiceRunReleaseTestClient) Recv() (*TestReleaseResponse, error
 	return m, nil
 }
 
+func (c *releaseServiceClient) InstallReleaseWithProgress(ctx context.Context, in *InstallReleaseRequest, opts ...grpc.CallOption) (ReleaseService_InstallReleaseWithProgressClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ReleaseService_serviceDesc.Streams[2], c.cc, "/hapi.services.tiller.ReleaseService/InstallReleaseWithProgress", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &releaseServiceInstallReleaseWithProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReleaseService_InstallReleaseWithProgressClient interface {
+	Recv() (*ReleaseProgress, error)
+	grpc.ClientStream
+}
+
+type releaseServiceInstallReleaseWithProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *releaseServiceInstallReleaseWithProgressClient) Recv() (*ReleaseProgress, error) {
+	m := new(ReleaseProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *releaseServiceClient) UpdateReleaseWithProgress(ctx context.Context, in *UpdateReleaseRequest, opts ...grpc.CallOption) (ReleaseService_UpdateReleaseWithProgressClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ReleaseService_serviceDesc.Streams[3], c.cc, "/hapi.services.tiller.ReleaseService/UpdateReleaseWithProgress", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &releaseServiceUpdateReleaseWithProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReleaseService_UpdateReleaseWithProgressClient interface {
+	Recv() (*ReleaseProgress, error)
+	grpc.ClientStream
+}
+
+type releaseServiceUpdateReleaseWithProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *releaseServiceUpdateReleaseWithProgressClient) Recv() (*ReleaseProgress, error) {
+	m := new(ReleaseProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *releaseServiceClient) RollbackReleaseWithProgress(ctx context.Context, in *RollbackReleaseRequest, opts ...grpc.CallOption) (ReleaseService_RollbackReleaseWithProgressClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ReleaseService_serviceDesc.Streams[4], c.cc, "/hapi.services.tiller.ReleaseService/RollbackReleaseWithProgress", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &releaseServiceRollbackReleaseWithProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReleaseService_RollbackReleaseWithProgressClient interface {
+	Recv() (*ReleaseProgress, error)
+	grpc.ClientStream
+}
+
+type releaseServiceRollbackReleaseWithProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *releaseServiceRollbackReleaseWithProgressClient) Recv() (*ReleaseProgress, error) {
+	m := new(ReleaseProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *releaseServiceClient) GetReleaseDrift(ctx context.Context, in *GetReleaseDriftRequest, opts ...grpc.CallOption) (*GetReleaseDriftResponse, error) {
+	out := new(GetReleaseDriftResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/GetReleaseDrift", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ReleaseService service
 
 type ReleaseServiceServer interface {
@@ -1124,6 +1608,18 @@ type ReleaseServiceServer interface {
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(*TestReleaseRequest, ReleaseService_RunReleaseTestServer) error
+	// InstallReleaseWithProgress installs a release like InstallRelease, and
+	// streams its progress. The last message holds the release.
+	InstallReleaseWithProgress(*InstallReleaseRequest, ReleaseService_InstallReleaseWithProgressServer) error
+	// UpdateReleaseWithProgress updates a release like UpdateRelease, and
+	// streams its progress. The last message holds the release.
+	UpdateReleaseWithProgress(*UpdateReleaseRequest, ReleaseService_UpdateReleaseWithProgressServer) error
+	// RollbackReleaseWithProgress rolls back a release like RollbackRelease,
+	// and streams its progress. The last message holds the release.
+	RollbackReleaseWithProgress(*RollbackReleaseRequest, ReleaseService_RollbackReleaseWithProgressServer) error
+	// GetReleaseDrift compares the resources of the deployed release with
+	// their live state in the cluster.
+	GetReleaseDrift(context.Context, *GetReleaseDriftRequest) (*GetReleaseDriftResponse, error)
 }
 
 func RegisterReleaseServiceServer(s *grpc.Server, srv ReleaseServiceServer) {
@@ -1316,6 +1812,87 @@ func (x *releaseServiceRunReleaseTestServer) Send(m *TestReleaseResponse) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ReleaseService_InstallReleaseWithProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InstallReleaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReleaseServiceServer).InstallReleaseWithProgress(m, &releaseServiceInstallReleaseWithProgressServer{stream})
+}
+
+type ReleaseService_InstallReleaseWithProgressServer interface {
+	Send(*ReleaseProgress) error
+	grpc.ServerStream
+}
+
+type releaseServiceInstallReleaseWithProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *releaseServiceInstallReleaseWithProgressServer) Send(m *ReleaseProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ReleaseService_UpdateReleaseWithProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpdateReleaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReleaseServiceServer).UpdateReleaseWithProgress(m, &releaseServiceUpdateReleaseWithProgressServer{stream})
+}
+
+type ReleaseService_UpdateReleaseWithProgressServer interface {
+	Send(*ReleaseProgress) error
+	grpc.ServerStream
+}
+
+type releaseServiceUpdateReleaseWithProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *releaseServiceUpdateReleaseWithProgressServer) Send(m *ReleaseProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ReleaseService_RollbackReleaseWithProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RollbackReleaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReleaseServiceServer).RollbackReleaseWithProgress(m, &releaseServiceRollbackReleaseWithProgressServer{stream})
+}
+
+type ReleaseService_RollbackReleaseWithProgressServer interface {
+	Send(*ReleaseProgress) error
+	grpc.ServerStream
+}
+
+type releaseServiceRollbackReleaseWithProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *releaseServiceRollbackReleaseWithProgressServer) Send(m *ReleaseProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ReleaseService_GetReleaseDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReleaseDriftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).GetReleaseDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/GetReleaseDrift",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).GetReleaseDrift(ctx, req.(*GetReleaseDriftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hapi.services.tiller.ReleaseService",
 	HandlerType: (*ReleaseServiceServer)(nil),
@@ -1352,6 +1929,10 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetHistory",
 			Handler:    _ReleaseService_GetHistory_Handler,
 		},
+		{
+			MethodName: "GetReleaseDrift",
+			Handler:    _ReleaseService_GetReleaseDrift_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1364,6 +1945,21 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _ReleaseService_RunReleaseTest_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "InstallReleaseWithProgress",
+			Handler:       _ReleaseService_InstallReleaseWithProgress_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UpdateReleaseWithProgress",
+			Handler:       _ReleaseService_UpdateReleaseWithProgress_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RollbackReleaseWithProgress",
+			Handler:       _ReleaseService_RollbackReleaseWithProgress_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "hapi/services/tiller.proto",
 }
@@ -1371,82 +1967,112 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("hapi/services/tiller.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1217 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x57, 0xdd, 0x6e, 0xe3, 0xc4,
-	0x17, 0xaf, 0xf3, 0x9d, 0x93, 0x36, 0xff, 0x74, 0x9a, 0xb6, 0xae, 0xff, 0x0b, 0x2a, 0x46, 0xb0,
-	0xd9, 0x85, 0x4d, 0x21, 0x70, 0x83, 0x84, 0x90, 0xba, 0xdd, 0xa8, 0x2d, 0x94, 0xae, 0xe4, 0x6c,
-	0x17, 0x09, 0x01, 0x91, 0x9b, 0x4c, 0x5a, 0xb3, 0x8e, 0x27, 0x78, 0xc6, 0x65, 0x7b, 0xcb, 0x1d,
-	0x8f, 0xc2, 0x5b, 0xf0, 0x1e, 0x5c, 0xc2, 0x83, 0x20, 0xcf, 0x87, 0xeb, 0x49, 0xed, 0xd6, 0xf4,
-	0x26, 0x9e, 0x99, 0xf3, 0xfd, 0x3b, 0x67, 0xce, 0x9c, 0x80, 0x75, 0xe9, 0x2e, 0xbc, 0x3d, 0x8a,
-	0xc3, 0x2b, 0x6f, 0x82, 0xe9, 0x1e, 0xf3, 0x7c, 0x1f, 0x87, 0xfd, 0x45, 0x48, 0x18, 0x41, 0xdd,
-	0x98, 0xd6, 0x57, 0xb4, 0xbe, 0xa0, 0x59, 0x5b, 0x5c, 0x62, 0x72, 0xe9, 0x86, 0x4c, 0xfc, 0x0a,
-	0x6e, 0x6b, 0x3b, 0x7d, 0x4e, 0x82, 0x99, 0x77, 0x21, 0x09, 0xc2, 0x44, 0x88, 0x7d, 0xec, 0x52,
-	0xac, 0xbe, 0x9a, 0x90, 0xa2, 0x79, 0xc1, 0x8c, 0x48, 0xc2, 0xff, 0x35, 0x02, 0xc3, 0x94, 0x8d,
-	0xc3, 0x28, 0x90, 0xc4, 0x1d, 0x8d, 0x48, 0x99, 0xcb, 0x22, 0xaa, 0x19, 0xbb, 0xc2, 0x21, 0xf5,
-	0x48, 0xa0, 0xbe, 0x82, 0x66, 0xff, 0x59, 0x82, 0x8d, 0x13, 0x8f, 0x32, 0x47, 0x08, 0x52, 0x07,
-	0xff, 0x12, 0x61, 0xca, 0x50, 0x17, 0xaa, 0xbe, 0x37, 0xf7, 0x98, 0x69, 0xec, 0x1a, 0xbd, 0xb2,
-	0x23, 0x36, 0x68, 0x0b, 0x6a, 0x64, 0x36, 0xa3, 0x98, 0x99, 0xa5, 0x5d, 0xa3, 0xd7, 0x74, 0xe4,
-	0x0e, 0x7d, 0x05, 0x75, 0x4a, 0x42, 0x36, 0x3e, 0xbf, 0x36, 0xcb, 0xbb, 0x46, 0xaf, 0x3d, 0xf8,
-	0xa0, 0x9f, 0x85, 0x53, 0x3f, 0xb6, 0x34, 0x22, 0x21, 0xeb, 0xc7, 0x3f, 0xcf, 0xaf, 0x9d, 0x1a,
-	0xe5, 0xdf, 0x58, 0xef, 0xcc, 0xf3, 0x19, 0x0e, 0xcd, 0x8a, 0xd0, 0x2b, 0x76, 0xe8, 0x10, 0x80,
-	0xeb, 0x25, 0xe1, 0x14, 0x87, 0x66, 0x95, 0xab, 0xee, 0x15, 0x50, 0xfd, 0x32, 0xe6, 0x77, 0x9a,
-	0x54, 0x2d, 0xd1, 0x97, 0xb0, 0x2a, 0x20, 0x19, 0x4f, 0xc8, 0x14, 0x53, 0xb3, 0xb6, 0x5b, 0xee,
-	0xb5, 0x07, 0x3b, 0x42, 0x95, 0x82, 0x7f, 0x24, 0x40, 0x3b, 0x20, 0x53, 0xec, 0xb4, 0x04, 0x7b,
-	0xbc, 0xa6, 0xe8, 0x11, 0x34, 0x03, 0x77, 0x8e, 0xe9, 0xc2, 0x9d, 0x60, 0xb3, 0xce, 0x3d, 0xbc,
-	0x39, 0xb0, 0x7f, 0x82, 0x86, 0x32, 0x6e, 0x0f, 0xa0, 0x26, 0x42, 0x43, 0x2d, 0xa8, 0x9f, 0x9d,
-	0x7e, 0x73, 0xfa, 0xf2, 0xbb, 0xd3, 0xce, 0x0a, 0x6a, 0x40, 0xe5, 0x74, 0xff, 0xdb, 0x61, 0xc7,
-	0x40, 0xeb, 0xb0, 0x76, 0xb2, 0x3f, 0x7a, 0x35, 0x76, 0x86, 0x27, 0xc3, 0xfd, 0xd1, 0xf0, 0x45,
-	0xa7, 0x64, 0xbf, 0x0b, 0xcd, 0xc4, 0x67, 0x54, 0x87, 0xf2, 0xfe, 0xe8, 0x40, 0x88, 0xbc, 0x18,
-	0x8e, 0x0e, 0x3a, 0x86, 0xfd, 0xbb, 0x01, 0x5d, 0x3d, 0x45, 0x74, 0x41, 0x02, 0x8a, 0xe3, 0x1c,
-	0x4d, 0x48, 0x14, 0x24, 0x39, 0xe2, 0x1b, 0x84, 0xa0, 0x12, 0xe0, 0xb7, 0x2a, 0x43, 0x7c, 0x1d,
-	0x73, 0x32, 0xc2, 0x5c, 0x9f, 0x67, 0xa7, 0xec, 0x88, 0x0d, 0xfa, 0x14, 0x1a, 0x32, 0x74, 0x6a,
-	0x56, 0x76, 0xcb, 0xbd, 0xd6, 0x60, 0x53, 0x07, 0x44, 0x5a, 0x74, 0x12, 0x36, 0xfb, 0x10, 0xb6,
-	0x0f, 0xb1, 0xf2, 0x44, 0xe0, 0xa5, 0x2a, 0x26, 0xb6, 0xeb, 0xce, 0x31, 0x77, 0x26, 0xb6, 0xeb,
-	0xce, 0x31, 0x32, 0xa1, 0x2e, 0xcb, 0x8d, 0xbb, 0x53, 0x75, 0xd4, 0xd6, 0x66, 0x60, 0xde, 0x56,
-	0x24, 0xe3, 0xca, 0xd2, 0xf4, 0x21, 0x54, 0xe2, 0x9b, 0xc0, 0xd5, 0xb4, 0x06, 0x48, 0xf7, 0xf3,
-	0x38, 0x98, 0x11, 0x87, 0xd3, 0xf5, 0x54, 0x95, 0x97, 0x53, 0x75, 0x94, 0xb6, 0x7a, 0x40, 0x02,
-	0x86, 0x03, 0xf6, 0x30, 0xff, 0x4f, 0x60, 0x27, 0x43, 0x93, 0x0c, 0x60, 0x0f, 0xea, 0xd2, 0x35,
-	0xae, 0x2d, 0x17, 0x57, 0xc5, 0x65, 0xff, 0x5d, 0x82, 0xee, 0xd9, 0x62, 0xea, 0x32, 0xac, 0x48,
-	0x77, 0x38, 0xf5, 0x18, 0xaa, 0xbc, 0xa3, 0x48, 0x2c, 0xd6, 0x85, 0x6e, 0xd1, 0x76, 0x0e, 0xe2,
-	0x5f, 0x47, 0xd0, 0xd1, 0x53, 0xa8, 0x5d, 0xb9, 0x7e, 0x84, 0x29, 0x07, 0x22, 0x41, 0x4d, 0x72,
-	0xf2, 0x76, 0xe4, 0x48, 0x0e, 0xb4, 0x0d, 0xf5, 0x69, 0x78, 0x1d, 0xf7, 0x13, 0x7e, 0x05, 0x1b,
-	0x4e, 0x6d, 0x1a, 0x5e, 0x3b, 0x51, 0x80, 0xde, 0x87, 0xb5, 0xa9, 0x47, 0xdd, 0x73, 0x1f, 0x8f,
-	0x2f, 0x09, 0x79, 0x43, 0xf9, 0x2d, 0x6c, 0x38, 0xab, 0xf2, 0xf0, 0x28, 0x3e, 0x43, 0x56, 0x5c,
-	0x49, 0x93, 0x10, 0xbb, 0x0c, 0x9b, 0x35, 0x4e, 0x4f, 0xf6, 0x31, 0x86, 0xcc, 0x9b, 0x63, 0x12,
-	0x31, 0x7e, 0x75, 0xca, 0x8e, 0xda, 0xa2, 0xf7, 0x60, 0x35, 0xc4, 0x14, 0xb3, 0xb1, 0xf4, 0xb2,
-	0xc1, 0x25, 0x5b, 0xfc, 0xec, 0xb5, 0x70, 0x0b, 0x41, 0xe5, 0x57, 0xd7, 0x63, 0x66, 0x93, 0x93,
-	0xf8, 0x5a, 0x88, 0x45, 0x14, 0x2b, 0x31, 0x50, 0x62, 0x11, 0xc5, 0x52, 0xac, 0x0b, 0xd5, 0x19,
-	0x09, 0x27, 0xd8, 0x6c, 0x71, 0x9a, 0xd8, 0xd8, 0x47, 0xb0, 0xb9, 0x04, 0xf2, 0x43, 0xf3, 0xf5,
-	0x8f, 0x01, 0x5b, 0x0e, 0xf1, 0xfd, 0x73, 0x77, 0xf2, 0xa6, 0x40, 0xc6, 0x52, 0xe0, 0x96, 0xee,
-	0x06, 0xb7, 0x9c, 0x01, 0x6e, 0xaa, 0x08, 0x2b, 0x5a, 0x11, 0x6a, 0xb0, 0x57, 0xf3, 0x61, 0xaf,
-	0xe9, 0xb0, 0x2b, 0x4c, 0xeb, 0x29, 0x4c, 0x13, 0xc0, 0x1a, 0x69, 0xc0, 0xbe, 0x86, 0xed, 0x5b,
-	0x51, 0x3e, 0x14, 0xb2, 0x3f, 0x4a, 0xb0, 0x79, 0x1c, 0x50, 0xe6, 0xfa, 0xfe, 0x12, 0x62, 0x49,
-	0x3d, 0x1b, 0x85, 0xeb, 0xb9, 0xf4, 0x5f, 0xea, 0xb9, 0xac, 0x41, 0xae, 0xf2, 0x53, 0x49, 0xe5,
-	0xa7, 0x50, 0x8d, 0x6b, 0x9d, 0xa5, 0xb6, 0xd4, 0x59, 0xd0, 0x3b, 0x00, 0xa2, 0x28, 0xb9, 0x72,
-	0x01, 0x6d, 0x93, 0x9f, 0x9c, 0xca, 0x46, 0xa2, 0xb2, 0xd1, 0xc8, 0xce, 0x46, 0xaa, 0xc2, 0xed,
-	0x63, 0xd8, 0x5a, 0x86, 0xea, 0xa1, 0xb0, 0xff, 0x66, 0xc0, 0xf6, 0x59, 0xe0, 0x65, 0x02, 0x9f,
-	0x55, 0xaa, 0xb7, 0xa0, 0x28, 0x65, 0x40, 0xd1, 0x85, 0xea, 0x22, 0x0a, 0x2f, 0xb0, 0x84, 0x56,
-	0x6c, 0xd2, 0x31, 0x56, 0xb4, 0x18, 0xed, 0x31, 0x98, 0xb7, 0x7d, 0x78, 0x60, 0x44, 0xb1, 0xd7,
-	0xc9, 0x4b, 0xd0, 0x14, 0x5d, 0xdf, 0xde, 0x80, 0xf5, 0x43, 0xcc, 0x5e, 0x8b, 0x6b, 0x21, 0xc3,
-	0xb3, 0x87, 0x80, 0xd2, 0x87, 0x37, 0xf6, 0xe4, 0x91, 0x6e, 0x4f, 0x8d, 0x45, 0x8a, 0x5f, 0x71,
-	0xd9, 0x5f, 0x70, 0xdd, 0x47, 0x1e, 0x65, 0x24, 0xbc, 0xbe, 0x0b, 0xba, 0x0e, 0x94, 0xe7, 0xee,
-	0x5b, 0xf9, 0x50, 0xc4, 0x4b, 0xfb, 0x90, 0x7b, 0x90, 0x88, 0x4a, 0x0f, 0xd2, 0xcf, 0xae, 0x51,
-	0xec, 0xd9, 0xfd, 0x01, 0xd0, 0x2b, 0x9c, 0x4c, 0x00, 0xf7, 0xbc, 0x58, 0x2a, 0x09, 0x25, 0xbd,
-	0xd0, 0x4c, 0xa8, 0x4f, 0x7c, 0xec, 0x06, 0xd1, 0x42, 0xa6, 0x4d, 0x6d, 0xed, 0x1f, 0x61, 0x43,
-	0xd3, 0x2e, 0xfd, 0x8c, 0xe3, 0xa1, 0x17, 0x52, 0x7b, 0xbc, 0x44, 0x9f, 0x43, 0x4d, 0x8c, 0x45,
-	0x5c, 0x77, 0x7b, 0xf0, 0x48, 0xf7, 0x9b, 0x2b, 0x89, 0x02, 0x39, 0x47, 0x39, 0x92, 0x77, 0xf0,
-	0x57, 0x03, 0xda, 0xea, 0xa1, 0x17, 0x43, 0x1b, 0xf2, 0x60, 0x35, 0x3d, 0xd1, 0xa0, 0x27, 0xf9,
-	0x33, 0xdd, 0xd2, 0x60, 0x6a, 0x3d, 0x2d, 0xc2, 0x2a, 0x22, 0xb0, 0x57, 0x3e, 0x31, 0x10, 0x85,
-	0xce, 0xf2, 0xa0, 0x81, 0x9e, 0x65, 0xeb, 0xc8, 0x99, 0x6c, 0xac, 0x7e, 0x51, 0x76, 0x65, 0x16,
-	0x5d, 0xf1, 0x9a, 0xd1, 0xa7, 0x03, 0x74, 0xaf, 0x1a, 0x7d, 0x20, 0xb1, 0xf6, 0x0a, 0xf3, 0x27,
-	0x76, 0x7f, 0x86, 0x35, 0xed, 0x85, 0x43, 0x39, 0x68, 0x65, 0xcd, 0x1a, 0xd6, 0x47, 0x85, 0x78,
-	0x13, 0x5b, 0x73, 0x68, 0xeb, 0x4d, 0x0a, 0xe5, 0x28, 0xc8, 0xec, 0xfa, 0xd6, 0xc7, 0xc5, 0x98,
-	0x13, 0x73, 0x14, 0x3a, 0xcb, 0x3d, 0x24, 0x2f, 0x8f, 0x39, 0xfd, 0x2e, 0x2f, 0x8f, 0x79, 0xad,
-	0xc9, 0x5e, 0x41, 0x2e, 0xc0, 0x4d, 0x0b, 0x41, 0x8f, 0x73, 0x13, 0xa2, 0x77, 0x1e, 0xab, 0x77,
-	0x3f, 0x63, 0x62, 0x62, 0x01, 0xff, 0x5b, 0x7a, 0x63, 0x51, 0x0e, 0x34, 0xd9, 0x03, 0x87, 0xf5,
-	0xac, 0x20, 0xf7, 0x52, 0x50, 0xb2, 0x2b, 0xdd, 0x11, 0x94, 0xde, 0xf2, 0xee, 0x08, 0x6a, 0xa9,
-	0xc1, 0xd9, 0x2b, 0xc8, 0x83, 0xb6, 0x13, 0x05, 0xd2, 0x74, 0xdc, 0x16, 0x50, 0x8e, 0xf4, 0xed,
-	0xae, 0x66, 0x3d, 0x29, 0xc0, 0x79, 0x73, 0xbf, 0x9f, 0xc3, 0xf7, 0x0d, 0xc5, 0x7a, 0x5e, 0xe3,
-	0xff, 0x69, 0x3f, 0xfb, 0x37, 0x00, 0x00, 0xff, 0xff, 0xf3, 0x7c, 0x9c, 0x49, 0xc1, 0x0f, 0x00,
-	0x00,
+	// 1710 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x58, 0x4f, 0x73, 0xe3, 0x48,
+	0x15, 0x5f, 0x59, 0xfe, 0xfb, 0xec, 0x78, 0x9c, 0x9e, 0x4c, 0xa2, 0xd1, 0x2e, 0x54, 0xd0, 0xd6,
+	0xb2, 0xde, 0x65, 0xc7, 0x03, 0x81, 0xc3, 0x52, 0xb5, 0x45, 0x95, 0x37, 0xf1, 0x24, 0x66, 0x43,
+	0x32, 0xd5, 0x4e, 0x76, 0x0a, 0x0a, 0x70, 0x29, 0x76, 0x3b, 0x11, 0x91, 0x25, 0xa1, 0x6e, 0x85,
+	0x49, 0xd5, 0x9e, 0xb8, 0xc1, 0x91, 0x03, 0x5f, 0x80, 0x13, 0x27, 0xbe, 0x02, 0x5f, 0x84, 0x1b,
+	0x1f, 0x84, 0xea, 0x7f, 0x8a, 0xe4, 0x58, 0x8e, 0x13, 0x2e, 0xb1, 0x5e, 0xbf, 0x5f, 0xbf, 0xf7,
+	0xfa, 0xfd, 0xfa, 0x75, 0xbf, 0x0e, 0xd8, 0x57, 0x6e, 0xe4, 0xbd, 0xa6, 0x24, 0xbe, 0xf1, 0x26,
+	0x84, 0xbe, 0x66, 0x9e, 0xef, 0x93, 0xb8, 0x17, 0xc5, 0x21, 0x0b, 0xd1, 0x16, 0xd7, 0xf5, 0xb4,
+	0xae, 0x27, 0x75, 0xf6, 0xb6, 0x98, 0x31, 0xb9, 0x72, 0x63, 0x26, 0xff, 0x4a, 0xb4, 0xbd, 0x93,
+	0x1d, 0x0f, 0x83, 0x99, 0x77, 0xa9, 0x14, 0xd2, 0x45, 0x4c, 0x7c, 0xe2, 0x52, 0xa2, 0x7f, 0x73,
+	0x93, 0xb4, 0xce, 0x0b, 0x66, 0xa1, 0x52, 0x7c, 0x98, 0x53, 0x30, 0x42, 0xd9, 0x38, 0x4e, 0x02,
+	0xa5, 0x7c, 0x99, 0x53, 0x52, 0xe6, 0xb2, 0x84, 0xe6, 0x9c, 0xdd, 0x90, 0x98, 0x7a, 0x61, 0xa0,
+	0x7f, 0xa5, 0xce, 0xf9, 0x77, 0x09, 0x9e, 0x1f, 0x7b, 0x94, 0x61, 0x39, 0x91, 0x62, 0xf2, 0xc7,
+	0x84, 0x50, 0x86, 0xb6, 0xa0, 0xe2, 0x7b, 0x73, 0x8f, 0x59, 0xc6, 0xae, 0xd1, 0x35, 0xb1, 0x14,
+	0xd0, 0x36, 0x54, 0xc3, 0xd9, 0x8c, 0x12, 0x66, 0x95, 0x76, 0x8d, 0x6e, 0x03, 0x2b, 0x09, 0xfd,
+	0x02, 0x6a, 0x34, 0x8c, 0xd9, 0xf8, 0xe2, 0xd6, 0x32, 0x77, 0x8d, 0x6e, 0x7b, 0xef, 0x93, 0xde,
+	0xb2, 0x3c, 0xf5, 0xb8, 0xa7, 0x51, 0x18, 0xb3, 0x1e, 0xff, 0xf3, 0xf5, 0x2d, 0xae, 0x52, 0xf1,
+	0xcb, 0xed, 0xce, 0x3c, 0x9f, 0x91, 0xd8, 0x2a, 0x4b, 0xbb, 0x52, 0x42, 0x87, 0x00, 0xc2, 0x6e,
+	0x18, 0x4f, 0x49, 0x6c, 0x55, 0x84, 0xe9, 0xee, 0x1a, 0xa6, 0x4f, 0x39, 0x1e, 0x37, 0xa8, 0xfe,
+	0x44, 0x5f, 0x41, 0x4b, 0xa6, 0x64, 0x3c, 0x09, 0xa7, 0x84, 0x5a, 0xd5, 0x5d, 0xb3, 0xdb, 0xde,
+	0x7b, 0x29, 0x4d, 0xe9, 0xf4, 0x8f, 0x64, 0xd2, 0xf6, 0xc3, 0x29, 0xc1, 0x4d, 0x09, 0xe7, 0xdf,
+	0x14, 0x7d, 0x04, 0x8d, 0xc0, 0x9d, 0x13, 0x1a, 0xb9, 0x13, 0x62, 0xd5, 0x44, 0x84, 0x77, 0x03,
+	0xce, 0xef, 0xa1, 0xae, 0x9d, 0x3b, 0x7b, 0x50, 0x95, 0x4b, 0x43, 0x4d, 0xa8, 0x9d, 0x9f, 0x7c,
+	0x73, 0x72, 0xfa, 0xee, 0xa4, 0xf3, 0x01, 0xaa, 0x43, 0xf9, 0xa4, 0xff, 0xab, 0x41, 0xc7, 0x40,
+	0x9b, 0xb0, 0x71, 0xdc, 0x1f, 0x9d, 0x8d, 0xf1, 0xe0, 0x78, 0xd0, 0x1f, 0x0d, 0x0e, 0x3a, 0x25,
+	0xe7, 0xfb, 0xd0, 0x48, 0x63, 0x46, 0x35, 0x30, 0xfb, 0xa3, 0x7d, 0x39, 0xe5, 0x60, 0x30, 0xda,
+	0xef, 0x18, 0xce, 0x5f, 0x0c, 0xd8, 0xca, 0x53, 0x44, 0xa3, 0x30, 0xa0, 0x84, 0x73, 0x34, 0x09,
+	0x93, 0x20, 0xe5, 0x48, 0x08, 0x08, 0x41, 0x39, 0x20, 0xef, 0x35, 0x43, 0xe2, 0x9b, 0x23, 0x59,
+	0xc8, 0x5c, 0x5f, 0xb0, 0x63, 0x62, 0x29, 0xa0, 0x9f, 0x40, 0x5d, 0x2d, 0x9d, 0x5a, 0xe5, 0x5d,
+	0xb3, 0xdb, 0xdc, 0x7b, 0x91, 0x4f, 0x88, 0xf2, 0x88, 0x53, 0x98, 0x73, 0x08, 0x3b, 0x87, 0x44,
+	0x47, 0x22, 0xf3, 0xa5, 0x77, 0x0c, 0xf7, 0xeb, 0xce, 0x89, 0x65, 0x28, 0xbf, 0xee, 0x9c, 0x20,
+	0x0b, 0x6a, 0x6a, 0xbb, 0x89, 0x70, 0x2a, 0x58, 0x8b, 0x0e, 0x03, 0xeb, 0xbe, 0x21, 0xb5, 0xae,
+	0x65, 0x96, 0x7e, 0x08, 0x65, 0x5e, 0x09, 0xc2, 0x4c, 0x73, 0x0f, 0xe5, 0xe3, 0x1c, 0x06, 0xb3,
+	0x10, 0x0b, 0x7d, 0x9e, 0x2a, 0x73, 0x91, 0xaa, 0xa3, 0xac, 0xd7, 0xfd, 0x30, 0x60, 0x24, 0x60,
+	0x4f, 0x8b, 0xff, 0x18, 0x5e, 0x2e, 0xb1, 0xa4, 0x16, 0xf0, 0x1a, 0x6a, 0x2a, 0x34, 0x61, 0xad,
+	0x30, 0xaf, 0x1a, 0xe5, 0xfc, 0xc7, 0x84, 0xad, 0xf3, 0x68, 0xea, 0x32, 0xa2, 0x55, 0x2b, 0x82,
+	0xfa, 0x14, 0x2a, 0xe2, 0x44, 0x51, 0xb9, 0xd8, 0x94, 0xb6, 0xc5, 0x50, 0x6f, 0x9f, 0xff, 0xc5,
+	0x52, 0x8f, 0x3e, 0x87, 0xea, 0x8d, 0xeb, 0x27, 0x84, 0x5a, 0x66, 0x36, 0x6b, 0x0a, 0x29, 0x8e,
+	0x23, 0xac, 0x10, 0x68, 0x07, 0x6a, 0xd3, 0xf8, 0x96, 0x9f, 0x27, 0xa2, 0x04, 0xeb, 0xb8, 0x3a,
+	0x8d, 0x6f, 0x71, 0x12, 0xa0, 0x8f, 0x61, 0x63, 0xea, 0x51, 0xf7, 0xc2, 0x27, 0xe3, 0xab, 0x30,
+	0xbc, 0xa6, 0xa2, 0x0a, 0xeb, 0xb8, 0xa5, 0x06, 0x8f, 0xf8, 0x18, 0xb2, 0xf9, 0x4e, 0x9a, 0xc4,
+	0xc4, 0x65, 0xc4, 0xaa, 0x0a, 0x7d, 0x2a, 0xf3, 0x1c, 0x32, 0x6f, 0x4e, 0xc2, 0x84, 0x89, 0xd2,
+	0x31, 0xb1, 0x16, 0xd1, 0x0f, 0xa0, 0x15, 0x13, 0x4a, 0xd8, 0x58, 0x45, 0x59, 0x17, 0x33, 0x9b,
+	0x62, 0xec, 0x5b, 0x19, 0x16, 0x82, 0xf2, 0x9f, 0x5c, 0x8f, 0x59, 0x0d, 0xa1, 0x12, 0xdf, 0x72,
+	0x5a, 0x42, 0x89, 0x9e, 0x06, 0x7a, 0x5a, 0x42, 0x89, 0x9a, 0xb6, 0x05, 0x95, 0x59, 0x18, 0x4f,
+	0x88, 0xd5, 0x14, 0x3a, 0x29, 0xa0, 0x37, 0xd0, 0x9a, 0xb8, 0x91, 0x7b, 0xe1, 0xf9, 0x1e, 0xf3,
+	0x08, 0xb5, 0x5a, 0x22, 0x2b, 0xce, 0xf2, 0xf3, 0x64, 0x3f, 0x83, 0xc4, 0xb9, 0x79, 0xdc, 0xba,
+	0x3b, 0x0d, 0x23, 0x66, 0x6d, 0x48, 0xeb, 0x42, 0xe0, 0x39, 0xb8, 0x71, 0x7d, 0x8f, 0x93, 0x68,
+	0xb5, 0x65, 0x0e, 0xb4, 0xec, 0x1c, 0xc1, 0x8b, 0x05, 0x7a, 0x9f, 0xba, 0x53, 0xfe, 0x6b, 0xc0,
+	0x36, 0x0e, 0x7d, 0xff, 0xc2, 0x9d, 0x5c, 0xaf, 0xb1, 0x57, 0x32, 0xb4, 0x96, 0x56, 0xd3, 0x6a,
+	0x2e, 0xa1, 0x35, 0xb3, 0xfd, 0xcb, 0xb9, 0xed, 0x9f, 0x23, 0xbc, 0x52, 0x4c, 0x78, 0x35, 0x4f,
+	0xb8, 0x66, 0xb3, 0x96, 0x61, 0x33, 0xa5, 0xaa, 0x9e, 0xa1, 0xca, 0xf9, 0x25, 0xec, 0xdc, 0x5b,
+	0xe5, 0x53, 0x53, 0xf6, 0x0f, 0x13, 0x5e, 0x0c, 0x03, 0xca, 0x5c, 0xdf, 0x5f, 0xc8, 0x58, 0x5a,
+	0x49, 0xc6, 0xda, 0x95, 0x54, 0x7a, 0x4c, 0x25, 0x99, 0xb9, 0x94, 0x6b, 0x7e, 0xca, 0x19, 0x7e,
+	0xd6, 0xaa, 0xae, 0xdc, 0x99, 0x56, 0x5d, 0x38, 0xd3, 0xd0, 0xf7, 0x00, 0x64, 0x39, 0x08, 0xe3,
+	0x32, 0xb5, 0x0d, 0x31, 0x72, 0xa2, 0x8e, 0x30, 0xcd, 0x46, 0x7d, 0x39, 0x1b, 0xd9, 0xda, 0x5a,
+	0x2c, 0x11, 0xf8, 0x7f, 0x4b, 0xa4, 0x59, 0x54, 0x22, 0xad, 0x85, 0x12, 0x19, 0xc2, 0xf6, 0x22,
+	0x49, 0x4f, 0x25, 0xfc, 0xcf, 0x06, 0xec, 0x9c, 0x07, 0xde, 0x52, 0xca, 0x97, 0x15, 0xc9, 0x3d,
+	0x12, 0x4a, 0x4b, 0x48, 0xd8, 0x82, 0x4a, 0x94, 0xc4, 0x97, 0x44, 0x91, 0x2a, 0x85, 0x6c, 0x76,
+	0xcb, 0xb9, 0xec, 0x3a, 0x63, 0xb0, 0xee, 0xc7, 0xf0, 0xc4, 0x15, 0xf1, 0xa8, 0xd3, 0xdb, 0xaf,
+	0x21, 0x6f, 0x3a, 0xe7, 0x39, 0x6c, 0x1e, 0x12, 0xf6, 0xad, 0x2c, 0x48, 0xb5, 0x3c, 0x67, 0x00,
+	0x28, 0x3b, 0x78, 0xe7, 0x4f, 0x0d, 0xe5, 0xfd, 0xe9, 0x56, 0x50, 0xe3, 0x35, 0xca, 0xf9, 0xb9,
+	0xb0, 0x7d, 0xe4, 0x51, 0x16, 0xc6, 0xb7, 0xab, 0x52, 0xd7, 0x01, 0x73, 0xee, 0xbe, 0x57, 0x97,
+	0x23, 0xff, 0x74, 0x0e, 0x01, 0x65, 0xa7, 0xaa, 0x08, 0xb2, 0xad, 0x86, 0xb1, 0x5e, 0xab, 0xf1,
+	0x5b, 0x40, 0x67, 0x24, 0xed, 0x7a, 0x1e, 0xb8, 0xa5, 0x35, 0x09, 0xa5, 0xfc, 0x16, 0xb7, 0xa0,
+	0x36, 0xf1, 0x89, 0x1b, 0x24, 0x91, 0xa2, 0x4d, 0x8b, 0xce, 0xef, 0xe0, 0x79, 0xce, 0xba, 0x8a,
+	0x93, 0xaf, 0x87, 0x5e, 0x2a, 0xeb, 0xfc, 0x13, 0xfd, 0x0c, 0xaa, 0xb2, 0x15, 0x14, 0xb6, 0xdb,
+	0x7b, 0x1f, 0xe5, 0xe3, 0x16, 0x46, 0x92, 0x40, 0xf5, 0x8e, 0x58, 0x61, 0x9d, 0xbf, 0x97, 0xa0,
+	0xa5, 0x6c, 0x0f, 0x6e, 0x48, 0xc0, 0x3b, 0xe4, 0x4a, 0x74, 0xa5, 0x09, 0x2f, 0x6c, 0x62, 0xb3,
+	0x53, 0x7a, 0x6f, 0x39, 0x1e, 0xcb, 0x69, 0x7c, 0xdd, 0x7c, 0x6f, 0xea, 0x1d, 0xc0, 0xbf, 0xe5,
+	0x21, 0x4c, 0xc3, 0x24, 0x4e, 0x5b, 0x9d, 0x54, 0xe6, 0x2b, 0x9f, 0x13, 0x4a, 0xdd, 0x4b, 0x7d,
+	0xde, 0x68, 0xd1, 0xf9, 0x0e, 0x2a, 0xc2, 0x72, 0xbe, 0x43, 0xdd, 0x84, 0x8d, 0xa3, 0xd3, 0xd3,
+	0x6f, 0x46, 0xe3, 0xd1, 0x59, 0x1f, 0x9f, 0x0d, 0x0e, 0x3a, 0x06, 0x42, 0xd0, 0x96, 0x43, 0x6f,
+	0x86, 0x27, 0xc3, 0xd1, 0x11, 0xef, 0x55, 0xd1, 0x0b, 0xd8, 0xc4, 0x83, 0xd1, 0xe9, 0x39, 0xde,
+	0x1f, 0x8c, 0xc6, 0xfd, 0xb7, 0x6f, 0x8f, 0x87, 0x83, 0x83, 0x8e, 0x89, 0xb6, 0xa0, 0xa3, 0x87,
+	0xc7, 0xef, 0xfa, 0xc3, 0xb3, 0xe1, 0xc9, 0x61, 0xa7, 0xcc, 0x0d, 0xa4, 0xa3, 0x78, 0xd0, 0x3f,
+	0xf8, 0x75, 0xa7, 0xe2, 0x7c, 0x07, 0xcf, 0xd4, 0x22, 0xdf, 0xc6, 0xe1, 0x65, 0x4c, 0x28, 0x45,
+	0x5f, 0x42, 0x85, 0xf0, 0x05, 0x5b, 0xc6, 0xaa, 0xc3, 0x26, 0x9b, 0x1a, 0x2c, 0x27, 0x64, 0xeb,
+	0xa8, 0xb4, 0xd6, 0xc9, 0xf0, 0x05, 0x6c, 0xdf, 0x75, 0x6d, 0x07, 0xb1, 0x37, 0x5b, 0xd5, 0xfd,
+	0x39, 0x67, 0x00, 0x6f, 0x3c, 0xe2, 0x4f, 0x05, 0x90, 0x23, 0x22, 0x97, 0x5d, 0x69, 0x04, 0xff,
+	0xe6, 0x0c, 0x90, 0xf7, 0x11, 0x99, 0x30, 0x32, 0x55, 0xcc, 0xa4, 0x32, 0x7f, 0xd3, 0xb8, 0x13,
+	0x96, 0xa8, 0xa6, 0xbb, 0x81, 0x95, 0xe4, 0xfc, 0xd3, 0x80, 0x0d, 0xac, 0x68, 0x4a, 0x2d, 0x5f,
+	0x7b, 0xc1, 0x54, 0x5b, 0xe6, 0xdf, 0xf9, 0x33, 0xbf, 0xb4, 0x78, 0xe6, 0xeb, 0x68, 0xcd, 0x7c,
+	0x15, 0xcc, 0x3d, 0x4a, 0xbd, 0xe0, 0x52, 0x75, 0x70, 0x5a, 0x44, 0x5f, 0xf2, 0xd7, 0x15, 0xf1,
+	0xa7, 0xfc, 0x76, 0xe1, 0xa5, 0xb7, 0xbb, 0x3c, 0xc3, 0x77, 0x6b, 0xc5, 0x0a, 0xef, 0xfc, 0xd5,
+	0xc8, 0xf6, 0xfb, 0x52, 0xb7, 0xaa, 0x4b, 0x2f, 0xec, 0x97, 0x51, 0x1f, 0x1a, 0x7a, 0x6f, 0xf2,
+	0x5e, 0x83, 0x87, 0xf1, 0x71, 0x11, 0xd1, 0x99, 0xdc, 0xe0, 0xbb, 0x59, 0xce, 0xdf, 0x0c, 0x68,
+	0x65, 0xaf, 0x1c, 0xde, 0x08, 0xba, 0x91, 0x37, 0x56, 0x2e, 0xe4, 0xc1, 0xd2, 0xc0, 0x4d, 0x37,
+	0xf2, 0xd4, 0x39, 0x26, 0x20, 0xd7, 0xc9, 0x05, 0x19, 0x67, 0xa3, 0x6a, 0xe0, 0x26, 0x1f, 0x53,
+	0x18, 0xf4, 0x15, 0xb4, 0xa5, 0xe7, 0x14, 0x64, 0xae, 0x3a, 0x23, 0x37, 0x24, 0x58, 0x89, 0x7b,
+	0xff, 0x6a, 0x41, 0x5b, 0xbf, 0x62, 0xe4, 0x42, 0x90, 0x07, 0xad, 0xec, 0x73, 0x0d, 0x7d, 0x56,
+	0xfc, 0x60, 0x5d, 0x78, 0x75, 0xdb, 0x9f, 0xaf, 0x03, 0x95, 0xf9, 0x77, 0x3e, 0xf8, 0xb1, 0x81,
+	0x28, 0x74, 0x16, 0x5f, 0x51, 0xe8, 0xd5, 0x72, 0x1b, 0x05, 0xcf, 0x36, 0xbb, 0xb7, 0x2e, 0x5c,
+	0xbb, 0x45, 0x37, 0xb0, 0x79, 0xa7, 0x55, 0x4f, 0x1f, 0xf4, 0xa0, 0x99, 0xfc, 0x6b, 0xcb, 0x7e,
+	0xbd, 0x36, 0x3e, 0xf5, 0xfb, 0x07, 0xd8, 0xc8, 0x35, 0xd1, 0xa8, 0x20, 0x5b, 0xcb, 0x1e, 0x52,
+	0xf6, 0x8f, 0xd6, 0xc2, 0xa6, 0xbe, 0xe6, 0xd0, 0xce, 0x77, 0x23, 0xa8, 0xc0, 0xc0, 0xd2, 0xc6,
+	0xd2, 0xfe, 0x62, 0x3d, 0x70, 0xea, 0x8e, 0x42, 0x67, 0xb1, 0x59, 0x28, 0xe2, 0xb1, 0xa0, 0xb1,
+	0xb1, 0x7b, 0xeb, 0xc2, 0x53, 0xa7, 0x2e, 0xc0, 0x5d, 0xaf, 0x80, 0x3e, 0x2d, 0x24, 0x24, 0xdf,
+	0x62, 0xd8, 0xdd, 0x87, 0x81, 0xa9, 0x8b, 0x08, 0x9e, 0x2d, 0xb4, 0xf1, 0xa8, 0x20, 0x35, 0xcb,
+	0xdf, 0x34, 0xf6, 0xab, 0x35, 0xd1, 0x0b, 0x8b, 0x52, 0xed, 0xc7, 0x8a, 0x45, 0xe5, 0x7b, 0x1b,
+	0xbb, 0xfb, 0x30, 0x30, 0x75, 0xe1, 0x41, 0x1b, 0x27, 0x81, 0x72, 0xcd, 0xef, 0x7f, 0x54, 0x30,
+	0xfb, 0x7e, 0xfb, 0x62, 0x7f, 0xb6, 0x06, 0x32, 0x53, 0xdf, 0x0c, 0xec, 0xfc, 0x9e, 0x79, 0xe7,
+	0xb1, 0xab, 0xf4, 0xe2, 0x7c, 0xd4, 0x96, 0xfc, 0x64, 0xe5, 0xb5, 0xaa, 0x6d, 0x0a, 0xaf, 0x31,
+	0xbc, 0xcc, 0xd5, 0x45, 0xce, 0xe9, 0x63, 0x8a, 0xee, 0x11, 0x3e, 0x6f, 0xe0, 0xc3, 0x05, 0x52,
+	0x73, 0x5e, 0x1f, 0xb7, 0x6b, 0x1e, 0xe1, 0x37, 0x82, 0x67, 0x0b, 0x17, 0x5c, 0x91, 0xaf, 0xe5,
+	0x8d, 0x83, 0xfd, 0x6a, 0x4d, 0xb4, 0x66, 0xf5, 0x6b, 0xf8, 0x4d, 0x5d, 0x83, 0x2f, 0xaa, 0xe2,
+	0x9f, 0xb0, 0x3f, 0xfd, 0xdf, 0x00, 0x47, 0xcd, 0xcb, 0x2d, 0x72, 0x16, 0x00, 0x00,
 }
@@ -0,0 +1,179 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	pgperrors "golang.org/x/crypto/openpgp/errors"
+)
+
+// messageType is the armor type of an encrypted OpenPGP message.
+const messageType = "PGP MESSAGE"
+
+// IsEncrypted reports whether data is an ASCII-armored OpenPGP message, such
+// as one written by Encrypt or by `gpg --armor --encrypt`.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN "+messageType+"-----"))
+}
+
+// Encrypt encrypts data for the given recipients, or for the Signatory's
+// Entity if there are none, and returns it as an ASCII-armored OpenPGP message.
+//
+// Only the public keys of the recipients are needed.
+func (s *Signatory) Encrypt(data []byte, recipients ...*openpgp.Entity) ([]byte, error) {
+	if len(recipients) == 0 {
+		if s.Entity == nil {
+			return nil, errors.New("key not found")
+		}
+		recipients = []*openpgp.Entity{s.Entity}
+	}
+
+	out := bytes.NewBuffer(nil)
+	w, err := armor.Encode(out, messageType, nil)
+	if err != nil {
+		return nil, err
+	}
+	pw, err := openpgp.Encrypt(w, recipients, nil, nil, &defaultPGPConfig)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := pw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := pw.Close(); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
+
+// Decrypt decrypts an ASCII-armored OpenPGP message with the private keys in
+// the keyring.
+//
+// If the private key the message is encrypted for is itself encrypted, the
+// PassphraseFetcher is called to unlock it. On success, the Signatory's
+// Entity is set to the key that decrypted the message, and the IDs of all the
+// keys the message is encrypted for are returned with the plain text, so that
+// it can be encrypted again for the same keys.
+func (s *Signatory) Decrypt(data []byte, fn PassphraseFetcher) ([]byte, []uint64, error) {
+	block, err := armor.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode message: %s", err)
+	}
+	if block.Type != messageType {
+		return nil, nil, fmt.Errorf("expected a %s block, got %s", messageType, block.Type)
+	}
+
+	prompt := func(keys []openpgp.Key, symmetric bool) ([]byte, error) {
+		for _, k := range keys {
+			if k.PrivateKey == nil || !k.PrivateKey.Encrypted {
+				continue
+			}
+			p, err := fn(entityName(k.Entity))
+			if err != nil {
+				return nil, err
+			}
+			if err := k.PrivateKey.Decrypt(p); err == nil {
+				return nil, nil
+			}
+		}
+		return nil, errors.New("cannot unlock the private key")
+	}
+
+	md, err := openpgp.ReadMessage(block.Body, s.KeyRing, prompt, &defaultPGPConfig)
+	if err != nil {
+		if err == pgperrors.ErrKeyIncorrect {
+			return nil, nil, errors.New("no private key in the keyring can decrypt the message")
+		}
+		return nil, nil, err
+	}
+	// The integrity of the message is checked once it has been read in full.
+	plain, err := ioutil.ReadAll(md.UnverifiedBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	if md.DecryptedWith.Entity != nil {
+		s.Entity = md.DecryptedWith.Entity
+	}
+	return plain, md.EncryptedToKeyIds, nil
+}
+
+// Recipients looks up the keys with the given IDs, as returned by Decrypt, in
+// the Signatory's keyring, and then in the keyring file for those that are not
+// found there.
+//
+// The keyring file is only read if needed. It is an error if a key cannot be
+// found, since encrypting without it would lock its owner out.
+func (s *Signatory) Recipients(ids []uint64, keyringfile string) (openpgp.EntityList, error) {
+	var (
+		recipients openpgp.EntityList
+		ring       openpgp.EntityList
+		loaded     bool
+	)
+	for _, id := range ids {
+		keys := s.KeyRing.KeysById(id)
+		if len(keys) == 0 {
+			if !loaded {
+				var err error
+				if ring, err = loadKeyRing(keyringfile); err != nil {
+					return nil, fmt.Errorf("cannot look up the key %X: %s", id, err)
+				}
+				loaded = true
+			}
+			keys = ring.KeysById(id)
+		}
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("the key %X is not in %s", id, keyringfile)
+		}
+		if !containsEntity(recipients, keys[0].Entity) {
+			recipients = append(recipients, keys[0].Entity)
+		}
+	}
+	return recipients, nil
+}
+
+func containsEntity(list openpgp.EntityList, e *openpgp.Entity) bool {
+	for _, x := range list {
+		if x.PrimaryKey.KeyId == e.PrimaryKey.KeyId {
+			return true
+		}
+	}
+	return false
+}
+
+// entityName returns the first identity of an entity, typically of the form
+//
+//	USER_NAME (COMMENT) <EMAIL>
+func entityName(e *openpgp.Entity) string {
+	if e != nil {
+		for i := range e.Identities {
+			if i != "" {
+				return i
+			}
+		}
+	}
+	return "Unknown"
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSecret = "password: hunter2\n"
+
+func TestEncryptDecrypt(t *testing.T) {
+	// Only the public key is needed to encrypt.
+	enc, err := NewFromKeyring(testPubfile, "helm-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := enc.Encrypt([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(data) {
+		t.Fatalf("Expected an armored message, got %q", data)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Fatal("Expected the secret to be encrypted")
+	}
+
+	dec, err := NewFromKeyring(testKeyfile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, _, err := dec.Decrypt(data, func(string) ([]byte, error) {
+		t.Fatal("Expected no passphrase to be needed")
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plain) != testSecret {
+		t.Errorf("Expected %q, got %q", testSecret, plain)
+	}
+	if _, ok := dec.Entity.Identities[testKeyName]; !ok {
+		t.Errorf("Expected the decrypting key to be selected, got %v", dec.Entity)
+	}
+
+	// Without the private key, the message cannot be read.
+	if _, _, err := enc.Decrypt(data, nil); err == nil || !strings.Contains(err.Error(), "no private key") {
+		t.Errorf("Expected a missing key error, got %v", err)
+	}
+}
+
+func TestDecryptWithPassphrase(t *testing.T) {
+	s, err := NewFromKeyring(testPasswordKeyfile, testPasswordKeyName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := s.Encrypt([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = NewFromKeyring(testPasswordKeyfile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Decrypt(data, func(string) ([]byte, error) {
+		return []byte("secrets_and_lies"), nil
+	}); err == nil {
+		t.Error("Expected a wrong passphrase to fail")
+	}
+
+	var asked string
+	plain, _, err := s.Decrypt(data, func(name string) ([]byte, error) {
+		asked = name
+		return []byte("secret"), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plain) != testSecret {
+		t.Errorf("Expected %q, got %q", testSecret, plain)
+	}
+	if asked != testPasswordKeyName {
+		t.Errorf("Expected the passphrase for %q to be requested, got %q", testPasswordKeyName, asked)
+	}
+}
+
+func TestEncryptRecipients(t *testing.T) {
+	s, err := NewFromKeyring(testPubfile, "helm-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewFromKeyring(testPasswordKeyfile, testPasswordKeyName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := s.Encrypt([]byte(testSecret), s.Entity, other.Entity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dec, err := NewFromKeyring(testKeyfile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, ids, err := dec.Decrypt(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plain) != testSecret {
+		t.Errorf("Expected %q, got %q", testSecret, plain)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("Expected the message to be encrypted for 2 keys, got %v", ids)
+	}
+
+	// The other recipient is not in the keyring that decrypted the message.
+	if _, err := dec.Recipients(ids, testKeyfile); err == nil || !strings.Contains(err.Error(), "is not in") {
+		t.Errorf("Expected a missing key error, got %v", err)
+	}
+	recipients, err := dec.Recipients(ids, testPasswordKeyfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recipients) != 2 {
+		t.Fatalf("Expected 2 recipients, got %d", len(recipients))
+	}
+	for i, name := range []string{testKeyName, testPasswordKeyName} {
+		if _, ok := recipients[i].Identities[name]; !ok {
+			t.Errorf("Expected recipient %d to be %q, got %v", i, name, recipients[i].Identities)
+		}
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	s, err := NewFromKeyring(testKeyfile, "helm-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Decrypt([]byte(testSecret), nil); err == nil {
+		t.Error("Expected plain text to be rejected")
+	}
+	if IsEncrypted([]byte(testSecret)) {
+		t.Error("Expected plain text not to be reported as encrypted")
+	}
+
+	data, err := s.Encrypt([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Flip a character in the middle of the armored body.
+	lines := strings.Split(string(data), "\n")
+	mid := lines[len(lines)/2]
+	b := []byte(mid)
+	if b[0] == 'A' {
+		b[0] = 'B'
+	} else {
+		b[0] = 'A'
+	}
+	lines[len(lines)/2] = string(b)
+	if _, _, err := s.Decrypt([]byte(strings.Join(lines, "\n")), nil); err == nil {
+		t.Error("Expected a tampered message to be rejected")
+	}
+}
@@ -179,15 +179,7 @@ func (s *Signatory) DecryptKey(fn PassphraseFetcher) error {
 		return nil
 	}
 
-	fname := "Unknown"
-	for i := range s.Entity.Identities {
-		if i != "" {
-			fname = i
-			break
-		}
-	}
-
-	p, err := fn(fname)
+	p, err := fn(entityName(s.Entity))
 	if err != nil {
 		return err
 	}
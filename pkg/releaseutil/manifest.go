@@ -19,6 +19,7 @@ package releaseutil
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -58,3 +59,16 @@ func SplitManifests(bigFile string) map[string]string {
 	}
 	return res
 }
+
+// BySplitManifestsOrder sorts the names that SplitManifests gives to
+// manifests in the order of the manifests in the file, so that "manifest-10"
+// comes after "manifest-2".
+type BySplitManifestsOrder []string
+
+func (a BySplitManifestsOrder) Len() int      { return len(a) }
+func (a BySplitManifestsOrder) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a BySplitManifestsOrder) Less(i, j int) bool {
+	anum, _ := strconv.Atoi(strings.TrimPrefix(a[i], "manifest-"))
+	bnum, _ := strconv.Atoi(strings.TrimPrefix(a[j], "manifest-"))
+	return anum < bnum
+}
@@ -18,6 +18,7 @@ package releaseutil // import "k8s.io/helm/pkg/releaseutil"
 
 import (
 	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -59,3 +60,12 @@ func TestSplitManifest(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, manifests)
 	}
 }
+
+func TestBySplitManifestsOrder(t *testing.T) {
+	names := []string{"manifest-10", "manifest-2", "manifest-0", "manifest-1"}
+	sort.Sort(BySplitManifestsOrder(names))
+	expected := []string{"manifest-0", "manifest-1", "manifest-2", "manifest-10"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+var (
+	docStart   = regexp.MustCompile(`^---`)
+	secretKind = regexp.MustCompile(`(?m)^kind:\s*["']?Secret["']?\s*$`)
+	secretData = regexp.MustCompile(`^(data|stringData):\s*(.*)$`)
+	dataEntry  = regexp.MustCompile(`^(\s+)([^\s#:][^:]*):`)
+)
+
+// Redactor masks the sensitive values of a release, which its chart marks as
+// sensitive (see chartutil.SensitivePaths), wherever they are printed. It also
+// masks the data of Secrets in manifests.
+//
+// A nil Redactor masks nothing, so that callers can pass one around
+// regardless of whether sensitive values are shown.
+type Redactor struct {
+	paths   []string
+	secrets []string
+}
+
+// NewRedactor returns a Redactor for the values of a release.
+func NewRedactor(rel *rspb.Release) (*Redactor, error) {
+	r := &Redactor{}
+	if rel.Chart == nil {
+		return r, nil
+	}
+	r.paths = chartutil.SensitivePaths(rel.Chart)
+	if len(r.paths) == 0 {
+		return r, nil
+	}
+	vals, err := chartutil.CoalesceValues(rel.Chart, rel.Config)
+	if err != nil {
+		return nil, err
+	}
+	_, r.secrets = chartutil.RedactValues(vals, r.paths)
+	// Longer strings are masked first, so that the strings they contain do
+	// not break them up.
+	sort.Sort(byLength(r.secrets))
+	return r, nil
+}
+
+// Values returns a copy of vals with the sensitive values masked.
+func (r *Redactor) Values(vals map[string]interface{}) map[string]interface{} {
+	if r == nil || len(r.paths) == 0 {
+		return vals
+	}
+	out, _ := chartutil.RedactValues(vals, r.paths)
+	return out
+}
+
+// Origins returns a copy of origins with the sensitive values masked.
+func (r *Redactor) Origins(origins []chartutil.ValueOrigin) []chartutil.ValueOrigin {
+	if r == nil || len(r.paths) == 0 {
+		return origins
+	}
+	return chartutil.RedactOrigins(origins, r.paths)
+}
+
+// Config returns a copy of cfg with the sensitive values masked.
+func (r *Redactor) Config(cfg *chart.Config) (*chart.Config, error) {
+	if r == nil || len(r.paths) == 0 || cfg == nil {
+		return cfg, nil
+	}
+	vals, err := chartutil.ReadValues([]byte(cfg.Raw))
+	if err != nil {
+		return nil, err
+	}
+	data, err := yaml.Marshal(r.Values(vals))
+	if err != nil {
+		return nil, err
+	}
+	return &chart.Config{Raw: string(data)}, nil
+}
+
+// Text masks the sensitive values in a text, such as notes or a log message.
+func (r *Redactor) Text(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, secret := range r.secrets {
+		s = strings.Replace(s, secret, chartutil.SensitiveMask, -1)
+	}
+	return s
+}
+
+// Manifest masks the data of the Secrets in a manifest, and the sensitive
+// values anywhere else in it.
+func (r *Redactor) Manifest(manifest string) string {
+	if r == nil {
+		return manifest
+	}
+	return r.Text(redactSecrets(manifest))
+}
+
+// Info returns a copy of info with the sensitive values masked in its
+// description, notes and resources.
+func (r *Redactor) Info(info *rspb.Info) *rspb.Info {
+	if r == nil || info == nil {
+		return info
+	}
+	out := *info
+	out.Description = r.Text(info.Description)
+	if info.Status != nil {
+		status := *info.Status
+		status.Notes = r.Text(status.Notes)
+		status.Resources = r.Text(status.Resources)
+		if len(info.Status.ResourceStatuses) > 0 {
+			status.ResourceStatuses = make([]*rspb.ResourceStatus, len(info.Status.ResourceStatuses))
+			for i, s := range info.Status.ResourceStatuses {
+				rs := *s
+				rs.Message = r.Text(s.Message)
+				status.ResourceStatuses[i] = &rs
+			}
+		}
+		out.Status = &status
+	}
+	return &out
+}
+
+// Release returns a copy of rel with the sensitive values masked in its
+// config, manifests and info. The chart, and with it the default values, is
+// left as it is.
+func (r *Redactor) Release(rel *rspb.Release) (*rspb.Release, error) {
+	if r == nil {
+		return rel, nil
+	}
+	out := *rel
+	cfg, err := r.Config(rel.Config)
+	if err != nil {
+		return nil, err
+	}
+	out.Config = cfg
+	out.Manifest = r.Manifest(rel.Manifest)
+	out.Info = r.Info(rel.Info)
+	out.Hooks = make([]*rspb.Hook, len(rel.Hooks))
+	for i, h := range rel.Hooks {
+		hook := *h
+		hook.Manifest = r.Manifest(h.Manifest)
+		out.Hooks[i] = &hook
+	}
+	return &out, nil
+}
+
+// redactSecrets masks the values of the data and stringData of the Secrets
+// in a manifest, leaving the rest of the manifest as it is.
+func redactSecrets(manifest string) string {
+	lines := strings.Split(manifest, "\n")
+	out := make([]string, 0, len(lines))
+	start := 0
+	flush := func(end int) {
+		doc := lines[start:end]
+		if secretKind.MatchString(strings.Join(doc, "\n")) {
+			doc = redactSecretData(doc)
+		}
+		out = append(out, doc...)
+	}
+	for i, l := range lines {
+		if i > start && docStart.MatchString(l) {
+			flush(i)
+			start = i
+		}
+	}
+	flush(len(lines))
+	return strings.Join(out, "\n")
+}
+
+// redactSecretData masks the entries of the top-level data and stringData
+// tables of a Secret, including the multi-line ones.
+func redactSecretData(lines []string) []string {
+	out := make([]string, 0, len(lines))
+	inData := false
+	entryIndent, skipIndent := -1, -1
+	for _, l := range lines {
+		trimmed := strings.TrimSpace(l)
+		indent := len(l) - len(strings.TrimLeft(l, " "))
+		if skipIndent >= 0 {
+			// The rest of a masked multi-line value.
+			if trimmed == "" || indent > skipIndent {
+				continue
+			}
+			skipIndent = -1
+		}
+		if inData && trimmed != "" && indent > 0 && !strings.HasPrefix(trimmed, "#") {
+			if entryIndent < 0 {
+				entryIndent = indent
+			}
+			if m := dataEntry.FindStringSubmatch(l); m != nil && indent == entryIndent {
+				out = append(out, m[1]+m[2]+": "+chartutil.SensitiveMask)
+				skipIndent = indent
+			}
+			continue
+		}
+		if trimmed != "" && indent == 0 {
+			inData = false
+		}
+		if m := secretData.FindStringSubmatch(l); m != nil {
+			if m[2] != "" && !strings.HasPrefix(m[2], "#") {
+				// A flow table, such as {password: c2VjcmV0}.
+				out = append(out, m[1]+": "+chartutil.SensitiveMask)
+				continue
+			}
+			inData, entryIndent = true, -1
+		}
+		out = append(out, l)
+	}
+	return out
+}
+
+type byLength []string
+
+func (s byLength) Len() int           { return len(s) }
+func (s byLength) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byLength) Less(i, j int) bool { return len(s[i]) > len(s[j]) }
@@ -0,0 +1,173 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+const redactManifest = `---
+# Source: app/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+type: Opaque
+data:
+  password: aHVudGVyMjI=
+  # the TLS certificate
+  tls.crt: |-
+    LS0tLS1CRUdJTi
+    BDRVJUSUZJQ0FURS0tLS0t
+stringData:
+  "config.yaml": "user: admin"
+---
+# Source: app/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+data:
+  DATABASE_URL: postgres://admin:hunter22@db/app
+  LOG_LEVEL: info
+`
+
+const redactedManifest = `---
+# Source: app/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+type: Opaque
+data:
+  password: ******
+  # the TLS certificate
+  tls.crt: ******
+stringData:
+  "config.yaml": ******
+---
+# Source: app/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+data:
+  DATABASE_URL: postgres://admin:******@db/app
+  LOG_LEVEL: info
+`
+
+func redactRelease() *rspb.Release {
+	return &rspb.Release{
+		Name: "app",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "app", Sensitive: []string{"database.password"}},
+			Values:   &chart.Config{Raw: "database:\n  user: admin\n  password: changeme\n"},
+		},
+		Config:   &chart.Config{Raw: "database:\n  password: hunter22\n"},
+		Manifest: redactManifest,
+		Hooks: []*rspb.Hook{
+			{Name: "init", Manifest: "command: [init, --password=hunter22]"},
+		},
+		Info: &rspb.Info{
+			Description: "Release \"app\" failed: invalid value \"hunter22\"",
+			Status: &rspb.Status{
+				Notes: "Log in with the password hunter22.",
+				ResourceStatuses: []*rspb.ResourceStatus{
+					{Kind: "Job", Name: "migrate", Message: "job migrate failed: cannot connect as admin:hunter22"},
+				},
+			},
+		},
+	}
+}
+
+func TestRedactorRelease(t *testing.T) {
+	rel := redactRelease()
+	r, err := NewRedactor(rel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := r.Release(rel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := "database:\n  password: '******'\n"; out.Config.Raw != expect {
+		t.Errorf("Expected config %q, got %q", expect, out.Config.Raw)
+	}
+	if out.Manifest != redactedManifest {
+		t.Errorf("Expected manifest:\n%s\ngot:\n%s", redactedManifest, out.Manifest)
+	}
+	if expect := "command: [init, --password=******]"; out.Hooks[0].Manifest != expect {
+		t.Errorf("Expected hook manifest %q, got %q", expect, out.Hooks[0].Manifest)
+	}
+	if expect := "Release \"app\" failed: invalid value \"******\""; out.Info.Description != expect {
+		t.Errorf("Expected description %q, got %q", expect, out.Info.Description)
+	}
+	if expect := "Log in with the password ******."; out.Info.Status.Notes != expect {
+		t.Errorf("Expected notes %q, got %q", expect, out.Info.Status.Notes)
+	}
+	if expect := "job migrate failed: cannot connect as admin:******"; out.Info.Status.ResourceStatuses[0].Message != expect {
+		t.Errorf("Expected resource message %q, got %q", expect, out.Info.Status.ResourceStatuses[0].Message)
+	}
+
+	// The release itself is left as it is.
+	orig := redactRelease()
+	if rel.Config.Raw != orig.Config.Raw || rel.Manifest != orig.Manifest ||
+		rel.Hooks[0].Manifest != orig.Hooks[0].Manifest || rel.Info.Status.Notes != orig.Info.Status.Notes ||
+		rel.Info.Status.ResourceStatuses[0].Message != orig.Info.Status.ResourceStatuses[0].Message {
+		t.Errorf("Expected the release to be left unchanged, got %v", rel)
+	}
+}
+
+func TestRedactorNoSensitiveValues(t *testing.T) {
+	rel := redactRelease()
+	rel.Chart.Metadata.Sensitive = nil
+	r, err := NewRedactor(rel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := r.Release(rel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Config.Raw != rel.Config.Raw {
+		t.Errorf("Expected config %q, got %q", rel.Config.Raw, out.Config.Raw)
+	}
+	// Secrets are masked regardless.
+	if !strings.Contains(out.Manifest, "password: ******") || !strings.Contains(out.Manifest, "admin:hunter22@db") {
+		t.Errorf("Expected only the Secret data to be masked, got:\n%s", out.Manifest)
+	}
+}
+
+func TestNilRedactor(t *testing.T) {
+	var r *Redactor
+	rel := redactRelease()
+	out, err := r.Release(rel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != rel {
+		t.Errorf("Expected a nil redactor to return the release as it is")
+	}
+	if s := r.Text("hunter22"); s != "hunter22" {
+		t.Errorf("Expected a nil redactor not to mask %q", s)
+	}
+}
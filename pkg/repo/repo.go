@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/facebookgo/atomicfile"
@@ -121,6 +122,22 @@ func (r *RepoFile) Has(name string) bool {
 	return false
 }
 
+// EntryForURL returns the repository that serves the given URL, i.e. the
+// one whose URL is the longest prefix of it, or nil if there is none.
+func (r *RepoFile) EntryForURL(u string) *Entry {
+	var found *Entry
+	for _, rf := range r.Repositories {
+		base := strings.TrimSuffix(rf.URL, "/")
+		if base == "" || !strings.HasPrefix(u, base+"/") {
+			continue
+		}
+		if found == nil || len(rf.URL) > len(found.URL) {
+			found = rf
+		}
+	}
+	return found
+}
+
 // Remove removes the entry from the list of repositories.
 //移除repos指定的repo信息
 func (r *RepoFile) Remove(name string) bool {
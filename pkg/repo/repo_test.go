@@ -146,6 +146,43 @@ func TestRemoveRepository(t *testing.T) {
 	}
 }
 
+func TestEntryForURL(t *testing.T) {
+	sampleRepository := NewRepoFile()
+	sampleRepository.Add(
+		&Entry{
+			Name: "stable",
+			URL:  "https://example.com/stable/charts",
+		},
+		&Entry{
+			Name: "example",
+			URL:  "https://example.com/",
+		},
+		&Entry{
+			Name: "config",
+			URL:  "https://example.com/config",
+		},
+	)
+
+	tests := []struct {
+		url, expect string
+	}{
+		{"https://example.com/stable/charts/values.yaml", "stable"},
+		{"https://example.com/config/prod/values.yaml", "config"},
+		{"https://example.com/configs/values.yaml", "example"},
+		{"https://example.com/values.yaml", "example"},
+		{"https://example.org/config/values.yaml", ""},
+	}
+	for _, tt := range tests {
+		name := ""
+		if e := sampleRepository.EntryForURL(tt.url); e != nil {
+			name = e.Name
+		}
+		if name != tt.expect {
+			t.Errorf("expected %q to be served by %q, got %q", tt.url, tt.expect, name)
+		}
+	}
+}
+
 func TestUpdateRepository(t *testing.T) {
 	sampleRepository := NewRepoFile()
 	sampleRepository.Add(
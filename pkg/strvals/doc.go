@@ -26,6 +26,11 @@ The above is equivalent to the YAML document
 	topname:
 	  subname: value
 
+Values that look like integers or booleans are typed accordingly. The parser
+has two other modes: ParseString keeps every value a string, and ParseFile
+treats each value as the name of a file and sets the key to whatever the
+given reader returns for it.
+
 This package provides a parser and utilities for converting the strvals format
 to other formats.
 */
@@ -50,6 +50,31 @@ func Parse(s string) (map[string]interface{}, error) {
 	return vals, err
 }
 
+// ParseString parses a set line, forcing every value to be a string.
+//
+// Where Parse would read "tag=1" as the integer 1, ParseString reads it as
+// the string "1".
+func ParseString(s string) (map[string]interface{}, error) {
+	vals := map[string]interface{}{}
+	scanner := bytes.NewBufferString(s)
+	t := newStringParser(scanner, vals)
+	err := t.parse()
+	return vals, err
+}
+
+// ParseFile parses a set line whose values name files.
+//
+// A set line is of the form name1=path1,name2=path2. The reader is called
+// with each path and returns the value to set, usually the contents of the
+// file.
+func ParseFile(s string, reader RunesValueReader) (map[string]interface{}, error) {
+	vals := map[string]interface{}{}
+	scanner := bytes.NewBufferString(s)
+	t := newFileParser(scanner, vals, reader)
+	err := t.parse()
+	return vals, err
+}
+
 //ParseInto parses a strvals line and merges the result into dest.
 //
 // If the strval string has a key that exists in dest, it overwrites the
@@ -60,15 +85,55 @@ func ParseInto(s string, dest map[string]interface{}) error {
 	return t.parse()
 }
 
+// ParseIntoString parses a strvals line like ParseString and merges the
+// result into dest.
+func ParseIntoString(s string, dest map[string]interface{}) error {
+	scanner := bytes.NewBufferString(s)
+	t := newStringParser(scanner, dest)
+	return t.parse()
+}
+
+// ParseIntoFile parses a strvals line like ParseFile and merges the result
+// into dest.
+func ParseIntoFile(s string, dest map[string]interface{}, reader RunesValueReader) error {
+	scanner := bytes.NewBufferString(s)
+	t := newFileParser(scanner, dest, reader)
+	return t.parse()
+}
+
+// RunesValueReader returns the value to set for the raw value of a key.
+type RunesValueReader func([]rune) (interface{}, error)
+
 // parser is a simple parser that takes a strvals line and parses it into a
 // map representation.
 type parser struct {
 	sc   *bytes.Buffer
 	data map[string]interface{}
+	// reader turns raw values into the values to set.
+	reader RunesValueReader
+	// isFile is set when values name files, in which case '{a,b}' is not
+	// read as a list.
+	isFile bool
 }
 
 func newParser(sc *bytes.Buffer, data map[string]interface{}) *parser {
-	return &parser{sc: sc, data: data}
+	return &parser{sc: sc, data: data, reader: typedValReader}
+}
+
+func newStringParser(sc *bytes.Buffer, data map[string]interface{}) *parser {
+	return &parser{sc: sc, data: data, reader: stringValReader}
+}
+
+func newFileParser(sc *bytes.Buffer, data map[string]interface{}, reader RunesValueReader) *parser {
+	return &parser{sc: sc, data: data, reader: reader, isFile: true}
+}
+
+func typedValReader(v []rune) (interface{}, error) {
+	return typedVal(v), nil
+}
+
+func stringValReader(v []rune) (interface{}, error) {
+	return string(v), nil
 }
 
 func (t *parser) parse() error {
@@ -121,6 +186,15 @@ func (t *parser) key(data map[string]interface{}) error {
 			set(data, kk, list)
 			return err
 		case last == '=':
+			if t.isFile {
+				v, e := t.val()
+				fv, err := t.reader(v)
+				if err != nil {
+					return err
+				}
+				set(data, string(k), fv)
+				return e
+			}
 			//End of key. Consume =, Get value.
 			// FIXME: Get value list first
 			vl, e := t.valList()
@@ -133,7 +207,11 @@ func (t *parser) key(data map[string]interface{}) error {
 				return e
 			case ErrNotList:
 				v, e := t.val()
-				set(data, string(k), typedVal(v))
+				tv, err := t.reader(v)
+				if err != nil {
+					return err
+				}
+				set(data, string(k), tv)
 				return e
 			default:
 				return e
@@ -198,6 +276,14 @@ func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
 	case err != nil:
 		return list, err
 	case last == '=':
+		if t.isFile {
+			v, e := t.val()
+			fv, err := t.reader(v)
+			if err != nil {
+				return list, err
+			}
+			return setIndex(list, i, fv), e
+		}
 		vl, e := t.valList()
 		switch e {
 		case nil:
@@ -206,7 +292,11 @@ func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
 			return setIndex(list, i, ""), err
 		case ErrNotList:
 			v, e := t.val()
-			return setIndex(list, i, typedVal(v)), e
+			tv, err := t.reader(v)
+			if err != nil {
+				return list, err
+			}
+			return setIndex(list, i, tv), e
 		default:
 			return list, e
 		}
@@ -265,10 +355,18 @@ func (t *parser) valList() ([]interface{}, error) {
 			if r, _, e := t.sc.ReadRune(); e == nil && r != ',' {
 				t.sc.UnreadRune()
 			}
-			list = append(list, typedVal(v))
+			tv, err := t.reader(v)
+			if err != nil {
+				return list, err
+			}
+			list = append(list, tv)
 			return list, nil
 		case last == ',':
-			list = append(list, typedVal(v))
+			tv, err := t.reader(v)
+			if err != nil {
+				return list, err
+			}
+			list = append(list, tv)
 		}
 	}
 }
@@ -16,6 +16,7 @@ limitations under the License.
 package strvals
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/ghodss/yaml"
@@ -280,6 +281,137 @@ func TestParseSet(t *testing.T) {
 	}
 }
 
+func TestParseSetString(t *testing.T) {
+	tests := []struct {
+		str    string
+		expect map[string]interface{}
+		err    bool
+	}{
+		{
+			str:    "tag=1,debug=true",
+			expect: map[string]interface{}{"tag": "1", "debug": "true"},
+		},
+		{
+			str:    "outer.inner=010,list[1]=false",
+			expect: map[string]interface{}{"outer": map[string]interface{}{"inner": "010"}, "list": []interface{}{nil, "false"}},
+		},
+		{
+			str:    "list={1,true,three}",
+			expect: map[string]interface{}{"list": []interface{}{"1", "true", "three"}},
+		},
+		{
+			str: "name1,name2=1",
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseString(tt.str)
+		if err != nil {
+			if tt.err {
+				continue
+			}
+			t.Fatalf("%s: %s", tt.str, err)
+		}
+		if tt.err {
+			t.Errorf("%s: Expected error. Got nil", tt.str)
+		}
+
+		y1, err := yaml.Marshal(tt.expect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y2, err := yaml.Marshal(got)
+		if err != nil {
+			t.Fatalf("Error serializing parsed value: %s", err)
+		}
+
+		if string(y1) != string(y2) {
+			t.Errorf("%s: Expected:\n%s\nGot:\n%s", tt.str, y1, y2)
+		}
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	files := map[string]string{
+		"cert.pem":   "-----BEGIN CERTIFICATE-----\n",
+		"{a,b}.conf": "braces",
+	}
+	reader := func(rs []rune) (interface{}, error) {
+		data, ok := files[string(rs)]
+		if !ok {
+			return nil, fmt.Errorf("no such file %q", string(rs))
+		}
+		return data, nil
+	}
+
+	tests := []struct {
+		str    string
+		expect map[string]interface{}
+		err    bool
+	}{
+		{
+			str:    "tls.cert=cert.pem",
+			expect: map[string]interface{}{"tls": map[string]interface{}{"cert": "-----BEGIN CERTIFICATE-----\n"}},
+		},
+		{
+			str:    "conf={a\\,b}.conf,certs[0]=cert.pem",
+			expect: map[string]interface{}{"conf": "braces", "certs": []interface{}{"-----BEGIN CERTIFICATE-----\n"}},
+		},
+		{
+			str: "tls.key=key.pem",
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFile(tt.str, reader)
+		if err != nil {
+			if tt.err {
+				continue
+			}
+			t.Fatalf("%s: %s", tt.str, err)
+		}
+		if tt.err {
+			t.Errorf("%s: Expected error. Got nil", tt.str)
+		}
+
+		y1, err := yaml.Marshal(tt.expect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y2, err := yaml.Marshal(got)
+		if err != nil {
+			t.Fatalf("Error serializing parsed value: %s", err)
+		}
+
+		if string(y1) != string(y2) {
+			t.Errorf("%s: Expected:\n%s\nGot:\n%s", tt.str, y1, y2)
+		}
+	}
+}
+
+func TestParseIntoString(t *testing.T) {
+	got := map[string]interface{}{
+		"outer": map[string]interface{}{
+			"inner1": "overwrite",
+			"inner2": 2,
+		},
+	}
+	if err := ParseIntoString("outer.inner1=1", got); err != nil {
+		t.Fatal(err)
+	}
+	if err := ParseIntoFile("outer.inner3=file", got, func(rs []rune) (interface{}, error) {
+		return "contents of " + string(rs), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	inner := got["outer"].(map[string]interface{})
+	if inner["inner1"] != "1" || inner["inner2"] != 2 || inner["inner3"] != "contents of file" {
+		t.Errorf("Unexpected values: %v", inner)
+	}
+}
+
 func TestParseInto(t *testing.T) {
 	got := map[string]interface{}{
 		"outer": map[string]interface{}{
@@ -0,0 +1,147 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dependsOnAnno is the annotation with which a resource declares the other
+// resources of its release that must be ready before it is applied, as a
+// comma-separated list such as "Service/db,Secret/db-credentials".
+const dependsOnAnno = "helm.sh/depends-on"
+
+// sortByDependencies groups manifests into waves, so that every resource comes
+// in a later wave than the resources it depends on. Manifests keep their order
+// within a wave.
+//
+// A dependency on a resource that is not in manifests, or a cycle of
+// dependencies, is an error.
+func sortByDependencies(manifests []manifest) ([][]manifest, error) {
+	index := map[string][]int{}
+	for i, m := range manifests {
+		if name, ok := manifestKindName(m); ok {
+			index[name] = append(index[name], i)
+		}
+	}
+
+	deps := make([][]int, len(manifests))
+	for i, m := range manifests {
+		refs, err := dependencies(m)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			js, ok := index[ref]
+			if !ok {
+				name, _ := manifestKindName(m)
+				return nil, fmt.Errorf("%s in %s depends on %s, which is not part of the release", name, m.name, ref)
+			}
+			deps[i] = append(deps[i], js...)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(manifests))
+	wave := make([]int, len(manifests))
+	var path []int
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return cycleError(manifests, path, i)
+		}
+		state[i] = visiting
+		path = append(path, i)
+		for _, j := range deps[i] {
+			if err := visit(j); err != nil {
+				return err
+			}
+			if wave[j] >= wave[i] {
+				wave[i] = wave[j] + 1
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+
+	var waves [][]manifest
+	for i := range manifests {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+		for len(waves) <= wave[i] {
+			waves = append(waves, nil)
+		}
+	}
+	for i, m := range manifests {
+		waves[wave[i]] = append(waves[wave[i]], m)
+	}
+	return waves, nil
+}
+
+// dependencies returns the resources a manifest depends on, as in
+// "Deployment/web".
+func dependencies(m manifest) ([]string, error) {
+	if m.head == nil || m.head.Metadata == nil {
+		return nil, nil
+	}
+	var refs []string
+	for _, ref := range strings.Split(m.head.Metadata.Annotations[dependsOnAnno], ",") {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		if parts := strings.Split(ref, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid %s reference %q in %s: expected Kind/name", dependsOnAnno, ref, m.name)
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// cycleError describes the cycle of dependencies that closes on manifest i.
+func cycleError(manifests []manifest, path []int, i int) error {
+	var names []string
+	for k := len(path) - 1; k >= 0; k-- {
+		if path[k] == i {
+			for _, j := range append(path[k:], i) {
+				name, _ := manifestKindName(manifests[j])
+				names = append(names, name)
+			}
+			break
+		}
+	}
+	return fmt.Errorf("dependency cycle between resources: %s", strings.Join(names, " -> "))
+}
+
+// manifestKindName returns the kind and name of a manifest, as in
+// "Deployment/web".
+func manifestKindName(m manifest) (string, bool) {
+	if m.head == nil || m.head.Kind == "" || m.head.Metadata == nil || m.head.Metadata.Name == "" {
+		return "", false
+	}
+	return m.head.Kind + "/" + m.head.Metadata.Name, true
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	util "k8s.io/helm/pkg/releaseutil"
+)
+
+func dependentManifest(name, kind, dependsOn string) manifest {
+	head := &util.SimpleHead{Kind: kind}
+	head.Metadata = &struct {
+		Name        string            `json:"name"`
+		Annotations map[string]string `json:"annotations"`
+	}{Name: name}
+	if dependsOn != "" {
+		head.Metadata.Annotations = map[string]string{dependsOnAnno: dependsOn}
+	}
+	return manifest{name: "templates/" + name, head: head}
+}
+
+func TestSortByDependencies(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifests []manifest
+		expected  string
+		err       string
+	}{
+		{
+			name: "no dependencies",
+			manifests: []manifest{
+				dependentManifest("a", "ConfigMap", ""),
+				dependentManifest("b", "Deployment", ""),
+			},
+			expected: "a b",
+		},
+		{
+			name: "waves",
+			manifests: []manifest{
+				dependentManifest("crd", "CustomResourceDefinition", ""),
+				dependentManifest("db", "StatefulSet", ""),
+				dependentManifest("web", "Deployment", "StatefulSet/db, EtcdCluster/etcd"),
+				dependentManifest("etcd", "EtcdCluster", "CustomResourceDefinition/crd"),
+				dependentManifest("hpa", "HorizontalPodAutoscaler", ""),
+			},
+			expected: "crd db hpa|etcd|web",
+		},
+		{
+			name: "missing dependency",
+			manifests: []manifest{
+				dependentManifest("web", "Deployment", "Service/db"),
+			},
+			err: "Deployment/web in templates/web depends on Service/db, which is not part of the release",
+		},
+		{
+			name: "invalid reference",
+			manifests: []manifest{
+				dependentManifest("web", "Deployment", "db"),
+			},
+			err: `invalid helm.sh/depends-on reference "db" in templates/web: expected Kind/name`,
+		},
+		{
+			name: "cycle",
+			manifests: []manifest{
+				dependentManifest("a", "ConfigMap", ""),
+				dependentManifest("b", "Deployment", "Service/c"),
+				dependentManifest("c", "Service", "Job/d"),
+				dependentManifest("d", "Job", "Deployment/b"),
+			},
+			err: "dependency cycle between resources: Deployment/b -> Service/c -> Job/d -> Deployment/b",
+		},
+		{
+			name: "self dependency",
+			manifests: []manifest{
+				dependentManifest("a", "ConfigMap", "ConfigMap/a"),
+			},
+			err: "dependency cycle between resources: ConfigMap/a -> ConfigMap/a",
+		},
+	}
+
+	for _, tt := range tests {
+		waves, err := sortByDependencies(tt.manifests)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("%s: expected error %q, got %v", tt.name, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		var got []string
+		for _, wave := range waves {
+			var names []string
+			for _, m := range wave {
+				names = append(names, m.head.Metadata.Name)
+			}
+			got = append(got, strings.Join(names, " "))
+		}
+		if s := strings.Join(got, "|"); s != tt.expected {
+			t.Errorf("%s: expected waves %q, got %q", tt.name, tt.expected, s)
+		}
+	}
+}
+
+func TestDeleteReleaseInDependencyOrder(t *testing.T) {
+	rel := releaseStub()
+	rel.Manifest = `apiVersion: v1
+kind: Service
+metadata:
+  name: db
+---
+apiVersion: v1
+kind: Deployment
+metadata:
+  name: web
+  annotations:
+    helm.sh/depends-on: ConfigMap/config
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  annotations:
+    helm.sh/depends-on: Service/db`
+
+	kc := newRecordingKubeClient()
+	if _, errs := DeleteRelease(rel, chartutil.DefaultVersionSet, kc); len(errs) > 0 {
+		t.Fatalf("Failed delete: %v", errs)
+	}
+	if got, want := strings.Join(kc.deleted, " "), "Deployment/web ConfigMap/config Service/db"; got != want {
+		t.Errorf("Expected deletions %q, got %q", want, got)
+	}
+}
@@ -31,6 +31,7 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/engine"
+	"k8s.io/helm/pkg/engine/overlay"
 	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/storage"
@@ -43,6 +44,9 @@ const DefaultTillerNamespace = "kube-system"
 // GoTplEngine is the name of the Go template engine, as registered in the EngineYard.
 const GoTplEngine = "gotpl"
 
+// OverlayEngine is the name of the overlay engine, as registered in the EngineYard.
+const OverlayEngine = overlay.Name
+
 // DefaultEngine points to the engine that the EngineYard should treat as the
 // default. A chart that does not specify an engine may be run through the
 // default engine.
@@ -114,6 +118,13 @@ type KubeClient interface {
 	// by "\n---\n").
 	Get(namespace string, reader io.Reader) (string, error)
 
+	// Status returns the state of one or more resources in the cluster, in
+	// the order of the reader.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	Status(namespace string, reader io.Reader) ([]kube.ResourceStatus, error)
+
 	// Delete destroys one or more resources.
 	//
 	// namespace must contain a valid existing namespace.
@@ -144,6 +155,28 @@ type KubeClient interface {
 	// WaitAndGetCompletedPodPhase waits up to a timeout until a pod enters a completed phase
 	// and returns said phase (PodSucceeded or PodFailed qualify).
 	WaitAndGetCompletedPodPhase(namespace string, reader io.Reader, timeout time.Duration) (api.PodPhase, error)
+
+	// Drift compares one or more resources with their live state, and
+	// returns those that differ.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	Drift(namespace string, reader io.Reader) ([]kube.ResourceDrift, error)
+
+	// Existing returns the kind and name, as in "Deployment/web", of the
+	// resources in reader that already exist in the cluster.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	Existing(namespace string, reader io.Reader) ([]string, error)
+
+	// ValidateOnServer submits the resources in targetReader to the API
+	// server as a dry run, creating or patching them from those in
+	// originalReader, and returns the errors of the resources it rejected.
+	// Existing resources that are not in originalReader are rejected.
+	//
+	// originalReader may be nil.
+	ValidateOnServer(namespace string, originalReader, targetReader io.Reader) error
 }
 
 // PrintingKubeClient implements KubeClient, but simply prints the reader to
@@ -164,6 +197,12 @@ func (p *PrintingKubeClient) Get(ns string, r io.Reader) (string, error) {
 	return "", err
 }
 
+// Status implements KubeClient Status.
+func (p *PrintingKubeClient) Status(ns string, r io.Reader) ([]kube.ResourceStatus, error) {
+	_, err := io.Copy(p.Out, r)
+	return []kube.ResourceStatus{}, err
+}
+
 // Delete implements KubeClient delete.
 //
 // It only prints out the content to be deleted.
@@ -200,6 +239,24 @@ func (p *PrintingKubeClient) WaitAndGetCompletedPodPhase(namespace string, reade
 	return api.PodUnknown, err
 }
 
+// Drift implements KubeClient Drift.
+func (p *PrintingKubeClient) Drift(ns string, reader io.Reader) ([]kube.ResourceDrift, error) {
+	_, err := io.Copy(p.Out, reader)
+	return []kube.ResourceDrift{}, err
+}
+
+// Existing implements KubeClient Existing.
+func (p *PrintingKubeClient) Existing(ns string, reader io.Reader) ([]string, error) {
+	_, err := io.Copy(p.Out, reader)
+	return []string{}, err
+}
+
+// ValidateOnServer implements KubeClient ValidateOnServer.
+func (p *PrintingKubeClient) ValidateOnServer(ns string, originalReader, targetReader io.Reader) error {
+	_, err := io.Copy(p.Out, targetReader)
+	return err
+}
+
 // Environment provides the context for executing a client request.
 //
 // All services in a context are concurrency safe.
@@ -218,9 +275,8 @@ func New() *Environment {
 	e := engine.New()
 	//记录模板引擎表
 	var ey EngineYard = map[string]Engine{
-		// Currently, the only template engine we support is the GoTpl one. But
-		// we can easily add some here.
-		GoTplEngine: e, //默认的模板引擎列表
+		GoTplEngine:   e, //默认的模板引擎列表
+		OverlayEngine: overlay.New(),
 	}
 
 	return &Environment{
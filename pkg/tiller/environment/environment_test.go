@@ -46,6 +46,9 @@ func (k *mockKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWai
 func (k *mockKubeClient) Get(ns string, r io.Reader) (string, error) {
 	return "", nil
 }
+func (k *mockKubeClient) Status(ns string, r io.Reader) ([]kube.ResourceStatus, error) {
+	return []kube.ResourceStatus{}, nil
+}
 func (k *mockKubeClient) Delete(ns string, r io.Reader) error {
 	return nil
 }
@@ -65,6 +68,18 @@ func (k *mockKubeClient) WaitAndGetCompletedPodPhase(namespace string, reader io
 	return api.PodUnknown, nil
 }
 
+func (k *mockKubeClient) Drift(ns string, reader io.Reader) ([]kube.ResourceDrift, error) {
+	return []kube.ResourceDrift{}, nil
+}
+
+func (k *mockKubeClient) Existing(ns string, reader io.Reader) ([]string, error) {
+	return []string{}, nil
+}
+
+func (k *mockKubeClient) ValidateOnServer(ns string, originalReader, targetReader io.Reader) error {
+	return nil
+}
+
 func (k *mockKubeClient) WaitAndGetCompletedPodStatus(namespace string, reader io.Reader, timeout time.Duration) (api.PodPhase, error) {
 	return "", nil
 }
@@ -23,18 +23,30 @@ import (
 // SortOrder is an ordering of Kinds.
 type SortOrder []string
 
+// otherKinds stands for the kinds missing from a SortOrder, such as custom
+// resources. Without it, they come after all the others.
+const otherKinds = "*"
+
 // InstallOrder is the order in which manifests should be installed (by Kind).
 //
 // Those occurring earlier in the list get installed before those occurring later in the list.
+// Kinds missing from the list are installed last, ordered by name. Resources that
+// must wait for others regardless of their kinds declare it with the
+// helm.sh/depends-on annotation.
 var InstallOrder SortOrder = []string{
 	"Namespace",
+	"NetworkPolicy",
 	"ResourceQuota",
 	"LimitRange",
+	"PodSecurityPolicy",
+	"PodDisruptionBudget",
 	"Secret",
 	"ConfigMap",
+	"StorageClass",
 	"PersistentVolume",
 	"PersistentVolumeClaim",
 	"ServiceAccount",
+	"CustomResourceDefinition",
 	"ClusterRole",
 	"ClusterRoleBinding",
 	"Role",
@@ -45,23 +57,32 @@ var InstallOrder SortOrder = []string{
 	"ReplicationController",
 	"ReplicaSet",
 	"Deployment",
+	"HorizontalPodAutoscaler",
 	"StatefulSet",
 	"Job",
 	"CronJob",
 	"Ingress",
 	"APIService",
+	"MutatingWebhookConfiguration",
+	"ValidatingWebhookConfiguration",
 }
 
 // UninstallOrder is the order in which manifests should be uninstalled (by Kind).
 //
 // Those occurring earlier in the list get uninstalled before those occurring later in the list.
+// Kinds missing from the list are uninstalled first, so that custom resources
+// are gone before their CustomResourceDefinition.
 var UninstallOrder SortOrder = []string{
+	otherKinds,
+	"ValidatingWebhookConfiguration",
+	"MutatingWebhookConfiguration",
 	"APIService",
 	"Ingress",
 	"Service",
 	"CronJob",
 	"Job",
 	"StatefulSet",
+	"HorizontalPodAutoscaler",
 	"Deployment",
 	"ReplicaSet",
 	"ReplicationController",
@@ -71,22 +92,27 @@ var UninstallOrder SortOrder = []string{
 	"Role",
 	"ClusterRoleBinding",
 	"ClusterRole",
+	"CustomResourceDefinition",
 	"ServiceAccount",
 	"PersistentVolumeClaim",
 	"PersistentVolume",
+	"StorageClass",
 	"ConfigMap",
 	"Secret",
+	"PodDisruptionBudget",
+	"PodSecurityPolicy",
 	"LimitRange",
 	"ResourceQuota",
+	"NetworkPolicy",
 	"Namespace",
 }
 
 // sortByKind does an in-place sort of manifests by Kind.
 //
-// Results are sorted by 'ordering'
+// Results are sorted by 'ordering', and keep their order within a Kind.
 func sortByKind(manifests []manifest, ordering SortOrder) []manifest {
 	ks := newKindSorter(manifests, ordering)
-	sort.Sort(ks)
+	sort.Stable(ks)
 	return ks.manifests
 }
 
@@ -114,14 +140,24 @@ func (k *kindSorter) Swap(i, j int) { k.manifests[i], k.manifests[j] = k.manifes
 func (k *kindSorter) Less(i, j int) bool {
 	a := k.manifests[i]
 	b := k.manifests[j]
-	first, ok := k.ordering[a.head.Kind]
-	if !ok {
-		// Unknown is always last
-		return false
+	first, aok := k.position(a.head.Kind)
+	second, bok := k.position(b.head.Kind)
+	if first != second || aok || bok {
+		return first < second
+	}
+	// Unknown kinds are ordered by name
+	return a.head.Kind < b.head.Kind
+}
+
+// position returns the position of a kind in the ordering, and whether the
+// kind is part of it. Unknown kinds take the position of otherKinds, or are
+// last.
+func (k *kindSorter) position(kind string) (int, bool) {
+	if o, ok := k.ordering[kind]; ok {
+		return o, true
 	}
-	second, ok := k.ordering[b.head.Kind]
-	if !ok {
-		return true
+	if o, ok := k.ordering[otherKinds]; ok {
+		return o, false
 	}
-	return first < second
+	return len(k.ordering), false
 }
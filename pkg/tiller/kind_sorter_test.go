@@ -130,7 +130,7 @@ func TestKindSorter(t *testing.T) {
 		expected    string
 	}{
 		{"install", InstallOrder, "abcdefghijklmnopqrstuvw!"},
-		{"uninstall", UninstallOrder, "wvmutsrqponlkjihgfedcba!"},
+		{"uninstall", UninstallOrder, "!wvmutsrqponlkjihgfedcba"},
 	} {
 		var buf bytes.Buffer
 		t.Run(test.description, func(t *testing.T) {
@@ -147,3 +147,57 @@ func TestKindSorter(t *testing.T) {
 		})
 	}
 }
+
+func TestKindSorterUnknownKinds(t *testing.T) {
+	manifests := []manifest{
+		{
+			name: "e",
+			head: &util.SimpleHead{Kind: "ZooKeeperCluster"},
+		},
+		{
+			name: "d",
+			head: &util.SimpleHead{Kind: "ValidatingWebhookConfiguration"},
+		},
+		{
+			name: "f",
+			head: &util.SimpleHead{Kind: "EtcdCluster"},
+		},
+		{
+			name: "g",
+			head: &util.SimpleHead{Kind: "ZooKeeperCluster"},
+		},
+		{
+			name: "b",
+			head: &util.SimpleHead{Kind: "CustomResourceDefinition"},
+		},
+		{
+			name: "c",
+			head: &util.SimpleHead{Kind: "HorizontalPodAutoscaler"},
+		},
+		{
+			name: "a",
+			head: &util.SimpleHead{Kind: "NetworkPolicy"},
+		},
+	}
+
+	for _, test := range []struct {
+		description string
+		order       SortOrder
+		expected    string
+	}{
+		{"install", InstallOrder, "abcdfeg"},
+		{"uninstall", UninstallOrder, "fegdcba"},
+	} {
+		var buf bytes.Buffer
+		t.Run(test.description, func(t *testing.T) {
+			in := make([]manifest, len(manifests))
+			copy(in, manifests)
+			for _, r := range sortByKind(in, test.order) {
+				buf.WriteString(r.name)
+			}
+			if got := buf.String(); got != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	util "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+// adoptAnno is the annotation with which a chart allows a resource to be
+// adopted when it already exists in the cluster.
+const adoptAnno = "helm.sh/adopt"
+
+// adoption holds the resources of a release that are taken over from the
+// cluster rather than created.
+type adoption struct {
+	// manifest holds the adopted resources as rendered for the release.
+	manifest string
+	// resources names the adopted resources, as in "Deployment/web".
+	resources []string
+}
+
+// adopt finds the resources of the target manifest that already exist in the
+// cluster without being part of the current manifest, and that may be
+// adopted: all of them when adoptAll is set, otherwise only those annotated
+// with helm.sh/adopt: "true".
+func adopt(kc environment.KubeClient, namespace, current, target string, adoptAll bool) (*adoption, error) {
+	owned := map[string]bool{}
+	for _, m := range util.SplitManifests(current) {
+		if name, _, ok := manifestResource(m); ok {
+			owned[name] = true
+		}
+	}
+
+	docs := util.SplitManifests(target)
+	keys := make([]string, 0, len(docs))
+	for k := range docs {
+		keys = append(keys, k)
+	}
+	sort.Sort(util.BySplitManifestsOrder(keys))
+
+	candidates := map[string]string{}
+	var b bytes.Buffer
+	for _, k := range keys {
+		name, annotations, ok := manifestResource(docs[k])
+		if !ok || owned[name] {
+			continue
+		}
+		if !adoptAll && strings.ToLower(strings.TrimSpace(annotations[adoptAnno])) != "true" {
+			continue
+		}
+		candidates[name] = docs[k]
+		b.WriteString("\n---\n" + docs[k])
+	}
+
+	a := &adoption{}
+	if len(candidates) == 0 {
+		return a, nil
+	}
+	existing, err := kc.Existing(namespace, &b)
+	if err != nil {
+		return nil, err
+	}
+	var m bytes.Buffer
+	for _, name := range existing {
+		m.WriteString("\n---\n" + candidates[name])
+		a.resources = append(a.resources, name)
+	}
+	a.manifest = m.String()
+	return a, nil
+}
+
+// manifestResource returns the kind and name, as in "Deployment/web", and
+// the annotations of the resource in a manifest.
+func manifestResource(m string) (string, map[string]string, bool) {
+	var head util.SimpleHead
+	if err := yaml.Unmarshal([]byte(m), &head); err != nil || head.Kind == "" || head.Metadata == nil {
+		return "", nil, false
+	}
+	return head.Kind + "/" + head.Metadata.Name, head.Metadata.Annotations, true
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	util "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+var manifestAdoptable = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-cm-adopt
+  annotations:
+    "helm.sh/adopt": "true"
+data:
+  name: value`
+
+var manifestExisting = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-cm-existing
+data:
+  name: value`
+
+// adoptingKubeClient pretends that some resources already exist, and records
+// how the release is applied.
+type adoptingKubeClient struct {
+	environment.PrintingKubeClient
+	existing  map[string]bool
+	created   bool
+	original  string
+	validated string
+}
+
+func newAdoptingKubeClient(existing ...string) *adoptingKubeClient {
+	k := &adoptingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout},
+		existing:           map[string]bool{},
+	}
+	for _, name := range existing {
+		k.existing[name] = true
+	}
+	return k
+}
+
+func (k *adoptingKubeClient) Existing(ns string, r io.Reader) ([]string, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	existing := []string{}
+	for _, m := range util.SplitManifests(string(b)) {
+		if name, _, ok := manifestResource(m); ok && k.existing[name] {
+			existing = append(existing, name)
+		}
+	}
+	return existing, nil
+}
+
+func (k *adoptingKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	k.created = true
+	return nil
+}
+
+func (k *adoptingKubeClient) Update(ns string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
+	b, err := ioutil.ReadAll(originalReader)
+	k.original = string(b)
+	return err
+}
+
+func (k *adoptingKubeClient) ValidateOnServer(ns string, originalReader, targetReader io.Reader) error {
+	if originalReader == nil {
+		return nil
+	}
+	b, err := ioutil.ReadAll(originalReader)
+	k.validated = string(b)
+	return err
+}
+
+func TestAdopt(t *testing.T) {
+	target := manifestAdoptable + "\n---\n" + manifestExisting
+	kc := newAdoptingKubeClient("ConfigMap/test-cm-adopt", "ConfigMap/test-cm-existing")
+
+	tests := []struct {
+		name     string
+		current  string
+		adoptAll bool
+		expect   []string
+	}{
+		{"annotated resources", "", false, []string{"ConfigMap/test-cm-adopt"}},
+		{"all resources", "", true, []string{"ConfigMap/test-cm-adopt", "ConfigMap/test-cm-existing"}},
+		{"resources of the release", manifestAdoptable, true, []string{"ConfigMap/test-cm-existing"}},
+		{"nothing to adopt", target, true, nil},
+	}
+	for _, tt := range tests {
+		a, err := adopt(kc, "default", tt.current, target, tt.adoptAll)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if !reflect.DeepEqual(a.resources, tt.expect) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, a.resources)
+		}
+		for _, name := range tt.expect {
+			if !strings.Contains(a.manifest, "name: "+strings.TrimPrefix(name, "ConfigMap/")) {
+				t.Errorf("%s: expected the manifest of %s, got %q", tt.name, name, a.manifest)
+			}
+		}
+	}
+}
+
+func TestInstallReleaseAdopt(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := newAdoptingKubeClient("ConfigMap/test-cm-existing")
+	rs.env.KubeClient = kc
+
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/adoptable", Data: []byte(manifestAdoptable)},
+				{Name: "templates/existing", Data: []byte(manifestExisting)},
+			},
+		},
+		Adopt: true,
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	rel, err := rs.env.Releases.Get(res.Release.Name, res.Release.Version)
+	if err != nil {
+		t.Fatalf("Expected release for %s (%v).", res.Release.Name, rs.env.Releases)
+	}
+	if expect := []string{"ConfigMap/test-cm-existing"}; !reflect.DeepEqual(rel.Info.Adopted, expect) {
+		t.Errorf("Expected adopted resources %v, got %v", expect, rel.Info.Adopted)
+	}
+	if kc.created {
+		t.Error("Expected the release to be applied as an update of the adopted resources")
+	}
+	if strings.Contains(kc.original, "test-cm-adopt") || !strings.Contains(kc.original, "test-cm-existing") {
+		t.Errorf("Expected only the adopted resource as the original manifest, got %q", kc.original)
+	}
+}
+
+func TestInstallReleaseValidateAdopt(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := newAdoptingKubeClient("ConfigMap/test-cm-existing")
+	rs.env.KubeClient = kc
+
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/adoptable", Data: []byte(manifestAdoptable)},
+				{Name: "templates/existing", Data: []byte(manifestExisting)},
+			},
+		},
+		Adopt:    true,
+		Validate: true,
+	}
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if strings.Contains(kc.validated, "test-cm-adopt") || !strings.Contains(kc.validated, "test-cm-existing") {
+		t.Errorf("Expected the adopted resource to be validated as part of the original manifest, got %q", kc.validated)
+	}
+}
+
+func TestInstallReleaseWithoutAdopt(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := newAdoptingKubeClient("ConfigMap/test-cm-existing")
+	rs.env.KubeClient = kc
+
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/existing", Data: []byte(manifestExisting)},
+			},
+		},
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if len(res.Release.Info.Adopted) != 0 {
+		t.Errorf("Expected nothing to be adopted, got %v", res.Release.Info.Adopted)
+	}
+	if !kc.created {
+		t.Error("Expected the release to be created")
+	}
+}
+
+func TestUpdateReleaseAdopt(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Manifest = manifestAdoptable
+	rs.env.Releases.Create(rel)
+	kc := newAdoptingKubeClient("ConfigMap/test-cm-adopt", "ConfigMap/test-cm-existing")
+	rs.env.KubeClient = kc
+
+	req := &services.UpdateReleaseRequest{
+		Name: rel.Name,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+				{Name: "templates/existing", Data: []byte(manifestExisting)},
+			},
+		},
+		Adopt: true,
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed update: %s", err)
+	}
+	if expect := []string{"ConfigMap/test-cm-existing"}; !reflect.DeepEqual(res.Release.Info.Adopted, expect) {
+		t.Errorf("Expected adopted resources %v, got %v", expect, res.Release.Info.Adopted)
+	}
+	if !strings.HasPrefix(kc.original, rel.Manifest) || !strings.Contains(kc.original, "test-cm-existing") {
+		t.Errorf("Expected the current manifest and the adopted resource as the original manifest, got %q", kc.original)
+	}
+}
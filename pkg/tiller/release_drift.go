@@ -0,0 +1,88 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
+)
+
+// GetReleaseDrift compares the resources of the deployed release with their
+// live state in the cluster.
+func (s *ReleaseServer) GetReleaseDrift(c ctx.Context, req *services.GetReleaseDriftRequest) (*services.GetReleaseDriftResponse, error) {
+	if err := validateReleaseName(req.Name); err != nil {
+		s.Log("getDrift: Release name is invalid: %s", req.Name)
+		return nil, err
+	}
+
+	rel, err := s.env.Releases.Deployed(req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("getting deployed release %q: %s", req.Name, err)
+	}
+
+	drifts, err := s.env.KubeClient.Drift(rel.Namespace, bytes.NewBufferString(rel.Manifest))
+	if err != nil {
+		s.Log("warning: Drift for %s failed: %v", rel.Name, err)
+		return nil, err
+	}
+
+	res := &services.GetReleaseDriftResponse{Name: rel.Name, Version: rel.Version}
+	red := s.redactor(rel)
+	for _, d := range drifts {
+		res.Resources = append(res.Resources, resourceDrift(red, d))
+	}
+	return res, nil
+}
+
+// resourceDrift converts a drifted resource to its message, masking the data
+// of Secrets and the sensitive values of the release.
+func resourceDrift(red *relutil.Redactor, d kube.ResourceDrift) *services.ResourceDrift {
+	out := &services.ResourceDrift{
+		Kind:      d.Kind,
+		Namespace: d.Namespace,
+		Name:      d.Name,
+		Missing:   d.Missing,
+	}
+	for _, f := range d.Fields {
+		fd := &services.FieldDrift{
+			Path:     f.Path,
+			Expected: red.Text(f.Expected),
+			Actual:   red.Text(f.Actual),
+		}
+		if d.Kind == "Secret" && (f.Path == "data" || strings.HasPrefix(f.Path, "data.")) {
+			fd.Expected = maskDrift(fd.Expected)
+			fd.Actual = maskDrift(fd.Actual)
+		}
+		out.Fields = append(out.Fields, fd)
+	}
+	return out
+}
+
+func maskDrift(v string) string {
+	if v == "" {
+		return v
+	}
+	return chartutil.SensitiveMask
+}
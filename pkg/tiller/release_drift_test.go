@@ -0,0 +1,91 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+type driftingKubeClient struct {
+	environment.PrintingKubeClient
+	drifts []kube.ResourceDrift
+}
+
+func (d *driftingKubeClient) Drift(ns string, r io.Reader) ([]kube.ResourceDrift, error) {
+	return d.drifts, nil
+}
+
+func TestGetReleaseDrift(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+	rs.env.KubeClient = &driftingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout},
+		drifts: []kube.ResourceDrift{
+			{Kind: "Deployment", Namespace: "default", Name: "web", Fields: []kube.FieldDrift{
+				{Path: "spec.replicas", Expected: "2", Actual: "0"},
+			}},
+			{Kind: "Secret", Namespace: "default", Name: "web", Fields: []kube.FieldDrift{
+				{Path: "data.password", Expected: `"c2VjcmV0"`, Actual: `"aHVudGVyMg=="`},
+				{Path: "data.token", Expected: `"dG9rZW4="`},
+			}},
+			{Kind: "Service", Namespace: "default", Name: "web", Missing: true},
+		},
+	}
+
+	res, err := rs.GetReleaseDrift(c, &services.GetReleaseDriftRequest{Name: rel.Name})
+	if err != nil {
+		t.Fatalf("Error getting release drift: %s", err)
+	}
+	if res.Name != rel.Name || res.Version != rel.Version {
+		t.Errorf("Expected %s (v%d), got %s (v%d)", rel.Name, rel.Version, res.Name, res.Version)
+	}
+
+	expect := []*services.ResourceDrift{
+		{Kind: "Deployment", Namespace: "default", Name: "web", Fields: []*services.FieldDrift{
+			{Path: "spec.replicas", Expected: "2", Actual: "0"},
+		}},
+		{Kind: "Secret", Namespace: "default", Name: "web", Fields: []*services.FieldDrift{
+			{Path: "data.password", Expected: "******", Actual: "******"},
+			{Path: "data.token", Expected: "******"},
+		}},
+		{Kind: "Service", Namespace: "default", Name: "web", Missing: true},
+	}
+	if !reflect.DeepEqual(res.Resources, expect) {
+		t.Errorf("Expected %v, got %v", expect, res.Resources)
+	}
+}
+
+func TestGetReleaseDriftNotDeployed(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	if _, err := rs.GetReleaseDrift(c, &services.GetReleaseDriftRequest{Name: "angry-panda"}); err == nil {
+		t.Error("Expected an error for a release that is not deployed")
+	}
+}
@@ -42,7 +42,7 @@ func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallRelea
 		// On dry run, append the manifest contents to a failed release. This is
 		// a stop-gap until we can revisit an error backchannel post-2.0.
 		if req.DryRun && strings.HasPrefix(err.Error(), "YAML parse error") {
-			err = fmt.Errorf("%s\n%s", err, rel.Manifest)
+			err = fmt.Errorf("%s\n%s", err, s.redactor(rel).Manifest(rel.Manifest))
 		}
 		return res, err
 	}
@@ -51,7 +51,7 @@ func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallRelea
 	//安装release
 	res, err := s.performRelease(rel, req)
 	if err != nil {
-		s.Log("failed install perform step: %s", err)
+		s.Log("failed install perform step: %s", s.redactor(rel).Text(err.Error()))
 	}
 	return res, err
 }
@@ -69,7 +69,7 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 	}
 
 	//获得k8s server的版本号,支持的api版本集(extesions/v1beta1),以及tiller版本号
-	caps, err := capabilities(s.clientset.Discovery())
+	caps, err := s.requestCapabilities(req.DryRun, req.Capabilities)
 	if err != nil {
 		return nil, err
 	}
@@ -115,12 +115,6 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 		return rel, err
 	}
 
-	//打印解析后的k8s资源文件
-	fmt.Println("==================>>>")
-	if manifestDoc != nil {
-		fmt.Println(manifestDoc.String())
-	}
-
 	// Store a release.
 	rel := &release.Release{
 		Name:      name,
@@ -141,6 +135,10 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 		rel.Info.Status.Notes = notesTxt
 	}
 
+	//打印解析后的k8s资源文件
+	fmt.Println("==================>>>")
+	fmt.Println(s.redactor(rel).Manifest(rel.Manifest))
+
 	//检测manifest是否合法吗?
 	//对,向k8s发出请求
 	//但注意这里不是使用k8s restclient,而是利用了kubectl的Factory
@@ -153,13 +151,22 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 func (s *ReleaseServer) performRelease(r *release.Release, req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
 	res := &services.InstallReleaseResponse{Release: r}
 
+	red := s.redactor(r)
 	fmt.Println("---------------release---------------")
 	//	fmt.Println("------chart::", r.Chart)
-	fmt.Println("------config:", r.Config)
-	fmt.Println("------info:", r.Info)
-	fmt.Println("------manifest:", r.Manifest)
+	if cfg, err := red.Config(r.Config); err == nil {
+		fmt.Println("------config:", cfg)
+	}
+	fmt.Println("------info:", red.Info(r.Info))
+	fmt.Println("------manifest:", red.Manifest(r.Manifest))
 	fmt.Println("------name:", r.Name)
 
+	if req.Validate {
+		if err := s.validateOnServer(r, nil, req.Adopt); err != nil {
+			return res, err
+		}
+	}
+
 	//不运行,只测试
 	if req.DryRun {
 		s.Log("dry run for %s", r.Name)
@@ -169,7 +176,7 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 
 	// pre-install hooks
 	if !req.DisableHooks {
-		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PreInstall, req.Timeout); err != nil {
+		if err := s.execHook(r, hooks.PreInstall, req.Timeout); err != nil {
 			return res, err
 		}
 	} else {
@@ -198,9 +205,10 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 			Wait:     req.Wait,
 			Recreate: false,
 			Timeout:  req.Timeout,
+			Adopt:    req.Adopt,
 		}
 		if err := s.ReleaseModule.Update(old, r, updateReq, s.env); err != nil {
-			msg := fmt.Sprintf("Release replace %q failed: %s", r.Name, err)
+			msg := red.Text(fmt.Sprintf("Release replace %q failed: %s", r.Name, err))
 			s.Log("warning: %s", msg)
 			old.Info.Status.Code = release.Status_SUPERSEDED
 			r.Info.Status.Code = release.Status_FAILED
@@ -215,19 +223,19 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 		// regular manifests
 		//调用k8s client/或者rudder api去创建k8s资源
 		if err := s.ReleaseModule.Create(r, req, s.env); err != nil {
-			msg := fmt.Sprintf("Release %q failed: %s", r.Name, err)
+			msg := red.Text(fmt.Sprintf("Release %q failed: %s", r.Name, err))
 			s.Log("warning: %s", msg)
 			r.Info.Status.Code = release.Status_FAILED
 			r.Info.Description = msg
 			s.recordRelease(r, false)
-			return res, fmt.Errorf("release %s failed: %s", r.Name, err)
+			return res, fmt.Errorf("release %s failed: %s", r.Name, red.Text(err.Error()))
 		}
 	}
 
 	// post-install hooks
 	if !req.DisableHooks {
-		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PostInstall, req.Timeout); err != nil {
-			msg := fmt.Sprintf("Release %q failed post-install: %s", r.Name, err)
+		if err := s.execHook(r, hooks.PostInstall, req.Timeout); err != nil {
+			msg := red.Text(fmt.Sprintf("Release %q failed post-install: %s", r.Name, err))
 			s.Log("warning: %s", msg)
 			r.Info.Status.Code = release.Status_FAILED
 			r.Info.Description = msg
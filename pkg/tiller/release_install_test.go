@@ -21,10 +21,12 @@ import (
 	"strings"
 	"testing"
 
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	tversion "k8s.io/helm/pkg/proto/hapi/version"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -384,6 +386,67 @@ func TestInstallRelease_DryRun(t *testing.T) {
 	}
 }
 
+func TestInstallRelease_DryRunCapabilities(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := &services.InstallReleaseRequest{
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/caps", Data: []byte(`kube: {{ .Capabilities.KubeVersion.Minor }}
+batch: {{ .Capabilities.APIVersions.Has "batch/v2alpha1" }}
+tiller: {{ .Capabilities.TillerVersion.SemVer }}`)},
+			},
+		},
+		DryRun: true,
+		Capabilities: &services.Capabilities{
+			ApiVersions:   []string{"v1", "batch/v2alpha1"},
+			KubeVersion:   "v1.5.2",
+			TillerVersion: &tversion.Version{SemVer: "v2.0.0"},
+		},
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	for _, expect := range []string{"kube: 5", "batch: true", "tiller: v2.0.0"} {
+		if !strings.Contains(res.Release.Manifest, expect) {
+			t.Errorf("Expected manifest to contain %q, got %s", expect, res.Release.Manifest)
+		}
+	}
+}
+
+func TestInstallRelease_CapabilitiesWithoutDryRun(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := &services.InstallReleaseRequest{
+		Chart:        chartStub(),
+		Capabilities: &services.Capabilities{KubeVersion: "v1.5.2"},
+	}
+	if _, err := rs.InstallRelease(c, req); err != chartutil.ErrCapabilitiesNotDryRun {
+		t.Errorf("Expected %q, got %v", chartutil.ErrCapabilitiesNotDryRun, err)
+	}
+}
+
+func TestInstallRelease_SchemaViolation(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	ch := chartStub()
+	ch.Schema = []byte(`{"properties": {"replicas": {"type": "integer"}}}`)
+	req := &services.InstallReleaseRequest{
+		Chart:  ch,
+		Values: &chart.Config{Raw: "replicas: two"},
+	}
+	_, err := rs.InstallRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "- replicas: Invalid type") {
+		t.Errorf("Expected the values to be rejected by the schema, got %v", err)
+	}
+}
+
 func TestInstallRelease_NoHooks(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -422,6 +485,36 @@ func TestInstallRelease_FailedHooks(t *testing.T) {
 	}
 }
 
+func TestInstallRelease_Validate(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := newValidationFailingKubeClient()
+	rs.env.KubeClient = kc
+
+	req := &services.InstallReleaseRequest{
+		Chart:    chartStub(),
+		Validate: true,
+	}
+	_, err := rs.InstallRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "ConfigMap/test-cm: admission webhook") {
+		t.Errorf("Expected the errors of the dry run, got %v", err)
+	}
+	if kc.applied {
+		t.Error("Expected no resources to be applied after a failed validation")
+	}
+
+	// Validating a dry run only checks the resources.
+	rs.env.KubeClient = MockEnvironment().KubeClient
+	req.DryRun = true
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if res.Release.Info.Description != "Dry run complete" {
+		t.Errorf("unexpected description: %s", res.Release.Info.Description)
+	}
+}
+
 func TestInstallRelease_ReuseName(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
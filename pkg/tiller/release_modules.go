@@ -40,7 +40,7 @@ type ReleaseModule interface {
 	Create(r *release.Release, req *services.InstallReleaseRequest, env *environment.Environment) error
 	Update(current, target *release.Release, req *services.UpdateReleaseRequest, env *environment.Environment) error
 	Rollback(current, target *release.Release, req *services.RollbackReleaseRequest, env *environment.Environment) error
-	Status(r *release.Release, req *services.GetReleaseStatusRequest, env *environment.Environment) (string, error)
+	Status(r *release.Release, req *services.GetReleaseStatusRequest, env *environment.Environment) ([]*release.ResourceStatus, error)
 	Delete(r *release.Release, req *services.UninstallReleaseRequest, env *environment.Environment) (string, []error)
 }
 
@@ -51,27 +51,44 @@ type LocalReleaseModule struct {
 
 // Create creates a release via kubeclient from provided environment
 func (m *LocalReleaseModule) Create(r *release.Release, req *services.InstallReleaseRequest, env *environment.Environment) error {
-	b := bytes.NewBufferString(r.Manifest)
-	return env.KubeClient.Create(r.Namespace, b, req.Timeout, req.Wait)
+	a, err := adopt(env.KubeClient, r.Namespace, "", r.Manifest, req.Adopt)
+	if err != nil {
+		return err
+	}
+	kc := ownedClient(env.KubeClient, r)
+	if len(a.resources) == 0 {
+		return CreateInWaves(kc, r.Namespace, r.Manifest, req.Timeout, req.Wait)
+	}
+
+	// Patch the adopted resources to their rendered state, and create the others.
+	r.Info.Adopted = a.resources
+	return UpdateInWaves(kc, r.Namespace, a.manifest, r.Manifest, false, false, req.Timeout, req.Wait)
 }
 
 // Update performs an update from current to target release
 func (m *LocalReleaseModule) Update(current, target *release.Release, req *services.UpdateReleaseRequest, env *environment.Environment) error {
-	c := bytes.NewBufferString(current.Manifest)
-	t := bytes.NewBufferString(target.Manifest)
-	return env.KubeClient.Update(target.Namespace, c, t, req.Force, req.Recreate, req.Timeout, req.Wait)
+	a, err := adopt(env.KubeClient, target.Namespace, current.Manifest, target.Manifest, req.Adopt)
+	if err != nil {
+		return err
+	}
+	c := current.Manifest
+	if len(a.resources) > 0 {
+		// Adopted resources are patched as if the current release had
+		// them in their rendered state already.
+		target.Info.Adopted = a.resources
+		c += a.manifest
+	}
+	return UpdateInWaves(ownedClient(env.KubeClient, target), target.Namespace, c, target.Manifest, req.Force, req.Recreate, req.Timeout, req.Wait)
 }
 
 // Rollback performs a rollback from current to target release
 func (m *LocalReleaseModule) Rollback(current, target *release.Release, req *services.RollbackReleaseRequest, env *environment.Environment) error {
-	c := bytes.NewBufferString(current.Manifest)
-	t := bytes.NewBufferString(target.Manifest)
-	return env.KubeClient.Update(target.Namespace, c, t, req.Force, req.Recreate, req.Timeout, req.Wait)
+	return UpdateInWaves(ownedClient(env.KubeClient, target), target.Namespace, current.Manifest, target.Manifest, req.Force, req.Recreate, req.Timeout, req.Wait)
 }
 
-// Status returns kubectl-like formatted status of release objects
-func (m *LocalReleaseModule) Status(r *release.Release, req *services.GetReleaseStatusRequest, env *environment.Environment) (string, error) {
-	return env.KubeClient.Get(r.Namespace, bytes.NewBufferString(r.Manifest))
+// Status returns the state of the release objects in the cluster
+func (m *LocalReleaseModule) Status(r *release.Release, req *services.GetReleaseStatusRequest, env *environment.Environment) ([]*release.ResourceStatus, error) {
+	return GetResourceStatuses(env.KubeClient, r)
 }
 
 // Delete deletes the release and returns manifests that were kept in the deletion process
@@ -80,14 +97,29 @@ func (m *LocalReleaseModule) Delete(rel *release.Release, req *services.Uninstal
 	if err != nil {
 		return rel.Manifest, []error{fmt.Errorf("Could not get apiVersions from Kubernetes: %v", err)}
 	}
-	return DeleteRelease(rel, vs, env.KubeClient)
+	return DeleteRelease(rel, vs, ownedClient(env.KubeClient, rel))
+}
+
+// ownedClient returns the Kubernetes client to apply the resources of a
+// release with, which annotates them with the release and refuses to modify
+// the resources of other releases.
+func ownedClient(kc environment.KubeClient, r *release.Release) environment.KubeClient {
+	if c, ok := kc.(*kube.Client); ok {
+		return c.WithOwner(r.Name, r.Namespace)
+	}
+	return kc
 }
 
 // RemoteReleaseModule is a ReleaseModule which calls Rudder service to operate on a release
 type RemoteReleaseModule struct{}
 
+var errAdoptRemote = errors.New("adopting existing resources is not supported with Rudder")
+
 // Create calls rudder.InstallRelease
 func (m *RemoteReleaseModule) Create(r *release.Release, req *services.InstallReleaseRequest, env *environment.Environment) error {
+	if req.Adopt {
+		return errAdoptRemote
+	}
 	request := &rudderAPI.InstallReleaseRequest{Release: r}
 	_, err := rudder.InstallRelease(request)
 	return err
@@ -95,6 +127,9 @@ func (m *RemoteReleaseModule) Create(r *release.Release, req *services.InstallRe
 
 // Update calls rudder.UpgradeRelease
 func (m *RemoteReleaseModule) Update(current, target *release.Release, req *services.UpdateReleaseRequest, env *environment.Environment) error {
+	if req.Adopt {
+		return errAdoptRemote
+	}
 	upgrade := &rudderAPI.UpgradeReleaseRequest{
 		Current:  current,
 		Target:   target,
@@ -121,10 +156,13 @@ func (m *RemoteReleaseModule) Rollback(current, target *release.Release, req *se
 }
 
 // Status returns status retrieved from rudder.ReleaseStatus
-func (m *RemoteReleaseModule) Status(r *release.Release, req *services.GetReleaseStatusRequest, env *environment.Environment) (string, error) {
+func (m *RemoteReleaseModule) Status(r *release.Release, req *services.GetReleaseStatusRequest, env *environment.Environment) ([]*release.ResourceStatus, error) {
 	statusRequest := &rudderAPI.ReleaseStatusRequest{Release: r}
 	resp, err := rudder.ReleaseStatus(statusRequest)
-	return resp.Info.Status.Resources, err
+	if err != nil {
+		return nil, err
+	}
+	return resp.Info.Status.ResourceStatuses, nil
 }
 
 // Delete calls rudder.DeleteRelease
@@ -149,12 +187,27 @@ func DeleteRelease(rel *release.Release, vs chartutil.VersionSet, kubeClient env
 		return rel.Manifest, []error{fmt.Errorf("corrupted release record. You must manually delete the resources: %s", err)}
 	}
 
+	errs = []error{}
+
+	// Resources are deleted before the resources they depend on. If their
+	// dependencies cannot be ordered, the error is reported and they are
+	// deleted in kind order.
+	waves, err := sortByDependencies(files)
+	if err != nil {
+		log.Printf("uninstall: cannot order the resources of %q by %s: %s", rel.Name, dependsOnAnno, err)
+		errs = append(errs, fmt.Errorf("cannot order the resources by %s, deleting them in kind order: %s", dependsOnAnno, err))
+	} else {
+		files = files[:0]
+		for i := len(waves) - 1; i >= 0; i-- {
+			files = append(files, waves[i]...)
+		}
+	}
+
 	filesToKeep, filesToDelete := filterManifestsToKeep(files)
 	if len(filesToKeep) > 0 {
 		kept = summarizeKeptManifests(filesToKeep)
 	}
 
-	errs = []error{}
 	for _, file := range filesToDelete {
 		b := bytes.NewBufferString(strings.TrimSpace(file.content))
 		if b.Len() == 0 {
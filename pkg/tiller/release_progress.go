@@ -0,0 +1,125 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+// InstallReleaseWithProgress installs a release like InstallRelease, and
+// streams the progress of its hooks and resources before the release itself.
+func (s *ReleaseServer) InstallReleaseWithProgress(req *services.InstallReleaseRequest, stream services.ReleaseService_InstallReleaseWithProgressServer) error {
+	p := newProgressStream(stream.Send)
+	res, err := s.withProgress(p.report).InstallRelease(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return p.finish(&services.ReleaseProgress{Release: res.Release})
+}
+
+// UpdateReleaseWithProgress upgrades a release like UpdateRelease, and
+// streams the progress of its hooks and resources before the release itself.
+func (s *ReleaseServer) UpdateReleaseWithProgress(req *services.UpdateReleaseRequest, stream services.ReleaseService_UpdateReleaseWithProgressServer) error {
+	p := newProgressStream(stream.Send)
+	res, err := s.withProgress(p.report).UpdateRelease(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return p.finish(&services.ReleaseProgress{Release: res.Release})
+}
+
+// RollbackReleaseWithProgress rolls back a release like RollbackRelease, and
+// streams the progress of its hooks and resources before the release itself.
+func (s *ReleaseServer) RollbackReleaseWithProgress(req *services.RollbackReleaseRequest, stream services.ReleaseService_RollbackReleaseWithProgressServer) error {
+	p := newProgressStream(stream.Send)
+	res, err := s.withProgress(p.report).RollbackRelease(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return p.finish(&services.ReleaseProgress{Release: res.Release})
+}
+
+// progressStream sends release events to a client. Once a send fails, the
+// following events are dropped, and the release carries on regardless.
+type progressStream struct {
+	send func(*services.ReleaseProgress) error
+	err  error
+}
+
+func newProgressStream(send func(*services.ReleaseProgress) error) *progressStream {
+	return &progressStream{send: send}
+}
+
+func (p *progressStream) report(e *services.ReleaseEvent) {
+	if p.err == nil {
+		p.err = p.send(&services.ReleaseProgress{Event: e})
+	}
+}
+
+func (p *progressStream) finish(last *services.ReleaseProgress) error {
+	if p.err != nil {
+		return p.err
+	}
+	return p.send(last)
+}
+
+// withProgress returns a copy of the server that reports the progress of
+// hooks, and of the resources its Kubernetes client applies and waits for.
+func (s *ReleaseServer) withProgress(report func(*services.ReleaseEvent)) *ReleaseServer {
+	ps := *s
+	env := *s.env
+	if kc, ok := env.KubeClient.(*kube.Client); ok {
+		env.KubeClient = kc.WithProgress(func(e kube.ProgressEvent) {
+			report(releaseEvent(e))
+		})
+	}
+	ps.env = &env
+	ps.progress = report
+	return &ps
+}
+
+// hookClient returns the Kubernetes client to run hooks with. Hooks report
+// their own progress, rather than that of the resources they create.
+func (s *ReleaseServer) hookClient() environment.KubeClient {
+	if kc, ok := s.env.KubeClient.(*kube.Client); ok && kc.Progress != nil {
+		return kc.WithProgress(nil)
+	}
+	return s.env.KubeClient
+}
+
+// reportEvent sends an event to the client of a streamed release, if any.
+func (s *ReleaseServer) reportEvent(e *services.ReleaseEvent) {
+	if s.progress != nil {
+		s.progress(e)
+	}
+}
+
+// releaseEvent converts a Kubernetes client event to a release event.
+func releaseEvent(e kube.ProgressEvent) *services.ReleaseEvent {
+	phase := services.ReleaseEvent_UNKNOWN
+	switch e.Type {
+	case kube.ResourcesApplied:
+		phase = services.ReleaseEvent_RESOURCES_APPLIED
+	case kube.ResourceWaiting:
+		phase = services.ReleaseEvent_RESOURCE_WAITING
+	case kube.ResourceReady:
+		phase = services.ReleaseEvent_RESOURCE_READY
+	}
+	return &services.ReleaseEvent{Phase: phase, Resource: e.Resource, Message: e.Message}
+}
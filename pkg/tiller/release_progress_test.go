@@ -0,0 +1,120 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+type mockProgressServer struct {
+	sent []*services.ReleaseProgress
+	err  error
+}
+
+func (p *mockProgressServer) Send(m *services.ReleaseProgress) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.sent = append(p.sent, m)
+	return nil
+}
+
+func (p *mockProgressServer) Context() context.Context       { return helm.NewContext() }
+func (p *mockProgressServer) SendMsg(v interface{}) error    { return nil }
+func (p *mockProgressServer) RecvMsg(v interface{}) error    { return nil }
+func (p *mockProgressServer) SendHeader(m metadata.MD) error { return nil }
+func (p *mockProgressServer) SetTrailer(m metadata.MD)       {}
+func (p *mockProgressServer) SetHeader(m metadata.MD) error  { return nil }
+
+func TestInstallReleaseWithProgress(t *testing.T) {
+	rs := rsFixture()
+	stream := &mockProgressServer{}
+
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+				{Name: "templates/hooks", Data: []byte(manifestWithHook)},
+			},
+		},
+	}
+	if err := rs.InstallReleaseWithProgress(req, stream); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	if len(stream.sent) != 3 {
+		t.Fatalf("Expected 3 messages, got %d: %v", len(stream.sent), stream.sent)
+	}
+	started, finished := stream.sent[0].Event, stream.sent[1].Event
+	if started.GetPhase() != services.ReleaseEvent_HOOKS_STARTED || started.Hook != hooks.PostInstall {
+		t.Errorf("Expected post-install hooks to start, got %v", started)
+	}
+	if finished.GetPhase() != services.ReleaseEvent_HOOKS_FINISHED || finished.Hook != hooks.PostInstall {
+		t.Errorf("Expected post-install hooks to finish, got %v", finished)
+	}
+	last := stream.sent[2]
+	if last.Event != nil || last.Release == nil || last.Release.Namespace != "spaced" {
+		t.Errorf("Expected the installed release last, got %v", last)
+	}
+	if rs.progress != nil {
+		t.Error("Expected the server not to keep reporting progress")
+	}
+}
+
+func TestInstallReleaseWithProgressSendError(t *testing.T) {
+	rs := rsFixture()
+	stream := &mockProgressServer{err: errors.New("stream closed")}
+
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Chart:     chartStub(),
+	}
+	if err := rs.InstallReleaseWithProgress(req, stream); err == nil || err.Error() != "stream closed" {
+		t.Errorf("Expected the send error, got %v", err)
+	}
+}
+
+func TestReleaseEvent(t *testing.T) {
+	tests := []struct {
+		in     kube.ProgressEvent
+		expect services.ReleaseEvent_Phase
+	}{
+		{kube.ProgressEvent{Type: kube.ResourcesApplied}, services.ReleaseEvent_RESOURCES_APPLIED},
+		{kube.ProgressEvent{Type: kube.ResourceWaiting}, services.ReleaseEvent_RESOURCE_WAITING},
+		{kube.ProgressEvent{Type: kube.ResourceReady}, services.ReleaseEvent_RESOURCE_READY},
+	}
+	for _, tt := range tests {
+		tt.in.Resource = "Deployment/web"
+		tt.in.Message = "1 of 2 new pods ready"
+		e := releaseEvent(tt.in)
+		if e.Phase != tt.expect || e.Resource != tt.in.Resource || e.Message != tt.in.Message {
+			t.Errorf("Expected %v for %v, got %v", tt.expect, tt.in, e)
+		}
+	}
+}
@@ -121,7 +121,7 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 
 	// pre-rollback hooks
 	if !req.DisableHooks {
-		if err := s.execHook(targetRelease.Hooks, targetRelease.Name, targetRelease.Namespace, hooks.PreRollback, req.Timeout); err != nil {
+		if err := s.execHook(targetRelease, hooks.PreRollback, req.Timeout); err != nil {
 			return res, err
 		}
 	} else {
@@ -129,7 +129,7 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 	}
 
 	if err := s.ReleaseModule.Rollback(currentRelease, targetRelease, req, s.env); err != nil {
-		msg := fmt.Sprintf("Rollback %q failed: %s", targetRelease.Name, err)
+		msg := s.redactor(targetRelease).Text(fmt.Sprintf("Rollback %q failed: %s", targetRelease.Name, err))
 		s.Log("warning: %s", msg)
 		currentRelease.Info.Status.Code = release.Status_SUPERSEDED
 		targetRelease.Info.Status.Code = release.Status_FAILED
@@ -141,7 +141,7 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 
 	// post-rollback hooks
 	if !req.DisableHooks {
-		if err := s.execHook(targetRelease.Hooks, targetRelease.Name, targetRelease.Namespace, hooks.PostRollback, req.Timeout); err != nil {
+		if err := s.execHook(targetRelease, hooks.PostRollback, req.Timeout); err != nil {
 			return res, err
 		}
 	}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"path"
 	"regexp"
 	"strings"
@@ -83,6 +84,8 @@ type ReleaseServer struct {
 	env           *environment.Environment //这里面包括kube client //k8s.io/helm/pkg/kube/client.go
 	clientset     internalclientset.Interface
 	Log           func(string, ...interface{})
+	// progress, if set, receives the events of a streamed release.
+	progress func(*services.ReleaseEvent)
 }
 
 // NewReleaseServer creates a new release server.
@@ -244,6 +247,43 @@ func capabilities(disc discovery.DiscoveryInterface) (*chartutil.Capabilities, e
 	}, nil
 }
 
+// requestCapabilities builds the Capabilities a release is rendered against,
+// applying the override carried by a dry-run request on top of the
+// discovered ones.
+func (s *ReleaseServer) requestCapabilities(dryRun bool, override *services.Capabilities) (*chartutil.Capabilities, error) {
+	if override != nil && !dryRun {
+		return nil, chartutil.ErrCapabilitiesNotDryRun
+	}
+	caps, err := capabilities(s.clientset.Discovery())
+	if err != nil {
+		return nil, err
+	}
+	o, err := capabilitiesFromProto(override)
+	if err != nil {
+		return nil, err
+	}
+	return chartutil.OverrideCapabilities(caps, o), nil
+}
+
+// capabilitiesFromProto converts the Capabilities override of a request.
+func capabilitiesFromProto(pc *services.Capabilities) (*chartutil.Capabilities, error) {
+	if pc == nil {
+		return nil, nil
+	}
+	caps := &chartutil.Capabilities{TillerVersion: pc.TillerVersion}
+	if len(pc.ApiVersions) > 0 {
+		caps.APIVersions = chartutil.NewVersionSet(pc.ApiVersions...)
+	}
+	if pc.KubeVersion != "" {
+		kv, err := chartutil.ParseKubeVersion(pc.KubeVersion)
+		if err != nil {
+			return nil, err
+		}
+		caps.KubeVersion = kv
+	}
+	return caps, nil
+}
+
 // GetVersionSet retrieves a set of available k8s API versions
 //获取K8s支持api版本组
 func GetVersionSet(client discovery.ServerGroupsInterface) (chartutil.VersionSet, error) {
@@ -325,12 +365,21 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 		return nil, b, "", err
 	}
 
+	// Resources that depend on others come after them, so that the manifest
+	// lists the resources in the order they are applied.
+	waves, err := sortByDependencies(manifests)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
 	// Aggregate all valid manifests into one big doc.
 	//将manifest存放在buffer中
 	b := bytes.NewBuffer(nil)
-	for _, m := range manifests {
-		b.WriteString("\n---\n# Source: " + m.name + "\n")
-		b.WriteString(m.content)
+	for _, wave := range waves {
+		for _, m := range wave {
+			b.WriteString("\n---\n# Source: " + m.name + "\n")
+			b.WriteString(m.content)
+		}
 	}
 
 	return hooks, b, notes, nil
@@ -347,16 +396,28 @@ func (s *ReleaseServer) recordRelease(r *release.Release, reuse bool) {
 	}
 }
 
-func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook string, timeout int64) error {
-	kubeCli := s.env.KubeClient
+// redactor returns a Redactor that masks the sensitive values of a release in
+// log messages and release descriptions.
+func (s *ReleaseServer) redactor(r *release.Release) *relutil.Redactor {
+	red, err := relutil.NewRedactor(r)
+	if err != nil {
+		s.Log("warning: cannot mask the sensitive values of %s: %s", r.Name, err)
+		return &relutil.Redactor{}
+	}
+	return red
+}
+
+func (s *ReleaseServer) execHook(r *release.Release, hook string, timeout int64) error {
+	kubeCli := s.hookClient()
 	code, ok := events[hook]
 	if !ok {
 		return fmt.Errorf("unknown hook %s", hook)
 	}
 
-	s.Log("executing %d %s hooks for %s", len(hs), hook, name)
+	name, namespace := r.Name, r.Namespace
+	s.Log("executing %d %s hooks for %s", len(r.Hooks), hook, name)
 	executingHooks := []*release.Hook{}
-	for _, h := range hs {
+	for _, h := range r.Hooks {
 		for _, e := range h.Events {
 			if e == code {
 				executingHooks = append(executingHooks, h)
@@ -365,25 +426,35 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 	}
 
 	executingHooks = sortByHookWeight(executingHooks)
+	if len(executingHooks) > 0 {
+		s.reportEvent(&services.ReleaseEvent{
+			Phase:   services.ReleaseEvent_HOOKS_STARTED,
+			Hook:    hook,
+			Message: fmt.Sprintf("running %d %s hook(s)", len(executingHooks), hook),
+		})
+	}
 
 	for _, h := range executingHooks {
 
 		b := bytes.NewBufferString(h.Manifest)
 		if err := kubeCli.Create(namespace, b, timeout, false); err != nil {
-			s.Log("warning: Release %s %s %s failed: %s", name, hook, h.Path, err)
+			s.Log("warning: Release %s %s %s failed: %s", name, hook, h.Path, s.redactor(r).Text(err.Error()))
 			return err
 		}
 		// No way to rewind a bytes.Buffer()?
 		b.Reset()
 		b.WriteString(h.Manifest)
 		if err := kubeCli.WatchUntilReady(namespace, b, timeout, false); err != nil {
-			s.Log("warning: Release %s %s %s could not complete: %s", name, hook, h.Path, err)
+			s.Log("warning: Release %s %s %s could not complete: %s", name, hook, h.Path, s.redactor(r).Text(err.Error()))
 			return err
 		}
 		h.LastRun = timeconv.Now()
 	}
 
 	s.Log("hooks complete for %s %s", hook, name)
+	if len(executingHooks) > 0 {
+		s.reportEvent(&services.ReleaseEvent{Phase: services.ReleaseEvent_HOOKS_FINISHED, Hook: hook})
+	}
 	return nil
 }
 
@@ -408,13 +479,35 @@ func validateManifest(c environment.KubeClient, ns string, manifest []byte) erro
 		fmt.Println("name:\n" + v.Name)
 		fmt.Println("namespace:\n" + v.Namespace)
 		fmt.Println("source:\n" + v.Source)
-		fmt.Println("versionObject:\n", v.VersionedObject)
-		fmt.Println("object:\n", v.Object)
 		fmt.Println("\n\n=================================")
 	}
 	return err
 }
 
+// validateOnServer submits the manifest of a release to the API server as a
+// dry run, against the manifest of the release it upgrades, if any, and the
+// resources it adopts, as they are applied.
+func (s *ReleaseServer) validateOnServer(r, current *release.Release, adoptAll bool) error {
+	var c string
+	if current != nil {
+		c = current.Manifest
+	}
+	a, err := adopt(s.env.KubeClient, r.Namespace, c, r.Manifest, adoptAll)
+	if err != nil {
+		return err
+	}
+	var original io.Reader
+	if current != nil || len(a.resources) > 0 {
+		original = bytes.NewBufferString(c + a.manifest)
+	}
+	s.Log("validating %s with a server-side dry run", r.Name)
+	err = ownedClient(s.env.KubeClient, r).ValidateOnServer(r.Namespace, original, bytes.NewBufferString(r.Manifest))
+	if err != nil {
+		return fmt.Errorf("validation of %s failed: %s", r.Name, s.redactor(r).Text(err.Error()))
+	}
+	return nil
+}
+
 //检测release名是否有效
 func validateReleaseName(releaseName string) error {
 
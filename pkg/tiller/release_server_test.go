@@ -30,6 +30,7 @@ import (
 	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/fake"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -219,6 +220,29 @@ func TestGetVersionSet(t *testing.T) {
 	}
 }
 
+func TestCapabilitiesFromProto(t *testing.T) {
+	caps, err := capabilitiesFromProto(&services.Capabilities{
+		ApiVersions: []string{"v1", "batch/v2alpha1"},
+		KubeVersion: "v1.8.3",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps.KubeVersion.Minor != "8" || !caps.APIVersions.Has("batch/v2alpha1") {
+		t.Errorf("Unexpected capabilities: %+v", caps)
+	}
+	if caps.TillerVersion != nil {
+		t.Errorf("Expected no Tiller version, got %v", caps.TillerVersion)
+	}
+
+	if _, err := capabilitiesFromProto(&services.Capabilities{KubeVersion: "latest"}); err == nil {
+		t.Error("Expected an invalid Kubernetes version to fail")
+	}
+	if caps, err := capabilitiesFromProto(nil); caps != nil || err != nil {
+		t.Errorf("Expected no override, got %v, %v", caps, err)
+	}
+}
+
 func TestUniqName(t *testing.T) {
 	rs := rsFixture()
 
@@ -324,6 +348,35 @@ func (h *hookFailingKubeClient) WatchUntilReady(ns string, r io.Reader, timeout
 	return errors.New("Failed watch")
 }
 
+func newValidationFailingKubeClient() *validationFailingKubeClient {
+	return &validationFailingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout},
+	}
+}
+
+// validationFailingKubeClient rejects every server-side dry run, and records
+// whether resources were applied anyway.
+type validationFailingKubeClient struct {
+	environment.PrintingKubeClient
+	applied bool
+}
+
+func (v *validationFailingKubeClient) ValidateOnServer(ns string, originalReader, targetReader io.Reader) error {
+	return kube.ValidationError{
+		{Resource: "ConfigMap/test-cm", Err: errors.New(`admission webhook "policy.example.com" denied the request`)},
+	}
+}
+
+func (v *validationFailingKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	v.applied = true
+	return nil
+}
+
+func (v *validationFailingKubeClient) Update(ns string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
+	v.applied = true
+	return nil
+}
+
 type mockListServer struct {
 	val *services.ListReleasesResponse
 }
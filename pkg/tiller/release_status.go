@@ -17,13 +17,17 @@ limitations under the License.
 package tiller
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"text/tabwriter"
+	"time"
 
 	ctx "golang.org/x/net/context"
 
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
 )
 
 // GetReleaseStatus gets the status information for a named release.
@@ -64,7 +68,7 @@ func (s *ReleaseServer) GetReleaseStatus(c ctx.Context, req *services.GetRelease
 
 	// Ok, we got the status of the release as we had jotted down, now we need to match the
 	// manifest we stashed away with reality from the cluster.
-	resp, err := s.ReleaseModule.Status(rel, req, s.env)
+	statuses, err := s.ReleaseModule.Status(rel, req, s.env)
 	if sc == release.Status_DELETED || sc == release.Status_FAILED {
 		// Skip errors if this is already deleted or failed.
 		return statusResp, nil
@@ -72,6 +76,85 @@ func (s *ReleaseServer) GetReleaseStatus(c ctx.Context, req *services.GetRelease
 		s.Log("warning: Get for %s failed: %v", rel.Name, err)
 		return nil, err
 	}
-	rel.Info.Status.Resources = resp
+	rel.Info.Status.ResourceStatuses = statuses
+	rel.Info.Status.Resources = formatResourceStatuses(statuses)
 	return statusResp, nil
 }
+
+// GetResourceStatuses returns the state of the resources of a release in the
+// cluster. It allows Rudder to report the status of a release.
+func GetResourceStatuses(kc environment.KubeClient, r *release.Release) ([]*release.ResourceStatus, error) {
+	statuses, err := kc.Status(r.Namespace, bytes.NewBufferString(r.Manifest))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*release.ResourceStatus, 0, len(statuses))
+	for _, s := range statuses {
+		res = append(res, &release.ResourceStatus{
+			ApiVersion: s.APIVersion,
+			Kind:       s.Kind,
+			Namespace:  s.Namespace,
+			Name:       s.Name,
+			Ready:      s.Ready,
+			Message:    s.Message,
+			Age:        int64(s.Age / time.Second),
+			Missing:    s.Missing,
+		})
+	}
+	return res, nil
+}
+
+// formatResourceStatuses renders the state of resources as a table per
+// kind, followed by the missing resources.
+func formatResourceStatuses(statuses []*release.ResourceStatus) string {
+	var kinds []string
+	byKind := map[string][]*release.ResourceStatus{}
+	var missing []*release.ResourceStatus
+	for _, s := range statuses {
+		if s.Missing {
+			missing = append(missing, s)
+			continue
+		}
+		kind := s.ApiVersion + "/" + s.Kind
+		if _, ok := byKind[kind]; !ok {
+			kinds = append(kinds, kind)
+		}
+		byKind[kind] = append(byKind[kind], s)
+	}
+
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, kind := range kinds {
+		fmt.Fprintf(w, "==> %s\nNAME\tREADY\tMESSAGE\tAGE\n", kind)
+		for _, s := range byKind[kind] {
+			fmt.Fprintf(w, "%s\t%t\t%s\t%s\n", s.Name, s.Ready, s.Message, formatAge(s.Age))
+		}
+		fmt.Fprintln(w)
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(w, "==> MISSING\nKIND\tNAME\n")
+		for _, s := range missing {
+			fmt.Fprintf(w, "%s\t%s\n", s.Kind, s.Name)
+		}
+	}
+	w.Flush()
+	return b.String()
+}
+
+// formatAge renders a number of seconds the way kubectl renders ages, as in
+// "45s", "12m" or "3d".
+func formatAge(seconds int64) string {
+	switch {
+	case seconds < 0:
+		return "<unknown>"
+	case seconds < 120:
+		return fmt.Sprintf("%ds", seconds)
+	case seconds < 3*60*60:
+		return fmt.Sprintf("%dm", seconds/60)
+	case seconds < 2*24*60*60:
+		return fmt.Sprintf("%dh", seconds/(60*60))
+	case seconds < 2*365*24*60*60:
+		return fmt.Sprintf("%dd", seconds/(24*60*60))
+	}
+	return fmt.Sprintf("%dy", seconds/(365*24*60*60))
+}
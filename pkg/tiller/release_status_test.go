@@ -17,13 +17,29 @@ limitations under the License.
 package tiller
 
 import (
+	"io"
+	"os"
+	"reflect"
 	"testing"
+	"time"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
 )
 
+// statusKubeClient reports fixed states for the resources of a release.
+type statusKubeClient struct {
+	environment.PrintingKubeClient
+	statuses []kube.ResourceStatus
+}
+
+func (k *statusKubeClient) Status(ns string, r io.Reader) ([]kube.ResourceStatus, error) {
+	return k.statuses, nil
+}
+
 func TestGetReleaseStatus(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -63,3 +79,68 @@ func TestGetReleaseStatusDeleted(t *testing.T) {
 		t.Errorf("Expected %d, got %d", release.Status_DELETED, res.Info.Status.Code)
 	}
 }
+
+func TestGetReleaseStatusResources(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.KubeClient = &statusKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout},
+		statuses: []kube.ResourceStatus{
+			{APIVersion: "extensions/v1beta1", Kind: "Deployment", Namespace: "default", Name: "web", Message: "deployment web has 1/2 ready replicas", Age: 90 * time.Minute},
+			{APIVersion: "v1", Kind: "Service", Namespace: "default", Name: "web", Ready: true, Age: 3 * 24 * time.Hour},
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "web-config", Message: "not found", Missing: true},
+		},
+	}
+	rel := releaseStub()
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.GetReleaseStatus(c, &services.GetReleaseStatusRequest{Name: rel.Name, Version: 1})
+	if err != nil {
+		t.Fatalf("Error getting release status: %s", err)
+	}
+
+	expect := []*release.ResourceStatus{
+		{ApiVersion: "extensions/v1beta1", Kind: "Deployment", Namespace: "default", Name: "web", Message: "deployment web has 1/2 ready replicas", Age: 5400},
+		{ApiVersion: "v1", Kind: "Service", Namespace: "default", Name: "web", Ready: true, Age: 259200},
+		{ApiVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "web-config", Message: "not found", Missing: true},
+	}
+	if !reflect.DeepEqual(res.Info.Status.ResourceStatuses, expect) {
+		t.Errorf("Expected %v, got %v", expect, res.Info.Status.ResourceStatuses)
+	}
+
+	table := `==> extensions/v1beta1/Deployment
+NAME  READY  MESSAGE                                AGE
+web   false  deployment web has 1/2 ready replicas  90m
+
+==> v1/Service
+NAME  READY  MESSAGE  AGE
+web   true            3d
+
+==> MISSING
+KIND       NAME
+ConfigMap  web-config
+`
+	if res.Info.Status.Resources != table {
+		t.Errorf("Expected\n%s\ngot\n%s", table, res.Info.Status.Resources)
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	for seconds, expect := range map[int64]string{
+		-1:        "<unknown>",
+		0:         "0s",
+		119:       "119s",
+		120:       "2m",
+		10799:     "179m",
+		10800:     "3h",
+		172800:    "2d",
+		63072000:  "2y",
+		630720000: "20y",
+	} {
+		if got := formatAge(seconds); got != expect {
+			t.Errorf("Expected %d seconds to read %q, got %q", seconds, expect, got)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"testing"
 
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -176,3 +177,57 @@ func TestUninstallReleaseNoHooks(t *testing.T) {
 		t.Errorf("Expected LastRun to be zero, got %d.", res.Release.Hooks[0].LastRun.Seconds)
 	}
 }
+
+func TestDeleteReleaseCustomResources(t *testing.T) {
+	rel := releaseStub()
+	rel.Manifest = `apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.stable.example.com
+---
+apiVersion: v1
+kind: Deployment
+metadata:
+  name: crontab-operator
+---
+apiVersion: stable.example.com/v1
+kind: CronTab
+metadata:
+  name: nightly`
+
+	vs := chartutil.NewVersionSet("v1", "apiextensions.k8s.io/v1beta1", "stable.example.com/v1")
+	kc := newRecordingKubeClient()
+	if _, errs := DeleteRelease(rel, vs, kc); len(errs) > 0 {
+		t.Fatalf("Failed delete: %v", errs)
+	}
+	want := "CronTab/nightly Deployment/crontab-operator CustomResourceDefinition/crontabs.stable.example.com"
+	if got := strings.Join(kc.deleted, " "); got != want {
+		t.Errorf("Expected deletions %q, got %q", want, got)
+	}
+}
+
+func TestDeleteReleaseReportsBrokenDependencies(t *testing.T) {
+	rel := releaseStub()
+	rel.Manifest = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: Deployment
+metadata:
+  name: web
+  annotations:
+    "helm.sh/depends-on": "Secret/missing"`
+
+	kc := newRecordingKubeClient()
+	_, errs := DeleteRelease(rel, chartutil.DefaultVersionSet, kc)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "Secret/missing") {
+		t.Errorf("Expected the dangling dependency to be reported, got %v", errs)
+	}
+	// The resources are still deleted, in kind order.
+	want := "Service/web Deployment/web"
+	if got := strings.Join(kc.deleted, " "); got != want {
+		t.Errorf("Expected deletions %q, got %q", want, got)
+	}
+}
@@ -93,7 +93,7 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 		Revision:  int(revision),
 	}
 
-	caps, err := capabilities(s.clientset.Discovery())
+	caps, err := s.requestCapabilities(req.DryRun, req.Capabilities)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -134,6 +134,12 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.Release, req *services.UpdateReleaseRequest) (*services.UpdateReleaseResponse, error) {
 	res := &services.UpdateReleaseResponse{Release: updatedRelease}
 
+	if req.Validate {
+		if err := s.validateOnServer(updatedRelease, originalRelease, req.Adopt); err != nil {
+			return res, err
+		}
+	}
+
 	if req.DryRun {
 		s.Log("dry run for %s", updatedRelease.Name)
 		res.Release.Info.Description = "Dry run complete"
@@ -142,14 +148,14 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 
 	// pre-upgrade hooks
 	if !req.DisableHooks {
-		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PreUpgrade, req.Timeout); err != nil {
+		if err := s.execHook(updatedRelease, hooks.PreUpgrade, req.Timeout); err != nil {
 			return res, err
 		}
 	} else {
 		s.Log("update hooks disabled for %s", req.Name)
 	}
 	if err := s.ReleaseModule.Update(originalRelease, updatedRelease, req, s.env); err != nil {
-		msg := fmt.Sprintf("Upgrade %q failed: %s", updatedRelease.Name, err)
+		msg := s.redactor(updatedRelease).Text(fmt.Sprintf("Upgrade %q failed: %s", updatedRelease.Name, err))
 		s.Log("warning: %s", msg)
 		originalRelease.Info.Status.Code = release.Status_SUPERSEDED
 		updatedRelease.Info.Status.Code = release.Status_FAILED
@@ -161,7 +167,7 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 
 	// post-upgrade hooks
 	if !req.DisableHooks {
-		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PostUpgrade, req.Timeout); err != nil {
+		if err := s.execHook(updatedRelease, hooks.PostUpgrade, req.Timeout); err != nil {
 			return res, err
 		}
 	}
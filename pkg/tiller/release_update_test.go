@@ -169,6 +169,36 @@ func TestUpdateRelease_ReuseValues(t *testing.T) {
 	}
 }
 
+func TestUpdateRelease_ReuseValuesMergesLists(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Chart.Values = &chart.Config{Raw: "$merge:\n  args: append\nargs:\n- --verbose\n"}
+	rel.Config = &chart.Config{Raw: "args:\n- --color\n"}
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name: rel.Name,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/args", Data: []byte("args: {{ .Values.args }}")},
+			},
+		},
+		Values:      &chart.Config{Raw: "args:\n- --debug\n"},
+		ReuseValues: true,
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	// The new list is appended to the reused one, following the strategy
+	// declared in the old chart's values.
+	if expect := "args: [--verbose --color --debug]"; !strings.Contains(res.Release.Manifest, expect) {
+		t.Errorf("Expected manifest to contain %q, got %q", expect, res.Release.Manifest)
+	}
+}
+
 func TestUpdateRelease_ResetReuseValues(t *testing.T) {
 	// This verifies that when both reset and reuse are set, reset wins.
 	c := helm.NewContext()
@@ -239,6 +269,43 @@ func TestUpdateReleaseFailure(t *testing.T) {
 	}
 }
 
+func TestUpdateRelease_Validate(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	kc := newValidationFailingKubeClient()
+	rs.env.KubeClient = kc
+
+	req := &services.UpdateReleaseRequest{
+		Name:     rel.Name,
+		Validate: true,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/something", Data: []byte("hello: world")},
+			},
+		},
+	}
+
+	if _, err := rs.UpdateRelease(c, req); err == nil || !strings.Contains(err.Error(), "ConfigMap/test-cm: admission webhook") {
+		t.Errorf("Expected the errors of the dry run, got %v", err)
+	}
+	if kc.applied {
+		t.Error("Expected no resources to be applied after a failed validation")
+	}
+	if _, err := rs.env.Releases.Get(rel.Name, rel.Version+1); err == nil {
+		t.Error("Expected no new revision after a failed validation")
+	}
+	current, err := rs.env.Releases.Get(rel.Name, rel.Version)
+	if err != nil {
+		t.Fatalf("Expected to be able to get the current release: %s", err)
+	}
+	if code := current.Info.Status.Code; code != release.Status_DEPLOYED {
+		t.Errorf("Expected the current release to stay DEPLOYED. Got %v", code)
+	}
+}
+
 func TestUpdateReleaseNoHooks(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
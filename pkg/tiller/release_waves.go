@@ -0,0 +1,146 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/ghodss/yaml"
+
+	util "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+// CreateInWaves creates the resources of a release manifest.
+//
+// When some of the resources depend on others with the helm.sh/depends-on
+// annotation, they are created one wave at a time, and each wave is waited for
+// until it is ready before the next one is created. The last wave is only
+// waited for when wait is set.
+func CreateInWaves(kc environment.KubeClient, namespace, manifest string, timeout int64, wait bool) error {
+	waves, err := manifestWaves(manifest)
+	if err != nil {
+		return err
+	}
+	if len(waves) <= 1 {
+		return kc.Create(namespace, bytes.NewBufferString(manifest), timeout, wait)
+	}
+	for i, w := range waves {
+		if err := kc.Create(namespace, bytes.NewBufferString(w), timeout, wait || i < len(waves)-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateInWaves updates the resources of a release from the current manifest
+// to the target one, in the waves of the target manifest as CreateInWaves
+// does. The resources that the target manifest no longer has are deleted once
+// all the waves are applied.
+func UpdateInWaves(kc environment.KubeClient, namespace, current, target string, force, recreate bool, timeout int64, wait bool) error {
+	waves, err := manifestWaves(target)
+	if err != nil {
+		return err
+	}
+	if len(waves) <= 1 {
+		c := bytes.NewBufferString(current)
+		t := bytes.NewBufferString(target)
+		return kc.Update(namespace, c, t, force, recreate, timeout, wait)
+	}
+
+	existing := map[string]string{}
+	for _, m := range util.SplitManifests(current) {
+		if name, _, ok := manifestResource(m); ok {
+			existing[name] += "\n---\n" + m
+		}
+	}
+
+	for i, w := range waves {
+		var c bytes.Buffer
+		for _, m := range util.SplitManifests(w) {
+			if name, _, ok := manifestResource(m); ok {
+				c.WriteString(existing[name])
+				delete(existing, name)
+			}
+		}
+
+		t := bytes.NewBufferString(w)
+		shouldWait := wait || i < len(waves)-1
+		if c.Len() == 0 {
+			err = kc.Create(namespace, t, timeout, shouldWait)
+		} else {
+			err = kc.Update(namespace, &c, t, force, recreate, timeout, shouldWait)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(existing) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(existing))
+	for name := range existing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b bytes.Buffer
+	for _, name := range names {
+		b.WriteString(existing[name])
+	}
+	if err := kc.Delete(namespace, &b); err != nil {
+		log.Printf("warning: failed to delete the resources removed from the release: %s", err)
+	}
+	return nil
+}
+
+// manifestWaves splits a release manifest into the waves in which its
+// resources are applied, ordered by kind within each wave.
+func manifestWaves(m string) ([]string, error) {
+	docs := util.SplitManifests(m)
+	keys := make([]string, 0, len(docs))
+	for k := range docs {
+		keys = append(keys, k)
+	}
+	sort.Sort(util.BySplitManifestsOrder(keys))
+
+	manifests := make([]manifest, 0, len(docs))
+	for _, k := range keys {
+		var head util.SimpleHead
+		if err := yaml.Unmarshal([]byte(docs[k]), &head); err != nil {
+			return nil, fmt.Errorf("YAML parse error on %s: %s", k, err)
+		}
+		manifests = append(manifests, manifest{name: k, content: docs[k], head: &head})
+	}
+
+	waves, err := sortByDependencies(sortByKind(manifests, InstallOrder))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(waves))
+	for i, wave := range waves {
+		var b bytes.Buffer
+		for _, m := range wave {
+			b.WriteString("\n---\n" + m.content)
+		}
+		out[i] = b.String()
+	}
+	return out, nil
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2017 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	util "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+// recordingKubeClient records the resources it is asked to apply, as in
+// "create wait: ConfigMap/a Service/b".
+type recordingKubeClient struct {
+	environment.PrintingKubeClient
+	calls   []string
+	deleted []string
+}
+
+func newRecordingKubeClient() *recordingKubeClient {
+	return &recordingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: os.Stdout},
+	}
+}
+
+func readResources(r io.Reader) string {
+	b, _ := ioutil.ReadAll(r)
+	docs := util.SplitManifests(string(b))
+	var names []string
+	for i := 0; i < len(docs); i++ {
+		if name, _, ok := manifestResource(docs[fmt.Sprintf("manifest-%d", i)]); ok {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+func waitLabel(shouldWait bool) string {
+	if shouldWait {
+		return " wait"
+	}
+	return ""
+}
+
+func (k *recordingKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	k.calls = append(k.calls, "create"+waitLabel(shouldWait)+": "+readResources(r))
+	return nil
+}
+
+func (k *recordingKubeClient) Update(ns string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
+	k.calls = append(k.calls, "update"+waitLabel(shouldWait)+": "+readResources(originalReader)+" => "+readResources(modifiedReader))
+	return nil
+}
+
+func (k *recordingKubeClient) Delete(ns string, r io.Reader) error {
+	names := readResources(r)
+	k.calls = append(k.calls, "delete: "+names)
+	k.deleted = append(k.deleted, names)
+	return nil
+}
+
+var manifestWithDependencies = `apiVersion: v1
+kind: Deployment
+metadata:
+  name: web
+  annotations:
+    helm.sh/depends-on: StatefulSet/db
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+---
+apiVersion: v1
+kind: StatefulSet
+metadata:
+  name: db
+  annotations:
+    helm.sh/depends-on: ConfigMap/config`
+
+var manifestServiceDB = `apiVersion: v1
+kind: Service
+metadata:
+  name: db`
+
+func TestCreateInWaves(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wait     bool
+		expected []string
+	}{
+		{
+			name:     "without dependencies",
+			manifest: manifestServiceDB,
+			expected: []string{"create: Service/db"},
+		},
+		{
+			name:     "with dependencies",
+			manifest: manifestWithDependencies,
+			expected: []string{"create wait: ConfigMap/config", "create wait: StatefulSet/db", "create: Deployment/web"},
+		},
+		{
+			name:     "waiting for the release",
+			manifest: manifestWithDependencies,
+			wait:     true,
+			expected: []string{"create wait: ConfigMap/config", "create wait: StatefulSet/db", "create wait: Deployment/web"},
+		},
+	}
+	for _, tt := range tests {
+		kc := newRecordingKubeClient()
+		if err := CreateInWaves(kc, "default", tt.manifest, 300, tt.wait); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if !reflect.DeepEqual(kc.calls, tt.expected) {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, kc.calls)
+		}
+	}
+}
+
+func TestManifestWavesKeepManifestOrder(t *testing.T) {
+	// More than ten resources of a kind, named against their order in the
+	// manifest.
+	var docs, expected []string
+	for i := 11; i >= 0; i-- {
+		name := fmt.Sprintf("config-%c", 'a'+i)
+		docs = append(docs, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: "+name)
+		expected = append(expected, "ConfigMap/"+name)
+	}
+	waves, err := manifestWaves(strings.Join(docs, "\n---\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(waves) != 1 {
+		t.Fatalf("expected 1 wave, got %d", len(waves))
+	}
+	if got := readResources(strings.NewReader(waves[0])); got != strings.Join(expected, " ") {
+		t.Errorf("expected %q, got %q", strings.Join(expected, " "), got)
+	}
+}
+
+func TestUpdateInWaves(t *testing.T) {
+	current := manifestServiceDB + "\n---\n" + `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config`
+
+	kc := newRecordingKubeClient()
+	if err := UpdateInWaves(kc, "default", current, manifestWithDependencies, false, false, 300, false); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"update wait: ConfigMap/config => ConfigMap/config",
+		"create wait: StatefulSet/db",
+		"create: Deployment/web",
+		"delete: Service/db",
+	}
+	if !reflect.DeepEqual(kc.calls, expected) {
+		t.Errorf("Expected %q, got %q", expected, kc.calls)
+	}
+
+	kc = newRecordingKubeClient()
+	if err := UpdateInWaves(kc, "default", current, manifestServiceDB, false, false, 300, true); err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{"update wait: Service/db ConfigMap/config => Service/db"}
+	if !reflect.DeepEqual(kc.calls, expected) {
+		t.Errorf("Expected %q, got %q", expected, kc.calls)
+	}
+}
+
+func TestInstallReleaseInWaves(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := newRecordingKubeClient()
+	rs.env.KubeClient = kc
+
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/web", Data: []byte(manifestWithDependencies)},
+			},
+		},
+	}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	expected := []string{"create wait: ConfigMap/config", "create wait: StatefulSet/db", "create: Deployment/web"}
+	if !reflect.DeepEqual(kc.calls, expected) {
+		t.Errorf("Expected %q, got %q", expected, kc.calls)
+	}
+	if got := readResources(strings.NewReader(res.Release.Manifest)); got != "ConfigMap/config StatefulSet/db Deployment/web" {
+		t.Errorf("Expected the manifest in dependency order, got %q", got)
+	}
+}
+
+func TestInstallReleaseDependencyCycle(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := newRecordingKubeClient()
+	rs.env.KubeClient = kc
+
+	req := &services.InstallReleaseRequest{
+		Namespace: "spaced",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/web", Data: []byte(strings.Replace(manifestWithDependencies, "name: config", "name: config\n  annotations:\n    helm.sh/depends-on: Deployment/web", 1))},
+			},
+		},
+	}
+	_, err := rs.InstallRelease(c, req)
+	if err == nil {
+		t.Fatal("Expected the install to fail on the dependency cycle")
+	}
+	if !strings.Contains(err.Error(), "dependency cycle between resources: ") {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if len(kc.calls) > 0 {
+		t.Errorf("Expected nothing to be applied, got %q", kc.calls)
+	}
+}